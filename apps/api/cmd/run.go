@@ -6,11 +6,13 @@ import (
 	"github.com/go-chi/chi/v5"
 	"github.com/cloudy-clip/api/internal/common/database"
 	"github.com/cloudy-clip/api/internal/orchestrator"
+	"github.com/cloudy-clip/api/internal/retention"
 )
 
 func Run(conf *orchestrator.Config, mux *chi.Mux) error {
 	database.InitializeDatabaseClient()
 	orchestrator.SetupControllerEndpoints(conf, mux)
+	retention.StartRetentionPurgeJob()
 
 	// Prepare server with CloudFlare recommendation timeouts config.
 	// See: https://blog.cloudflare.com/the-complete-guide-to-golang-net-http-timeouts/
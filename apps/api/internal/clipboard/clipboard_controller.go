@@ -0,0 +1,407 @@
+package clipboard
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/cloudy-clip/api/internal/clipboard/dto"
+	"github.com/cloudy-clip/api/internal/clipboard/model"
+	"github.com/cloudy-clip/api/internal/common/environment"
+	"github.com/cloudy-clip/api/internal/common/exception"
+	_http "github.com/cloudy-clip/api/internal/common/http"
+	"github.com/cloudy-clip/api/internal/common/http/middleware/context"
+	"github.com/cloudy-clip/api/internal/common/jwt"
+	"github.com/cloudy-clip/api/internal/common/logger"
+	"github.com/cloudy-clip/api/internal/entitlement"
+	_entitlementModel "github.com/cloudy-clip/api/internal/entitlement/model"
+	"github.com/go-chi/chi/v5"
+	"github.com/pkg/errors"
+)
+
+const (
+	// Image uploads are exempted from the global request size limit and timeout, see `orchestrator`.
+	ImageUploadEndpoint = "/api/v1/clipboard/images"
+	imageUploadTimeout  = 2 * time.Minute
+	maxSearchTextLength = 256
+)
+
+var (
+	clipboardService          *ClipboardService
+	clipboardRepository       *ClipboardRepository
+	clipboardControllerLogger *logger.Logger
+)
+
+func GetClipboardService() *ClipboardService {
+	return clipboardService
+}
+
+func GetClipboardRepository() *ClipboardRepository {
+	return clipboardRepository
+}
+
+func SetupClipboardControllerEndpoints(parentRouter chi.Router) {
+	clipboardRepository = NewClipboardRepository()
+	clipboardService = NewClipboardService()
+	clipboardControllerLogger = logger.NewLogger(
+		"ClipboardController", slog.Level(environment.Config.ApplicationLogLevel),
+	)
+
+	parentRouter.Route("/v1/clipboard/items", func(v1Router chi.Router) {
+		v1Router.Group(func(router chi.Router) {
+			router.Use(
+				context.CallSiteMiddleware("handleClipboardItemCreation"),
+				jwt.JwtVerifierMiddleware(clipboardControllerLogger),
+				entitlement.EntitlementGuardMiddleware(clipboardControllerLogger),
+			)
+			router.Post("/", handleClipboardItemCreation())
+		})
+
+		v1Router.Group(func(router chi.Router) {
+			router.Use(
+				context.CallSiteMiddleware("handleGettingClipboardItems"),
+				jwt.JwtVerifierMiddleware(clipboardControllerLogger),
+			)
+			router.Get("/", handleGettingClipboardItems())
+		})
+
+		v1Router.Group(func(router chi.Router) {
+			router.Use(
+				context.CallSiteMiddleware("handleSearchingClipboardItems"),
+				jwt.JwtVerifierMiddleware(clipboardControllerLogger),
+			)
+			router.Get("/search", handleSearchingClipboardItems())
+		})
+
+		v1Router.Group(func(router chi.Router) {
+			router.Use(
+				context.CallSiteMiddleware("handleGettingClipboardItem"),
+				jwt.JwtVerifierMiddleware(clipboardControllerLogger),
+			)
+			router.Get("/{clipboardItemId}", handleGettingClipboardItem())
+		})
+
+		v1Router.Group(func(router chi.Router) {
+			router.Use(
+				context.CallSiteMiddleware("handleGettingClipboardItemDownloadUrl"),
+				jwt.JwtVerifierMiddleware(clipboardControllerLogger),
+			)
+			router.Get("/{clipboardItemId}/download-url", handleGettingClipboardItemDownloadUrl())
+		})
+
+		v1Router.Group(func(router chi.Router) {
+			router.Use(
+				context.CallSiteMiddleware("handleUpdatingClipboardItem"),
+				jwt.JwtVerifierMiddleware(clipboardControllerLogger),
+			)
+			router.Patch("/{clipboardItemId}", handleUpdatingClipboardItem())
+		})
+
+		v1Router.Group(func(router chi.Router) {
+			router.Use(
+				context.CallSiteMiddleware("handleDeletingClipboardItem"),
+				jwt.JwtVerifierMiddleware(clipboardControllerLogger),
+			)
+			router.Delete("/{clipboardItemId}", handleDeletingClipboardItem())
+		})
+	})
+
+	parentRouter.Route("/v1/clipboard/images", func(v1Router chi.Router) {
+		v1Router.Group(func(router chi.Router) {
+			router.Use(
+				context.CallSiteMiddleware("handleClipboardImageUpload"),
+				jwt.JwtVerifierMiddleware(clipboardControllerLogger),
+				entitlement.EntitlementGuardMiddleware(
+					clipboardControllerLogger,
+					_entitlementModel.EntitlementTypeImageUpload,
+				),
+			)
+			router.Post("/", handleClipboardImageUpload())
+		})
+	})
+}
+
+func handleClipboardItemCreation() http.HandlerFunc {
+	return _http.GetResponseSender(
+		http.StatusCreated,
+		func(request *http.Request, responseWriter http.ResponseWriter) (any, error) {
+			ctx := request.Context()
+
+			var createClipboardItemRequest dto.CreateClipboardItemRequest
+			err := _http.ReadRequestBodyAs(request, clipboardControllerLogger, &createClipboardItemRequest)
+			if err != nil {
+				clipboardControllerLogger.ErrorAttrs(
+					ctx,
+					err,
+					"failed to parse request body",
+					slog.String("userEmail", jwt.GetUserEmailClaim(ctx)),
+				)
+
+				return nil, err
+			}
+
+			return clipboardService.createClipboardItem(ctx, createClipboardItemRequest)
+		},
+	)
+}
+
+func handleGettingClipboardItems() http.HandlerFunc {
+	return _http.GetResponseSender(
+		http.StatusOK,
+		func(request *http.Request, responseWriter http.ResponseWriter) (any, error) {
+			ctx := request.Context()
+			queryParams := request.URL.Query()
+
+			offset, err := _http.GetQueryParamAsInt64(queryParams, "offset")
+			if err != nil {
+				clipboardControllerLogger.ErrorAttrs(
+					ctx,
+					err,
+					"failed to get offset query param",
+					slog.String("userEmail", jwt.GetUserEmailClaim(ctx)),
+					slog.String("queryParams", queryParams.Encode()),
+				)
+
+				return nil, err
+			}
+
+			limit, err := _http.GetQueryParamAsInt64(queryParams, "limit")
+			if err != nil {
+				clipboardControllerLogger.ErrorAttrs(
+					ctx,
+					err,
+					"failed to get limit query param",
+					slog.String("userEmail", jwt.GetUserEmailClaim(ctx)),
+					slog.String("queryParams", queryParams.Encode()),
+				)
+
+				return nil, err
+			}
+
+			return clipboardService.getClipboardItems(ctx, offset, limit)
+		},
+	)
+}
+
+// handleSearchingClipboardItems supports the `q`, `type` (repeatable), `createdFrom`, `createdTo`,
+// `isPinned` and `deviceId` query params on top of `offset` and `limit`, all filters are optional.
+func handleSearchingClipboardItems() http.HandlerFunc {
+	return _http.GetResponseSender(
+		http.StatusOK,
+		func(request *http.Request, responseWriter http.ResponseWriter) (any, error) {
+			ctx := request.Context()
+			queryParams := request.URL.Query()
+
+			searchQuery, err := getSearchClipboardItemsQuery(queryParams)
+			if err != nil {
+				clipboardControllerLogger.ErrorAttrs(
+					ctx,
+					err,
+					"failed to get search query params",
+					slog.String("userEmail", jwt.GetUserEmailClaim(ctx)),
+					slog.String("queryParams", queryParams.Encode()),
+				)
+
+				return nil, err
+			}
+
+			return clipboardService.searchClipboardItems(ctx, searchQuery)
+		},
+	)
+}
+
+func getSearchClipboardItemsQuery(queryParams url.Values) (dto.SearchClipboardItemsQuery, error) {
+	offset, err := _http.GetQueryParamAsInt64(queryParams, "offset")
+	if err != nil {
+		return dto.SearchClipboardItemsQuery{}, err
+	}
+
+	limit, err := _http.GetQueryParamAsInt64(queryParams, "limit")
+	if err != nil {
+		return dto.SearchClipboardItemsQuery{}, err
+	}
+
+	searchQuery := dto.SearchClipboardItemsQuery{
+		Text:     queryParams.Get("q"),
+		DeviceId: queryParams.Get("deviceId"),
+		Offset:   offset,
+		Limit:    limit,
+	}
+
+	if len(searchQuery.Text) > maxSearchTextLength {
+		return dto.SearchClipboardItemsQuery{}, errors.WithStack(exception.NewValidationExceptionWithExtra(
+			fmt.Sprintf("'q' query param must be at most %d characters long", maxSearchTextLength),
+			map[string]any{
+				"q": searchQuery.Text,
+			},
+		))
+	}
+
+	for _, clipboardItemType := range queryParams["type"] {
+		switch model.ClipboardItemType(clipboardItemType) {
+		case model.ClipboardItemTypeText, model.ClipboardItemTypeUrl, model.ClipboardItemTypeImage:
+			searchQuery.Types = append(searchQuery.Types, model.ClipboardItemType(clipboardItemType))
+		default:
+			return dto.SearchClipboardItemsQuery{}, errors.WithStack(exception.NewValidationExceptionWithExtra(
+				"'type' query param must be one of TEXT, URL or IMAGE",
+				map[string]any{
+					"type": clipboardItemType,
+				},
+			))
+		}
+	}
+
+	searchQuery.CreatedFrom, err = getQueryParamAsOptionalTime(queryParams, "createdFrom")
+	if err != nil {
+		return dto.SearchClipboardItemsQuery{}, err
+	}
+
+	searchQuery.CreatedTo, err = getQueryParamAsOptionalTime(queryParams, "createdTo")
+	if err != nil {
+		return dto.SearchClipboardItemsQuery{}, err
+	}
+
+	if isPinned := queryParams.Get("isPinned"); isPinned != "" {
+		value, err := strconv.ParseBool(isPinned)
+		if err != nil {
+			return dto.SearchClipboardItemsQuery{}, errors.WithStack(exception.NewValidationExceptionWithExtra(
+				"cannot parse 'isPinned' as boolean",
+				map[string]any{
+					"isPinned": isPinned,
+				},
+			))
+		}
+
+		searchQuery.IsPinned = &value
+	}
+
+	return searchQuery, nil
+}
+
+func getQueryParamAsOptionalTime(queryParams url.Values, paramName string) (*time.Time, error) {
+	paramValue := queryParams.Get(paramName)
+	if paramValue == "" {
+		return nil, nil
+	}
+
+	value, err := time.Parse(time.RFC3339, paramValue)
+	if err != nil {
+		return nil, errors.WithStack(exception.NewValidationExceptionWithExtra(
+			"cannot parse '"+paramName+"' as RFC 3339 timestamp",
+			map[string]any{
+				paramName: paramValue,
+			},
+		))
+	}
+
+	return &value, nil
+}
+
+func handleGettingClipboardItem() http.HandlerFunc {
+	return _http.GetResponseSender(
+		http.StatusOK,
+		func(request *http.Request, responseWriter http.ResponseWriter) (any, error) {
+			clipboardItemId := chi.URLParam(request, "clipboardItemId")
+
+			return clipboardService.getClipboardItem(request.Context(), clipboardItemId)
+		},
+	)
+}
+
+func handleUpdatingClipboardItem() http.HandlerFunc {
+	return _http.GetResponseSender(
+		http.StatusOK,
+		func(request *http.Request, responseWriter http.ResponseWriter) (any, error) {
+			ctx := request.Context()
+			clipboardItemId := chi.URLParam(request, "clipboardItemId")
+
+			var updateClipboardItemRequest dto.UpdateClipboardItemRequest
+			err := _http.ReadRequestBodyAs(request, clipboardControllerLogger, &updateClipboardItemRequest)
+			if err != nil {
+				clipboardControllerLogger.ErrorAttrs(
+					ctx,
+					err,
+					"failed to parse request body",
+					slog.String("userEmail", jwt.GetUserEmailClaim(ctx)),
+					slog.String("clipboardItemId", clipboardItemId),
+					slog.Any("requestBody", updateClipboardItemRequest),
+				)
+
+				return nil, err
+			}
+
+			return clipboardService.updateClipboardItem(ctx, clipboardItemId, updateClipboardItemRequest)
+		},
+	)
+}
+
+func handleDeletingClipboardItem() http.HandlerFunc {
+	return _http.GetEmptyResponseSender(func(request *http.Request, responseWriter http.ResponseWriter) error {
+		clipboardItemId := chi.URLParam(request, "clipboardItemId")
+
+		return clipboardService.deleteClipboardItem(request.Context(), clipboardItemId)
+	})
+}
+
+// handleClipboardImageUpload expects the raw image as the request body, which is streamed to object storage
+// as it is received. The size has to be known upfront so that oversized images are rejected right away.
+func handleClipboardImageUpload() http.HandlerFunc {
+	return _http.GetResponseSender(
+		http.StatusCreated,
+		func(request *http.Request, responseWriter http.ResponseWriter) (any, error) {
+			ctx := request.Context()
+			size := request.ContentLength
+			maxSize := environment.Config.ImageUploadMaxSizeBytes
+
+			if size <= 0 {
+				clipboardControllerLogger.WarnAttrs(
+					ctx,
+					"rejected image upload without content length",
+					slog.String("userEmail", jwt.GetUserEmailClaim(ctx)),
+					slog.Int64("contentLength", size),
+				)
+
+				return nil, exception.NewValidationException("'Content-Length' header is required and must be positive")
+			}
+
+			if size > maxSize {
+				clipboardControllerLogger.WarnAttrs(
+					ctx,
+					"rejected image upload that is too large",
+					slog.String("userEmail", jwt.GetUserEmailClaim(ctx)),
+					slog.Int64("contentLength", size),
+				)
+
+				return nil, exception.NewPayloadTooLargeExceptionWithExtra(
+					"image is too large",
+					map[string]any{
+						"maxSizeBytes": maxSize,
+					},
+				)
+			}
+
+			responseController := http.NewResponseController(responseWriter)
+			deadline := time.Now().Add(imageUploadTimeout)
+			_ = responseController.SetReadDeadline(deadline)
+			_ = responseController.SetWriteDeadline(deadline)
+
+			body := http.MaxBytesReader(responseWriter, request.Body, size)
+
+			return clipboardService.uploadClipboardImage(ctx, body, size)
+		},
+	)
+}
+
+func handleGettingClipboardItemDownloadUrl() http.HandlerFunc {
+	return _http.GetResponseSender(
+		http.StatusOK,
+		func(request *http.Request, responseWriter http.ResponseWriter) (any, error) {
+			clipboardItemId := chi.URLParam(request, "clipboardItemId")
+
+			return clipboardService.getClipboardItemDownloadUrl(request.Context(), clipboardItemId)
+		},
+	)
+}
@@ -0,0 +1,260 @@
+package clipboard
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cloudy-clip/api/internal/clipboard/dto"
+	"github.com/cloudy-clip/api/internal/common/database"
+	_jetModel "github.com/cloudy-clip/api/internal/common/database/.jet/model"
+	"github.com/cloudy-clip/api/internal/common/database/.jet/table"
+	jet "github.com/go-jet/jet/v2/postgres"
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	// Private use characters that delimit the matches of a highlight until `escapeHighlight`
+	// replaces them with <mark></mark>.
+	highlightMatchStart = "\uE000"
+	highlightMatchEnd   = "\uE001"
+)
+
+type ClipboardRepository struct {
+}
+
+func NewClipboardRepository() *ClipboardRepository {
+	return &ClipboardRepository{}
+}
+
+func (clipboardRepository *ClipboardRepository) createClipboardItem(
+	ctx context.Context,
+	transaction pgx.Tx,
+	clipboardItemModel _jetModel.ClipboardItem,
+) error {
+	queryBuilder := table.ClipboardItemTable.
+		INSERT(table.ClipboardItemTable.AllColumns).
+		MODEL(clipboardItemModel)
+
+	if transaction != nil {
+		return database.ExecTx(ctx, transaction, queryBuilder)
+	}
+
+	return database.Exec(ctx, queryBuilder)
+}
+
+func (clipboardRepository *ClipboardRepository) FindClipboardItemById(
+	ctx context.Context,
+	transaction pgx.Tx,
+	userId string,
+	clipboardItemId string,
+) (_jetModel.ClipboardItem, error) {
+	clipboardItemTable := table.ClipboardItemTable
+	queryBuilder := clipboardItemTable.
+		SELECT(clipboardItemTable.AllColumns.As("")).
+		WHERE(
+			clipboardItemTable.ClipboardItemID.EQ(jet.String(clipboardItemId)).
+				AND(clipboardItemTable.UserID.EQ(jet.String(userId))),
+		).
+		LIMIT(1)
+
+	if transaction != nil {
+		return database.SelectOneTx[_jetModel.ClipboardItem](ctx, transaction, queryBuilder)
+	}
+
+	return database.SelectOne[_jetModel.ClipboardItem](ctx, queryBuilder)
+}
+
+func (clipboardRepository *ClipboardRepository) getClipboardItems(
+	ctx context.Context,
+	userId string,
+	offset,
+	limit int64,
+) ([]_jetModel.ClipboardItem, error) {
+	clipboardItemTable := table.ClipboardItemTable
+	queryBuilder := clipboardItemTable.
+		SELECT(clipboardItemTable.AllColumns.As("")).
+		WHERE(clipboardItemTable.UserID.EQ(jet.String(userId))).
+		OFFSET(offset).
+		LIMIT(limit).
+		ORDER_BY(clipboardItemTable.CreatedAt.DESC(), clipboardItemTable.ClipboardItemID.DESC())
+
+	return database.SelectMany[_jetModel.ClipboardItem](ctx, queryBuilder)
+}
+
+type clipboardItemSearchQueryResult struct {
+	_jetModel.ClipboardItem
+	Highlight *string `db:"highlight"` // Nullable
+}
+
+// searchClipboardItems ranks the items by how well they match the text, if any, then by recency.
+func (clipboardRepository *ClipboardRepository) searchClipboardItems(
+	ctx context.Context,
+	userId string,
+	searchQuery dto.SearchClipboardItemsQuery,
+) ([]clipboardItemSearchQueryResult, error) {
+	clipboardItemTable := table.ClipboardItemTable
+	highlight := jet.Expression(jet.NULL)
+	orderBy := []jet.OrderByClause{clipboardItemTable.CreatedAt.DESC(), clipboardItemTable.ClipboardItemID.DESC()}
+
+	if searchQuery.Text != "" {
+		textArgs := jet.RawArgs{
+			"#text": searchQuery.Text,
+		}
+		highlight = jet.RawString(
+			"ts_headline('simple', content, websearch_to_tsquery('simple', #text), #highlightOptions)",
+			jet.RawArgs{
+				"#text": searchQuery.Text,
+				"#highlightOptions": fmt.Sprintf(
+					`StartSel=%s, StopSel=%s, MaxFragments=3, FragmentDelimiter=" ... "`,
+					highlightMatchStart,
+					highlightMatchEnd,
+				),
+			},
+		)
+		orderBy = append(
+			[]jet.OrderByClause{
+				jet.RawFloat("ts_rank(search_vector, websearch_to_tsquery('simple', #text))", textArgs).DESC(),
+			},
+			orderBy...,
+		)
+	}
+
+	queryBuilder := clipboardItemTable.
+		SELECT(clipboardItemTable.AllColumns.As(""), highlight.AS("highlight")).
+		WHERE(newSearchClipboardItemsCondition(userId, searchQuery)).
+		ORDER_BY(orderBy...).
+		OFFSET(searchQuery.Offset).
+		LIMIT(searchQuery.Limit)
+
+	return database.SelectMany[clipboardItemSearchQueryResult](ctx, queryBuilder)
+}
+
+func (clipboardRepository *ClipboardRepository) countClipboardItemsMatchingSearch(
+	ctx context.Context,
+	userId string,
+	searchQuery dto.SearchClipboardItemsQuery,
+) (int, error) {
+	queryBuilder := table.ClipboardItemTable.
+		SELECT(jet.COUNT(jet.Raw("*")).AS("count")).
+		WHERE(newSearchClipboardItemsCondition(userId, searchQuery))
+
+	var count int
+
+	err := database.SelectInto(ctx, queryBuilder, &count)
+
+	return count, err
+}
+
+// `search_vector` is a generated column, it is left out of the generated table so that inserting
+// all columns does not try to write to it.
+func newSearchClipboardItemsCondition(userId string, searchQuery dto.SearchClipboardItemsQuery) jet.BoolExpression {
+	clipboardItemTable := table.ClipboardItemTable
+	condition := clipboardItemTable.UserID.EQ(jet.String(userId))
+
+	if searchQuery.Text != "" {
+		condition = condition.AND(
+			jet.RawBool(
+				"search_vector @@ websearch_to_tsquery('simple', #text)",
+				jet.RawArgs{
+					"#text": searchQuery.Text,
+				},
+			),
+		)
+	}
+
+	if len(searchQuery.Types) > 0 {
+		types := make([]jet.Expression, 0, len(searchQuery.Types))
+		for _, clipboardItemType := range searchQuery.Types {
+			types = append(types, jet.String(clipboardItemType.String()))
+		}
+
+		condition = condition.AND(clipboardItemTable.Type.IN(types...))
+	}
+
+	if searchQuery.CreatedFrom != nil {
+		condition = condition.AND(clipboardItemTable.CreatedAt.GT_EQ(jet.TimestampzT(*searchQuery.CreatedFrom)))
+	}
+
+	if searchQuery.CreatedTo != nil {
+		condition = condition.AND(clipboardItemTable.CreatedAt.LT(jet.TimestampzT(*searchQuery.CreatedTo)))
+	}
+
+	if searchQuery.IsPinned != nil {
+		condition = condition.AND(clipboardItemTable.IsPinned.EQ(jet.Bool(*searchQuery.IsPinned)))
+	}
+
+	if searchQuery.DeviceId != "" {
+		condition = condition.AND(clipboardItemTable.DeviceID.EQ(jet.String(searchQuery.DeviceId)))
+	}
+
+	return condition
+}
+
+func (clipboardRepository *ClipboardRepository) countTotalNumberOfClipboardItemsForUser(
+	ctx context.Context,
+	userId string,
+) (int, error) {
+	queryBuilder := table.ClipboardItemTable.
+		SELECT(jet.COUNT(jet.Raw("*")).AS("count")).
+		WHERE(table.ClipboardItemTable.UserID.EQ(jet.String(userId)))
+
+	var count int
+
+	err := database.SelectInto(ctx, queryBuilder, &count)
+
+	return count, err
+}
+
+func (clipboardRepository *ClipboardRepository) updateClipboardItemPinState(
+	ctx context.Context,
+	transaction pgx.Tx,
+	userId string,
+	clipboardItemId string,
+	isPinned bool,
+	pinnedAt *time.Time,
+	version string,
+) error {
+	clipboardItemTable := table.ClipboardItemTable
+	queryBuilder := clipboardItemTable.
+		UPDATE(
+			clipboardItemTable.IsPinned,
+			clipboardItemTable.PinnedAt,
+			clipboardItemTable.UpdatedAt,
+			clipboardItemTable.Version,
+		).
+		SET(isPinned, pinnedAt, time.Now(), version).
+		WHERE(
+			clipboardItemTable.ClipboardItemID.EQ(jet.String(clipboardItemId)).
+				AND(clipboardItemTable.UserID.EQ(jet.String(userId))).
+				// A change that was assigned a greater version has already won.
+				AND(clipboardItemTable.Version.LT(jet.String(version))),
+		)
+
+	if transaction != nil {
+		return database.ExecTx(ctx, transaction, queryBuilder)
+	}
+
+	return database.Exec(ctx, queryBuilder)
+}
+
+func (clipboardRepository *ClipboardRepository) deleteClipboardItemById(
+	ctx context.Context,
+	transaction pgx.Tx,
+	userId string,
+	clipboardItemId string,
+) error {
+	clipboardItemTable := table.ClipboardItemTable
+	queryBuilder := clipboardItemTable.
+		DELETE().
+		WHERE(
+			clipboardItemTable.ClipboardItemID.EQ(jet.String(clipboardItemId)).
+				AND(clipboardItemTable.UserID.EQ(jet.String(userId))),
+		)
+
+	if transaction != nil {
+		return database.ExecTx(ctx, transaction, queryBuilder)
+	}
+
+	return database.Exec(ctx, queryBuilder)
+}
@@ -0,0 +1,684 @@
+package clipboard
+
+import (
+	"bytes"
+	"context"
+	"html"
+	"io"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/cloudy-clip/api/internal/clipboard/dto"
+	"github.com/cloudy-clip/api/internal/clipboard/model"
+	"github.com/cloudy-clip/api/internal/common/database"
+	_jetModel "github.com/cloudy-clip/api/internal/common/database/.jet/model"
+	"github.com/cloudy-clip/api/internal/common/environment"
+	"github.com/cloudy-clip/api/internal/common/exception"
+	_http "github.com/cloudy-clip/api/internal/common/http"
+	"github.com/cloudy-clip/api/internal/common/jwt"
+	_logger "github.com/cloudy-clip/api/internal/common/logger"
+	"github.com/cloudy-clip/api/internal/common/ulid"
+	"github.com/cloudy-clip/api/internal/common/utils"
+	"github.com/cloudy-clip/api/internal/entitlement"
+	_entitlementModel "github.com/cloudy-clip/api/internal/entitlement/model"
+	"github.com/cloudy-clip/api/internal/event"
+	_eventModel "github.com/cloudy-clip/api/internal/event/model"
+	"github.com/cloudy-clip/api/internal/storage"
+	"github.com/jackc/pgx/v5"
+	"github.com/pkg/errors"
+)
+
+const (
+	// How many leading bytes `http.DetectContentType` looks at.
+	contentTypeSniffingSize = 512
+)
+
+var (
+	clipboardServiceLogger     *_logger.Logger
+	supportedImageContentTypes = []string{"image/png", "image/jpeg", "image/gif", "image/webp", "image/bmp"}
+	highlightMarkReplacer      = strings.NewReplacer(highlightMatchStart, "<mark>", highlightMatchEnd, "</mark>")
+)
+
+type ClipboardService struct {
+}
+
+func NewClipboardService() *ClipboardService {
+	clipboardServiceLogger = _logger.NewLogger(
+		"ClipboardService",
+		slog.Level(environment.Config.ApplicationLogLevel),
+	)
+
+	return &ClipboardService{}
+}
+
+func (clipboardService *ClipboardService) createClipboardItem(
+	ctx context.Context,
+	createClipboardItemRequest dto.CreateClipboardItemRequest,
+) (dto.ClipboardItem, exception.Exception) {
+	createdClipboardItem, err := createClipboardItem(ctx, createClipboardItemRequest)
+	if err == nil {
+		return createdClipboardItem, nil
+	}
+
+	if exception.IsOfExceptionType[exception.EntitlementLimitExceededException](err) {
+		clipboardServiceLogger.WarnAttrs(
+			ctx,
+			"cannot create clipboard item because entitlement limit was exceeded",
+			slog.String("userEmail", jwt.GetUserEmailClaim(ctx)),
+			slog.String("clipboardItemType", createClipboardItemRequest.Type.String()),
+		)
+	} else {
+		clipboardServiceLogger.ErrorAttrs(
+			ctx,
+			err,
+			"failed to create clipboard item",
+			slog.String("userEmail", jwt.GetUserEmailClaim(ctx)),
+			slog.String("clipboardItemType", createClipboardItemRequest.Type.String()),
+		)
+	}
+
+	return dto.ClipboardItem{}, exception.GetAsApplicationException(err, "failed to create clipboard item")
+}
+
+func createClipboardItem(
+	ctx context.Context,
+	createClipboardItemRequest dto.CreateClipboardItemRequest,
+) (dto.ClipboardItem, error) {
+	clipboardItemId, err := ulid.GenerateWithRetry()
+	if err != nil {
+		return dto.ClipboardItem{}, err
+	}
+
+	userId := jwt.GetUserIdClaim(ctx)
+	now := time.Now()
+	clipboardItemModel := _jetModel.ClipboardItem{
+		ClipboardItemID: clipboardItemId,
+		Type:            createClipboardItemRequest.Type,
+		Content:         createClipboardItemRequest.Content,
+		IsPinned:        false,
+		PinnedAt:        nil,
+		UserID:          userId,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+		DeviceID:        getSourceDeviceId(ctx),
+	}
+
+	if clipboardItemModel.Type != model.ClipboardItemTypeImage {
+		clipboardItemModel.WordCount = int32(utils.CountWords(clipboardItemModel.Content))
+	}
+
+	err = saveNewClipboardItem(ctx, &clipboardItemModel)
+	if err == nil {
+		return dto.NewClipboardItem(clipboardItemModel), nil
+	}
+
+	return dto.ClipboardItem{}, err
+}
+
+// getSourceDeviceId returns nil when the item is not created from a registered device.
+func getSourceDeviceId(ctx context.Context) *string {
+	deviceId := jwt.GetDeviceIdClaim(ctx)
+	if deviceId == "" {
+		return nil
+	}
+
+	return &deviceId
+}
+
+// saveNewClipboardItem assigns the item its first version, then saves it once the user is known to be entitled to it.
+func saveNewClipboardItem(ctx context.Context, clipboardItemModel *_jetModel.ClipboardItem) error {
+	return database.UseTransaction(ctx, func(transaction pgx.Tx) error {
+		userId := clipboardItemModel.UserID
+
+		// Concurrent changes wait here, so that the usage is read after the previous item was tracked
+		// and the user cannot go over their limit by creating several items at once.
+		version, err := event.
+			GetEventService().
+			NextClipboardItemVersion(ctx, transaction, userId)
+		if err != nil {
+			return err
+		}
+
+		err = enforceEntitlementForNewClipboardItem(ctx, transaction, userId, *clipboardItemModel)
+		if err != nil {
+			return err
+		}
+
+		clipboardItemModel.Version = version
+
+		err = clipboardRepository.createClipboardItem(ctx, transaction, *clipboardItemModel)
+		if err != nil {
+			return err
+		}
+
+		err = entitlement.
+			GetEntitlementService().
+			TrackAddedClipboardItems(ctx, transaction, userId, *clipboardItemModel)
+		if err != nil {
+			return err
+		}
+
+		return event.
+			GetEventService().
+			PublishClipboardEvent(ctx, transaction, _eventModel.ClipboardEventTypeCreated, *clipboardItemModel)
+	})
+}
+
+func enforceEntitlementForNewClipboardItem(
+	ctx context.Context,
+	transaction pgx.Tx,
+	userId string,
+	clipboardItemModel _jetModel.ClipboardItem,
+) error {
+	if clipboardItemModel.Type == model.ClipboardItemTypeImage {
+		return entitlement.
+			GetEntitlementService().
+			EnforceEntitlement(ctx, transaction, userId, _entitlementModel.EntitlementTypeImageUpload, 1)
+	}
+
+	return entitlement.
+		GetEntitlementService().
+		EnforceEntitlement(
+			ctx,
+			transaction,
+			userId,
+			_entitlementModel.EntitlementTypeWordCount,
+			int(clipboardItemModel.WordCount),
+		)
+}
+
+func (clipboardService *ClipboardService) getClipboardItems(
+	ctx context.Context,
+	offset,
+	limit int64,
+) (_http.PaginationResult[dto.ClipboardItem], exception.Exception) {
+	foundClipboardItems, err := getClipboardItems(ctx, offset, limit)
+	if err == nil {
+		return foundClipboardItems, nil
+	}
+
+	clipboardServiceLogger.ErrorAttrs(
+		ctx,
+		err,
+		"failed to get clipboard items",
+		slog.String("userEmail", jwt.GetUserEmailClaim(ctx)),
+		slog.Int64("offset", offset),
+		slog.Int64("limit", limit),
+	)
+
+	return _http.PaginationResult[dto.ClipboardItem]{}, exception.NewUnknownException("failed to get clipboard items")
+}
+
+func getClipboardItems(
+	ctx context.Context,
+	offset,
+	limit int64,
+) (_http.PaginationResult[dto.ClipboardItem], error) {
+	userId := jwt.GetUserIdClaim(ctx)
+	totalClipboardItemCount, err := clipboardRepository.countTotalNumberOfClipboardItemsForUser(ctx, userId)
+	if err != nil {
+		return _http.PaginationResult[dto.ClipboardItem]{}, err
+	}
+
+	clipboardItemModels, err := clipboardRepository.getClipboardItems(ctx, userId, offset, limit)
+	if err == nil {
+		clipboardItems := make([]dto.ClipboardItem, 0, len(clipboardItemModels))
+
+		for _, clipboardItemModel := range clipboardItemModels {
+			clipboardItems = append(clipboardItems, dto.NewClipboardItem(clipboardItemModel))
+		}
+
+		return _http.NewPaginationResult(clipboardItems, totalClipboardItemCount), nil
+	}
+
+	return _http.PaginationResult[dto.ClipboardItem]{}, err
+}
+
+func (clipboardService *ClipboardService) searchClipboardItems(
+	ctx context.Context,
+	searchQuery dto.SearchClipboardItemsQuery,
+) (_http.PaginationResult[dto.ClipboardItemSearchResult], exception.Exception) {
+	searchResults, err := searchClipboardItems(ctx, searchQuery)
+	if err == nil {
+		return searchResults, nil
+	}
+
+	clipboardServiceLogger.ErrorAttrs(
+		ctx,
+		err,
+		"failed to search clipboard items",
+		slog.String("userEmail", jwt.GetUserEmailClaim(ctx)),
+		slog.Int64("offset", searchQuery.Offset),
+		slog.Int64("limit", searchQuery.Limit),
+	)
+
+	return _http.PaginationResult[dto.ClipboardItemSearchResult]{},
+		exception.NewUnknownException("failed to search clipboard items")
+}
+
+func searchClipboardItems(
+	ctx context.Context,
+	searchQuery dto.SearchClipboardItemsQuery,
+) (_http.PaginationResult[dto.ClipboardItemSearchResult], error) {
+	userId := jwt.GetUserIdClaim(ctx)
+	matchingClipboardItemCount, err := clipboardRepository.countClipboardItemsMatchingSearch(ctx, userId, searchQuery)
+	if err != nil {
+		return _http.PaginationResult[dto.ClipboardItemSearchResult]{}, err
+	}
+
+	queryResults, err := clipboardRepository.searchClipboardItems(ctx, userId, searchQuery)
+	if err != nil {
+		return _http.PaginationResult[dto.ClipboardItemSearchResult]{}, err
+	}
+
+	searchResults := make([]dto.ClipboardItemSearchResult, 0, len(queryResults))
+
+	for _, queryResult := range queryResults {
+		searchResults = append(
+			searchResults,
+			dto.ClipboardItemSearchResult{
+				ClipboardItem: dto.NewClipboardItem(queryResult.ClipboardItem),
+				Highlight:     escapeHighlight(queryResult.Highlight),
+			},
+		)
+	}
+
+	return _http.NewPaginationResult(searchResults, matchingClipboardItemCount), nil
+}
+
+// escapeHighlight escapes the content of the highlight before marking its matches, so that the
+// marks are the only markup that clients render.
+func escapeHighlight(highlight *string) *string {
+	if highlight == nil {
+		return nil
+	}
+
+	escapedHighlight := highlightMarkReplacer.Replace(html.EscapeString(*highlight))
+
+	return &escapedHighlight
+}
+
+func (clipboardService *ClipboardService) getClipboardItem(
+	ctx context.Context,
+	clipboardItemId string,
+) (dto.ClipboardItem, exception.Exception) {
+	clipboardItemModel, err := clipboardRepository.FindClipboardItemById(
+		ctx,
+		nil,
+		jwt.GetUserIdClaim(ctx),
+		clipboardItemId,
+	)
+	if err == nil {
+		return dto.NewClipboardItem(clipboardItemModel), nil
+	}
+
+	clipboardServiceLogger.ErrorAttrs(
+		ctx,
+		err,
+		"failed to find clipboard item",
+		slog.String("userEmail", jwt.GetUserEmailClaim(ctx)),
+		slog.String("clipboardItemId", clipboardItemId),
+	)
+
+	if database.IsEmptyResultError(err) {
+		return dto.ClipboardItem{}, exception.NewNotFoundException("no clipboard item was found")
+	}
+
+	return dto.ClipboardItem{}, exception.NewUnknownException("failed to find clipboard item")
+}
+
+func (clipboardService *ClipboardService) updateClipboardItem(
+	ctx context.Context,
+	clipboardItemId string,
+	updateClipboardItemRequest dto.UpdateClipboardItemRequest,
+) (dto.ClipboardItem, exception.Exception) {
+	updatedClipboardItem, err := updateClipboardItem(ctx, clipboardItemId, updateClipboardItemRequest)
+	if err == nil {
+		return updatedClipboardItem, nil
+	}
+
+	clipboardServiceLogger.ErrorAttrs(
+		ctx,
+		err,
+		"failed to update clipboard item",
+		slog.String("userEmail", jwt.GetUserEmailClaim(ctx)),
+		slog.String("clipboardItemId", clipboardItemId),
+		slog.Bool("isPinned", *updateClipboardItemRequest.IsPinned),
+	)
+
+	return dto.ClipboardItem{}, exception.GetAsApplicationException(err, "failed to update clipboard item")
+}
+
+func updateClipboardItem(
+	ctx context.Context,
+	clipboardItemId string,
+	updateClipboardItemRequest dto.UpdateClipboardItemRequest,
+) (dto.ClipboardItem, error) {
+	userId := jwt.GetUserIdClaim(ctx)
+
+	var updatedClipboardItem dto.ClipboardItem
+
+	err := database.UseTransaction(ctx, func(transaction pgx.Tx) error {
+		// Concurrent changes wait here so that the item is read as left by the last of them.
+		version, err := event.
+			GetEventService().
+			NextClipboardItemVersion(ctx, transaction, userId)
+		if err != nil {
+			return err
+		}
+
+		clipboardItemModel, err := findClipboardItemOrThrow(ctx, transaction, userId, clipboardItemId)
+		if err != nil {
+			return err
+		}
+
+		isPinned := *updateClipboardItemRequest.IsPinned
+		if clipboardItemModel.IsPinned != isPinned {
+			var pinnedAt *time.Time
+			if isPinned {
+				now := time.Now()
+				pinnedAt = &now
+			}
+
+			err = clipboardRepository.updateClipboardItemPinState(
+				ctx,
+				transaction,
+				userId,
+				clipboardItemId,
+				isPinned,
+				pinnedAt,
+				version,
+			)
+			if err != nil {
+				return err
+			}
+
+			clipboardItemModel.IsPinned = isPinned
+			clipboardItemModel.PinnedAt = pinnedAt
+			clipboardItemModel.UpdatedAt = time.Now()
+			clipboardItemModel.Version = version
+
+			err = event.
+				GetEventService().
+				PublishClipboardEvent(ctx, transaction, _eventModel.ClipboardEventTypeUpdated, clipboardItemModel)
+			if err != nil {
+				return err
+			}
+		}
+
+		updatedClipboardItem = dto.NewClipboardItem(clipboardItemModel)
+
+		return nil
+	})
+
+	return updatedClipboardItem, err
+}
+
+func findClipboardItemOrThrow(
+	ctx context.Context,
+	transaction pgx.Tx,
+	userId string,
+	clipboardItemId string,
+) (_jetModel.ClipboardItem, error) {
+	clipboardItemModel, err := clipboardRepository.FindClipboardItemById(ctx, transaction, userId, clipboardItemId)
+	if database.IsEmptyResultError(err) {
+		return clipboardItemModel, exception.NewNotFoundException("no clipboard item was found")
+	}
+
+	return clipboardItemModel, err
+}
+
+func (clipboardService *ClipboardService) deleteClipboardItem(
+	ctx context.Context,
+	clipboardItemId string,
+) exception.Exception {
+	err := deleteClipboardItem(ctx, clipboardItemId)
+	if err == nil {
+		return nil
+	}
+
+	clipboardServiceLogger.ErrorAttrs(
+		ctx,
+		err,
+		"failed to delete clipboard item",
+		slog.String("userEmail", jwt.GetUserEmailClaim(ctx)),
+		slog.String("clipboardItemId", clipboardItemId),
+	)
+
+	return exception.GetAsApplicationException(err, "failed to delete clipboard item")
+}
+
+func deleteClipboardItem(ctx context.Context, clipboardItemId string) error {
+	userId := jwt.GetUserIdClaim(ctx)
+
+	var deletedClipboardItem _jetModel.ClipboardItem
+
+	err := database.UseTransaction(ctx, func(transaction pgx.Tx) error {
+		version, err := event.
+			GetEventService().
+			NextClipboardItemVersion(ctx, transaction, userId)
+		if err != nil {
+			return err
+		}
+
+		clipboardItemModel, err := findClipboardItemOrThrow(ctx, transaction, userId, clipboardItemId)
+		if err != nil {
+			return err
+		}
+
+		err = clipboardRepository.deleteClipboardItemById(ctx, transaction, userId, clipboardItemId)
+		if err != nil {
+			return err
+		}
+
+		err = entitlement.
+			GetEntitlementService().
+			TrackRemovedClipboardItems(ctx, transaction, userId, clipboardItemModel)
+		if err != nil {
+			return err
+		}
+
+		clipboardItemModel.Version = version
+		deletedClipboardItem = clipboardItemModel
+
+		return event.
+			GetEventService().
+			PublishClipboardEvent(ctx, transaction, _eventModel.ClipboardEventTypeDeleted, clipboardItemModel)
+	})
+	if err != nil {
+		return err
+	}
+
+	clipboardService.DeleteStoredContent(ctx, deletedClipboardItem)
+
+	return nil
+}
+
+// uploadClipboardImage streams the image straight into object storage rather than buffering it,
+// and records it as a new clipboard item whose content is the key of the stored object.
+func (clipboardService *ClipboardService) uploadClipboardImage(
+	ctx context.Context,
+	body io.Reader,
+	size int64,
+) (dto.ClipboardItem, exception.Exception) {
+	uploadedClipboardItem, err := uploadClipboardImage(ctx, body, size)
+	if err == nil {
+		return uploadedClipboardItem, nil
+	}
+
+	if exception.IsOfExceptionType[exception.EntitlementLimitExceededException](err) ||
+		exception.IsOfExceptionType[exception.UnsupportedMediaTypeException](err) {
+		clipboardServiceLogger.WarnAttrs(
+			ctx,
+			"cannot upload clipboard image",
+			slog.String("userEmail", jwt.GetUserEmailClaim(ctx)),
+			slog.String("reason", err.Error()),
+		)
+	} else {
+		clipboardServiceLogger.ErrorAttrs(
+			ctx,
+			err,
+			"failed to upload clipboard image",
+			slog.String("userEmail", jwt.GetUserEmailClaim(ctx)),
+			slog.Int64("size", size),
+		)
+	}
+
+	return dto.ClipboardItem{}, exception.GetAsApplicationException(err, "failed to upload clipboard image")
+}
+
+func uploadClipboardImage(ctx context.Context, body io.Reader, size int64) (dto.ClipboardItem, error) {
+	contentType, body, err := sniffImageContentType(body)
+	if err != nil {
+		return dto.ClipboardItem{}, err
+	}
+
+	clipboardItemId, err := ulid.GenerateWithRetry()
+	if err != nil {
+		return dto.ClipboardItem{}, err
+	}
+
+	userId := jwt.GetUserIdClaim(ctx)
+	objectKey := newImageObjectKey(userId, clipboardItemId)
+
+	err = storage.GetObjectStorage().PutObject(ctx, objectKey, body, size, contentType)
+	if err != nil {
+		return dto.ClipboardItem{}, err
+	}
+
+	now := time.Now()
+	clipboardItemModel := _jetModel.ClipboardItem{
+		ClipboardItemID: clipboardItemId,
+		Type:            model.ClipboardItemTypeImage,
+		Content:         objectKey,
+		IsPinned:        false,
+		PinnedAt:        nil,
+		UserID:          userId,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+		DeviceID:        getSourceDeviceId(ctx),
+	}
+
+	err = saveNewClipboardItem(ctx, &clipboardItemModel)
+	if err == nil {
+		return dto.NewClipboardItem(clipboardItemModel), nil
+	}
+
+	// Nothing references the object anymore.
+	clipboardService.DeleteStoredContent(context.WithoutCancel(ctx), clipboardItemModel)
+
+	return dto.ClipboardItem{}, err
+}
+
+// sniffImageContentType returns a reader that still yields the bytes that were read to detect the content type.
+func sniffImageContentType(body io.Reader) (string, io.Reader, error) {
+	sniffedBytes := make([]byte, contentTypeSniffingSize)
+	sniffedByteCount, err := io.ReadFull(body, sniffedBytes)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return "", nil, errors.WithStack(err)
+	}
+
+	sniffedBytes = sniffedBytes[:sniffedByteCount]
+	contentType := http.DetectContentType(sniffedBytes)
+	if !slices.Contains(supportedImageContentTypes, contentType) {
+		return "", nil, exception.NewUnsupportedMediaTypeExceptionWithExtra(
+			"uploaded file is not a supported image",
+			map[string]any{
+				"contentType":                contentType,
+				"supportedImageContentTypes": supportedImageContentTypes,
+			},
+		)
+	}
+
+	return contentType, io.MultiReader(bytes.NewReader(sniffedBytes), body), nil
+}
+
+func newImageObjectKey(userId string, clipboardItemId string) string {
+	return "users/" + userId + "/images/" + clipboardItemId
+}
+
+// Items created with their content inline rather than uploaded have nothing in object storage.
+func HasStoredContent(clipboardItemModel _jetModel.ClipboardItem) bool {
+	return clipboardItemModel.Type == model.ClipboardItemTypeImage &&
+		clipboardItemModel.Content == newImageObjectKey(clipboardItemModel.UserID, clipboardItemModel.ClipboardItemID)
+}
+
+// DeleteStoredContent removes the object that backs the deleted clipboard item, if any. Failures are only
+// logged because the item is gone already.
+func (clipboardService *ClipboardService) DeleteStoredContent(
+	ctx context.Context,
+	clipboardItemModel _jetModel.ClipboardItem,
+) {
+	if !HasStoredContent(clipboardItemModel) {
+		return
+	}
+
+	err := storage.GetObjectStorage().DeleteObject(ctx, clipboardItemModel.Content)
+	if err != nil {
+		clipboardServiceLogger.ErrorAttrs(
+			ctx,
+			err,
+			"failed to delete stored content of clipboard item",
+			slog.String("userEmail", jwt.GetUserEmailClaim(ctx)),
+			slog.String("clipboardItemId", clipboardItemModel.ClipboardItemID),
+		)
+	}
+}
+
+func (clipboardService *ClipboardService) getClipboardItemDownloadUrl(
+	ctx context.Context,
+	clipboardItemId string,
+) (dto.ClipboardItemDownloadUrl, exception.Exception) {
+	downloadUrl, err := getClipboardItemDownloadUrl(ctx, clipboardItemId)
+	if err == nil {
+		return downloadUrl, nil
+	}
+
+	clipboardServiceLogger.ErrorAttrs(
+		ctx,
+		err,
+		"failed to get clipboard item download url",
+		slog.String("userEmail", jwt.GetUserEmailClaim(ctx)),
+		slog.String("clipboardItemId", clipboardItemId),
+	)
+
+	return dto.ClipboardItemDownloadUrl{}, exception.GetAsApplicationException(
+		err,
+		"failed to get clipboard item download url",
+	)
+}
+
+func getClipboardItemDownloadUrl(ctx context.Context, clipboardItemId string) (dto.ClipboardItemDownloadUrl, error) {
+	clipboardItemModel, err := findClipboardItemOrThrow(ctx, nil, jwt.GetUserIdClaim(ctx), clipboardItemId)
+	if err != nil {
+		return dto.ClipboardItemDownloadUrl{}, err
+	}
+
+	return clipboardService.SignDownloadUrl(clipboardItemModel)
+}
+
+// SignDownloadUrl returns a short-lived URL to the stored content of the clipboard item,
+// or a `NotFoundException` when its content is inline.
+func (clipboardService *ClipboardService) SignDownloadUrl(
+	clipboardItemModel _jetModel.ClipboardItem,
+) (dto.ClipboardItemDownloadUrl, error) {
+	if !HasStoredContent(clipboardItemModel) {
+		return dto.ClipboardItemDownloadUrl{}, exception.NewNotFoundException("clipboard item has nothing to download")
+	}
+
+	downloadUrlTtl := storage.GetDownloadUrlTtl()
+	expiresAt := time.Now().Add(downloadUrlTtl)
+
+	downloadUrl, err := storage.GetObjectStorage().SignDownloadUrl(clipboardItemModel.Content, downloadUrlTtl)
+	if err != nil {
+		return dto.ClipboardItemDownloadUrl{}, err
+	}
+
+	return dto.ClipboardItemDownloadUrl{
+		Url:       downloadUrl,
+		ExpiresAt: expiresAt,
+	}, nil
+}
@@ -0,0 +1,34 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/cloudy-clip/api/internal/clipboard/model"
+	_jetModel "github.com/cloudy-clip/api/internal/common/database/.jet/model"
+)
+
+type ClipboardItem struct {
+	ClipboardItemId string                  `json:"clipboardItemId"`
+	Type            model.ClipboardItemType `json:"type"`
+	Content         string                  `json:"content"`
+	IsPinned        bool                    `json:"isPinned"`
+	PinnedAt        *time.Time              `json:"pinnedAt"`
+	CreatedAt       time.Time               `json:"createdAt"`
+	UpdatedAt       time.Time               `json:"updatedAt"`
+	Version         string                  `json:"version"`
+	DeviceId        *string                 `json:"deviceId"`
+}
+
+func NewClipboardItem(clipboardItemModel _jetModel.ClipboardItem) ClipboardItem {
+	return ClipboardItem{
+		ClipboardItemId: clipboardItemModel.ClipboardItemID,
+		Type:            clipboardItemModel.Type,
+		Content:         clipboardItemModel.Content,
+		IsPinned:        clipboardItemModel.IsPinned,
+		PinnedAt:        clipboardItemModel.PinnedAt,
+		CreatedAt:       clipboardItemModel.CreatedAt,
+		UpdatedAt:       clipboardItemModel.UpdatedAt,
+		Version:         clipboardItemModel.Version,
+		DeviceId:        clipboardItemModel.DeviceID,
+	}
+}
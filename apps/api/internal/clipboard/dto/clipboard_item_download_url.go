@@ -0,0 +1,8 @@
+package dto
+
+import "time"
+
+type ClipboardItemDownloadUrl struct {
+	Url       string    `json:"url"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
@@ -0,0 +1,8 @@
+package dto
+
+type ClipboardItemSearchResult struct {
+	ClipboardItem
+	// Fragments of the content with the matched words wrapped in <mark></mark>, the content is HTML-escaped.
+	// Null when the search has no text.
+	Highlight *string `json:"highlight"`
+}
@@ -0,0 +1,8 @@
+package dto
+
+import "github.com/cloudy-clip/api/internal/clipboard/model"
+
+type CreateClipboardItemRequest struct {
+	Type    model.ClipboardItemType `json:"type" validate:"required"`
+	Content string                  `json:"content" validate:"required"`
+}
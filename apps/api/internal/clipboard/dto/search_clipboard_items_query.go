@@ -0,0 +1,19 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/cloudy-clip/api/internal/clipboard/model"
+)
+
+// SearchClipboardItemsQuery leaves out every filter that is empty.
+type SearchClipboardItemsQuery struct {
+	Text        string
+	Types       []model.ClipboardItemType
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+	IsPinned    *bool
+	DeviceId    string
+	Offset      int64
+	Limit       int64
+}
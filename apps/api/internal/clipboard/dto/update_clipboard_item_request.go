@@ -0,0 +1,5 @@
+package dto
+
+type UpdateClipboardItemRequest struct {
+	IsPinned *bool `json:"isPinned" validate:"required"`
+}
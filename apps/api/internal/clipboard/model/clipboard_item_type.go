@@ -0,0 +1,43 @@
+package model
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+type ClipboardItemType string
+
+const (
+	ClipboardItemTypeText  ClipboardItemType = "TEXT"
+	ClipboardItemTypeImage ClipboardItemType = "IMAGE"
+	ClipboardItemTypeUrl   ClipboardItemType = "URL"
+)
+
+func (itemType ClipboardItemType) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + itemType.String() + `"`), nil
+}
+
+func (itemType ClipboardItemType) String() string {
+	return string(itemType)
+}
+
+func (itemType *ClipboardItemType) UnmarshalJSON(buf []byte) error {
+	var itemTypeString string
+	err := json.Unmarshal(buf, &itemTypeString)
+	if err != nil {
+		return err
+	}
+
+	switch itemTypeString {
+	case "TEXT":
+		*itemType = ClipboardItemTypeText
+	case "IMAGE":
+		*itemType = ClipboardItemTypeImage
+	case "URL":
+		*itemType = ClipboardItemTypeUrl
+	default:
+		return errors.New("unknown clipboard item type '" + itemTypeString + "'")
+	}
+
+	return nil
+}
@@ -11,14 +11,20 @@ package table
 // this method only once at the beginning of the program.
 func UseSchema(schema string) {
 	BillingInfoTable = BillingInfoTable.FromSchema(schema)
+	ClipboardEventTable = ClipboardEventTable.FromSchema(schema)
+	ClipboardItemTable = ClipboardItemTable.FromSchema(schema)
+	DeviceTable = DeviceTable.FromSchema(schema)
 	PaymentTable = PaymentTable.FromSchema(schema)
 	PaymentMethodTable = PaymentMethodTable.FromSchema(schema)
 	PlanTable = PlanTable.FromSchema(schema)
 	PlanEntitlementTable = PlanEntitlementTable.FromSchema(schema)
 	PlanOfferingTable = PlanOfferingTable.FromSchema(schema)
+	ShareLinkTable = ShareLinkTable.FromSchema(schema)
+	ShareLinkViewTable = ShareLinkViewTable.FromSchema(schema)
 	SubscriptionTable = SubscriptionTable.FromSchema(schema)
 	TaskTable = TaskTable.FromSchema(schema)
 	TaxRateTable = TaxRateTable.FromSchema(schema)
+	UsageCounterTable = UsageCounterTable.FromSchema(schema)
 	UserTable = UserTable.FromSchema(schema)
 	VerificationCodeTable = VerificationCodeTable.FromSchema(schema)
 }
@@ -159,6 +159,76 @@ func SelectManyTx[T any](ctx context.Context, transaction pgx.Tx, queryBuilder j
 	return result, errors.WithStack(err)
 }
 
+// Listen holds on to a dedicated connection that listens on `channel`, calls `onListening` once
+// the connection is ready, then calls `onNotification` with the payload of every notification
+// until ctx is done or the connection is lost.
+func Listen(
+	ctx context.Context,
+	channel string,
+	onListening func(),
+	onNotification func(payload string),
+) error {
+	connection, err := databaseClient.connectionPool.Acquire(ctx)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	defer func() {
+		_, _ = connection.Exec(context.WithoutCancel(ctx), "UNLISTEN *")
+		connection.Release()
+	}()
+
+	_, err = connection.Exec(ctx, "LISTEN "+pgx.Identifier{channel}.Sanitize())
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	onListening()
+
+	for {
+		notification, err := connection.Conn().WaitForNotification(ctx)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+
+		onNotification(notification.Payload)
+	}
+}
+
+// UseSessionAdvisoryLock holds on to a dedicated connection, tries to take the session level
+// advisory lock for `lockKey` and calls `fn` only if it got it, so that a single instance runs
+// `fn` at a time. Reports whether `fn` was called.
+func UseSessionAdvisoryLock(ctx context.Context, lockKey string, fn func() error) (bool, error) {
+	connection, err := databaseClient.connectionPool.Acquire(ctx)
+	if err != nil {
+		return false, errors.WithStack(err)
+	}
+
+	defer connection.Release()
+
+	var isLocked bool
+
+	err = connection.QueryRow(ctx, "SELECT pg_try_advisory_lock(hashtext($1))", lockKey).Scan(&isLocked)
+	if err != nil {
+		return false, errors.WithStack(err)
+	}
+
+	if !isLocked {
+		return false, nil
+	}
+
+	defer func() {
+		_, unlockErr := connection.Exec(context.WithoutCancel(ctx), "SELECT pg_advisory_unlock(hashtext($1))", lockKey)
+		if unlockErr != nil {
+			// Closing the connection rather than returning it to the pool is the only other way to
+			// release the lock.
+			_ = connection.Conn().Close(context.WithoutCancel(ctx))
+		}
+	}()
+
+	return true, fn()
+}
+
 func Close() {
 	databaseClient.connectionPool.Close()
 }
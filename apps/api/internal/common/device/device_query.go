@@ -0,0 +1,51 @@
+package device
+
+import (
+	"context"
+	"time"
+
+	"github.com/cloudy-clip/api/internal/common/database"
+	"github.com/cloudy-clip/api/internal/common/database/.jet/model"
+	"github.com/cloudy-clip/api/internal/common/database/.jet/table"
+	"github.com/go-jet/jet/v2/postgres"
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	// How stale the last seen timestamp of a device gets before it is refreshed,
+	// so that authenticated requests do not write to the database every time.
+	lastSeenRefreshInterval = 5 * time.Minute
+)
+
+func FindDeviceById(
+	ctx context.Context,
+	transaction pgx.Tx,
+	userId string,
+	deviceId string,
+) (model.Device, error) {
+	queryBuilder := table.DeviceTable.
+		SELECT(table.DeviceTable.AllColumns.As("")).
+		WHERE(
+			table.DeviceTable.DeviceID.EQ(postgres.String(deviceId)).
+				AND(table.DeviceTable.UserID.EQ(postgres.String(userId))),
+		).
+		LIMIT(1)
+
+	if transaction != nil {
+		return database.SelectOneTx[model.Device](ctx, transaction, queryBuilder)
+	}
+
+	return database.SelectOne[model.Device](ctx, queryBuilder)
+}
+
+func MarkDeviceAsSeen(ctx context.Context, deviceId string, seenAt time.Time) error {
+	queryBuilder := table.DeviceTable.
+		UPDATE(table.DeviceTable.LastSeenAt).
+		SET(postgres.TimestampzT(seenAt)).
+		WHERE(
+			table.DeviceTable.DeviceID.EQ(postgres.String(deviceId)).
+				AND(table.DeviceTable.LastSeenAt.LT(postgres.TimestampzT(seenAt.Add(-lastSeenRefreshInterval)))),
+		)
+
+	return database.Exec(ctx, queryBuilder)
+}
@@ -31,6 +31,7 @@ type config struct {
 	EmailProviderApiKey         string           `env:"EMAIL_PROVIDER_API_KEY,notEmpty"`
 	EmailProviderDomain         string           `env:"EMAIL_PROVIDER_DOMAIN,notEmpty"`
 	ExecutionProfile            ExecutionProfile `env:"EXECUTION_PROFILE,notEmpty"`
+	ImageUploadMaxSizeBytes     int64            `env:"IMAGE_UPLOAD_MAX_SIZE_BYTES,notEmpty"`
 	JwtIssuer                   string           `env:"JWT_ISSUER,notEmpty"`
 	JwtTtlSeconds               uint16           `env:"JWT_TTL_SECONDS,notEmpty"`
 	Oauth2DiscordClientId       string           `env:"OAUTH2_DISCORD_CLIENT_ID,notEmpty"`
@@ -39,6 +40,16 @@ type config struct {
 	Oauth2FacebookAppSecret     string           `env:"OAUTH2_FACEBOOK_APP_SECRET,notEmpty"`
 	Oauth2GoogleClientId        string           `env:"OAUTH2_GOOGLE_CLIENT_ID,notEmpty"`
 	Oauth2GoogleClientSecret    string           `env:"OAUTH2_GOOGLE_CLIENT_SECRET,notEmpty"`
+	StorageBackend              string           `env:"OBJECT_STORAGE_BACKEND,notEmpty"`
+	StorageUrlTtlSeconds        uint16           `env:"OBJECT_STORAGE_DOWNLOAD_URL_TTL_SECONDS,notEmpty"`
+	StorageLocalBaseUrl         string           `env:"OBJECT_STORAGE_LOCAL_BASE_URL" envDefault:""`
+	StorageLocalDirectory       string           `env:"OBJECT_STORAGE_LOCAL_DIRECTORY" envDefault:""`
+	StorageS3AccessKeyId        string           `env:"OBJECT_STORAGE_S3_ACCESS_KEY_ID" envDefault:""`
+	StorageS3Bucket             string           `env:"OBJECT_STORAGE_S3_BUCKET" envDefault:""`
+	StorageS3Endpoint           string           `env:"OBJECT_STORAGE_S3_ENDPOINT" envDefault:""`
+	StorageS3Region             string           `env:"OBJECT_STORAGE_S3_REGION" envDefault:""`
+	StorageS3SecretAccessKey    string           `env:"OBJECT_STORAGE_S3_SECRET_ACCESS_KEY" envDefault:""`
+	StorageS3UsePathStyle       bool             `env:"OBJECT_STORAGE_S3_USE_PATH_STYLE" envDefault:"false"`
 	ServerHost                  string           `env:"SERVER_HOST,notEmpty"`
 	ServerIdleTimeout           uint8            `env:"SERVER_IDLE_TIMEOUT,notEmpty"`
 	ServerPort                  string           `env:"SERVER_PORT,notEmpty"`
@@ -0,0 +1,27 @@
+package exception
+
+import "net/http"
+
+type EntitlementLimitExceededException struct {
+	ApplicationException
+}
+
+func NewEntitlementLimitExceededException(
+	entitlementType string,
+	limit int,
+	currentUsage int,
+) EntitlementLimitExceededException {
+	applicationException := ApplicationException{
+		Message:    "entitlement limit for \"" + entitlementType + "\" was exceeded",
+		StatusCode: http.StatusForbidden,
+		Extra: map[string]any{
+			"entitlementType": entitlementType,
+			"limit":           limit,
+			"currentUsage":    currentUsage,
+		},
+	}
+
+	return EntitlementLimitExceededException{
+		applicationException,
+	}
+}
@@ -0,0 +1,19 @@
+package exception
+
+import "net/http"
+
+type PayloadTooLargeException struct {
+	ApplicationException
+}
+
+func NewPayloadTooLargeExceptionWithExtra(message string, extra map[string]any) PayloadTooLargeException {
+	applicationException := ApplicationException{
+		Message:    message,
+		StatusCode: http.StatusRequestEntityTooLarge,
+		Extra:      extra,
+	}
+
+	return PayloadTooLargeException{
+		applicationException,
+	}
+}
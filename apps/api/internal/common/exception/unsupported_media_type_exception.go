@@ -0,0 +1,19 @@
+package exception
+
+import "net/http"
+
+type UnsupportedMediaTypeException struct {
+	ApplicationException
+}
+
+func NewUnsupportedMediaTypeExceptionWithExtra(message string, extra map[string]any) UnsupportedMediaTypeException {
+	applicationException := ApplicationException{
+		Message:    message,
+		StatusCode: http.StatusUnsupportedMediaType,
+		Extra:      extra,
+	}
+
+	return UnsupportedMediaTypeException{
+		applicationException,
+	}
+}
@@ -0,0 +1,36 @@
+package hashing
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/argon2"
+)
+
+func GenerateSalt() ([]byte, error) {
+	salt := make([]byte, 32)
+	_, err := rand.Read(salt)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return salt, nil
+}
+
+func HashPassword(password string, salt []byte) []byte {
+	// https://cheatsheetseries.owasp.org/cheatsheets/Password_Storage_Cheat_Sheet.html#password-hashing-algorithms
+	const (
+		t         = 5
+		m         = 7168
+		p         = 1
+		keyLength = 32
+	)
+
+	return argon2.IDKey([]byte(password), salt, t, m, p, keyLength)
+}
+
+// IsPasswordCorrect compares in constant time so that the comparison leaks nothing about `hashedPassword`.
+func IsPasswordCorrect(passwordToCheck string, hashedPassword []byte, salt []byte) bool {
+	return subtle.ConstantTimeCompare(hashedPassword, HashPassword(passwordToCheck, salt)) == 1
+}
@@ -15,9 +15,10 @@ import (
 type JwtClaim string
 
 const (
-	JwtCookieName           = "lc__jv__j"
-	JwtClaimUserId JwtClaim = "userId"
-	JwtClaimEmail  JwtClaim = "email"
+	JwtCookieName             = "lc__jv__j"
+	JwtClaimUserId   JwtClaim = "userId"
+	JwtClaimEmail    JwtClaim = "email"
+	JwtClaimDeviceId JwtClaim = "deviceId"
 )
 
 func JwtVerifierMiddleware(logger *logger.Logger) func(http.Handler) http.Handler {
@@ -75,6 +76,29 @@ func JwtVerifierMiddleware(logger *logger.Logger) func(http.Handler) http.Handle
 			contextWithJwtClaims := context.WithValue(request.Context(), JwtClaimUserId, jwtClaims["sub"])
 			contextWithJwtClaims = context.WithValue(contextWithJwtClaims, JwtClaimEmail, jwtClaims["email"])
 
+			if deviceId, ok := jwtClaims["deviceId"].(string); ok && deviceId != "" {
+				contextWithJwtClaims = context.WithValue(contextWithJwtClaims, JwtClaimDeviceId, deviceId)
+
+				err = VerifyDevice(contextWithJwtClaims, GetUserIdClaim(contextWithJwtClaims), deviceId)
+				if err != nil {
+					logger.ErrorAttrs(
+						contextWithJwtClaims,
+						err,
+						"failed to verify device of jwt",
+						slog.String("userEmail", GetUserEmailClaim(contextWithJwtClaims)),
+						slog.String("deviceId", deviceId),
+					)
+
+					_http.WriteErrorResponse(
+						request,
+						responseWriter,
+						exception.NewUnauthorizedException("device is no longer allowed to sign in"),
+					)
+
+					return
+				}
+			}
+
 			next.ServeHTTP(responseWriter, request.WithContext(contextWithJwtClaims))
 		}
 
@@ -122,3 +146,10 @@ func GetUserEmailClaim(ctx context.Context) string {
 func GetUserIdClaim(ctx context.Context) string {
 	return ctx.Value(JwtClaimUserId).(string)
 }
+
+// GetDeviceIdClaim returns an empty string when the jwt was not issued to a registered device.
+func GetDeviceIdClaim(ctx context.Context) string {
+	deviceId, _ := ctx.Value(JwtClaimDeviceId).(string)
+
+	return deviceId
+}
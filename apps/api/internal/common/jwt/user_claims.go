@@ -12,5 +12,7 @@ type UserClaims struct {
 	DisplayName    string    `json:"displayName"`
 	Status         string    `json:"status"`
 	LastLoggedInAt time.Time `json:"lastLoggedInAt"`
+	// Only set for credentials issued to a registered device, so that they stop working once it is revoked.
+	DeviceId string `json:"deviceId,omitempty"`
 	jwt.RegisteredClaims
 }
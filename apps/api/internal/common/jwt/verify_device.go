@@ -0,0 +1,26 @@
+package jwt
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/cloudy-clip/api/internal/common/device"
+)
+
+var ErrDeviceRevoked = errors.New("device has been revoked")
+
+// VerifyDevice fails when the device that credentials were issued to no longer exists or was revoked,
+// and keeps track of when the device was last seen otherwise.
+func VerifyDevice(ctx context.Context, userId string, deviceId string) error {
+	foundDevice, err := device.FindDeviceById(ctx, nil, userId, deviceId)
+	if err != nil {
+		return err
+	}
+
+	if foundDevice.RevokedAt != nil {
+		return ErrDeviceRevoked
+	}
+
+	return device.MarkDeviceAsSeen(ctx, deviceId, time.Now())
+}
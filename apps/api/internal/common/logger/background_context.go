@@ -0,0 +1,17 @@
+package logger
+
+import (
+	"context"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/oklog/ulid/v2"
+)
+
+// NewBackgroundContext returns a context carrying the values that the logger expects to find,
+// for work that runs outside of any incoming request such as scheduled jobs.
+func NewBackgroundContext(callSite string) context.Context {
+	ctx := context.WithValue(context.Background(), middleware.RequestIDKey, ulid.Make().String())
+	ctx = context.WithValue(ctx, LoggerContextRemoteAddrKey, "")
+
+	return context.WithValue(ctx, LoggerContextCallSiteKey, callSite)
+}
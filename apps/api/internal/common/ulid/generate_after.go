@@ -0,0 +1,31 @@
+package ulid
+
+import (
+	ulid "github.com/oklog/ulid/v2"
+	"github.com/pkg/errors"
+)
+
+// GenerateAfter returns a new ULID that sorts after `previousId`, even when `previousId` was
+// generated within the same millisecond or by an instance whose clock is ahead. An empty
+// `previousId` behaves like `GenerateWithRetry`.
+func GenerateAfter(previousId string) (string, error) {
+	nextId, err := GenerateWithRetry()
+	if err != nil || previousId == "" || nextId > previousId {
+		return nextId, err
+	}
+
+	parsedPreviousId, err := ulid.ParseStrict(previousId)
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+
+	// Bytes after the 48-bit timestamp hold the entropy, incrementing it keeps the timestamp.
+	for i := len(parsedPreviousId) - 1; i >= 6; i-- {
+		parsedPreviousId[i]++
+		if parsedPreviousId[i] != 0 {
+			return parsedPreviousId.String(), nil
+		}
+	}
+
+	return "", errors.WithStack(ulid.ErrMonotonicOverflow)
+}
@@ -0,0 +1,8 @@
+package utils
+
+import "strings"
+
+// CountWords returns the number of whitespace-separated words in `text`.
+func CountWords(text string) int {
+	return len(strings.Fields(text))
+}
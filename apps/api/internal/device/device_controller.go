@@ -0,0 +1,152 @@
+package device
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/cloudy-clip/api/internal/common/environment"
+	_http "github.com/cloudy-clip/api/internal/common/http"
+	"github.com/cloudy-clip/api/internal/common/http/middleware/context"
+	"github.com/cloudy-clip/api/internal/common/jwt"
+	"github.com/cloudy-clip/api/internal/common/logger"
+	"github.com/cloudy-clip/api/internal/device/dto"
+	"github.com/cloudy-clip/api/internal/user"
+	"github.com/go-chi/chi/v5"
+)
+
+var (
+	deviceService          *DeviceService
+	deviceRepository       *DeviceRepository
+	deviceControllerLogger *logger.Logger
+)
+
+func SetupDeviceControllerEndpoints(parentRouter chi.Router) {
+	deviceRepository = NewDeviceRepository()
+	deviceService = NewDeviceService()
+	deviceControllerLogger = logger.NewLogger(
+		"DeviceController", slog.Level(environment.Config.ApplicationLogLevel),
+	)
+
+	parentRouter.Route("/v1/users/me/devices", func(v1Router chi.Router) {
+		v1Router.Group(func(router chi.Router) {
+			router.Use(
+				context.CallSiteMiddleware("handleDeviceRegistration"),
+				jwt.JwtVerifierMiddleware(deviceControllerLogger),
+			)
+			router.Post("/", handleDeviceRegistration())
+		})
+
+		v1Router.Group(func(router chi.Router) {
+			router.Use(
+				context.CallSiteMiddleware("handleGettingDevices"),
+				jwt.JwtVerifierMiddleware(deviceControllerLogger),
+			)
+			router.Get("/", handleGettingDevices())
+		})
+
+		v1Router.Group(func(router chi.Router) {
+			router.Use(
+				context.CallSiteMiddleware("handleRenamingDevice"),
+				jwt.JwtVerifierMiddleware(deviceControllerLogger),
+			)
+			router.Patch("/{deviceId}", handleRenamingDevice())
+		})
+
+		v1Router.Group(func(router chi.Router) {
+			router.Use(
+				context.CallSiteMiddleware("handleRevokingDevice"),
+				jwt.JwtVerifierMiddleware(deviceControllerLogger),
+			)
+			router.Delete("/{deviceId}", handleRevokingDevice())
+		})
+	})
+}
+
+func handleDeviceRegistration() http.HandlerFunc {
+	return _http.GetResponseSender(
+		http.StatusCreated,
+		func(request *http.Request, responseWriter http.ResponseWriter) (any, error) {
+			ctx := request.Context()
+
+			var registerDeviceRequest dto.RegisterDeviceRequest
+			err := _http.ReadRequestBodyAs(request, deviceControllerLogger, &registerDeviceRequest)
+			if err != nil {
+				deviceControllerLogger.ErrorAttrs(
+					ctx,
+					err,
+					"failed to parse request body",
+					slog.String("userEmail", jwt.GetUserEmailClaim(ctx)),
+				)
+
+				return nil, err
+			}
+
+			registeredDevice, authenticatedUser, err := deviceService.registerDevice(
+				ctx,
+				registerDeviceRequest,
+				request.RemoteAddr,
+				request.UserAgent(),
+			)
+			if err == nil {
+				user.SetAuthenticationCookies(responseWriter, authenticatedUser)
+			}
+
+			return registeredDevice, err
+		},
+	)
+}
+
+func handleGettingDevices() http.HandlerFunc {
+	return _http.GetResponseSender(
+		http.StatusOK,
+		func(request *http.Request, responseWriter http.ResponseWriter) (any, error) {
+			return deviceService.getDevices(request.Context())
+		},
+	)
+}
+
+func handleRenamingDevice() http.HandlerFunc {
+	return _http.GetResponseSender(
+		http.StatusOK,
+		func(request *http.Request, responseWriter http.ResponseWriter) (any, error) {
+			ctx := request.Context()
+			deviceId := chi.URLParam(request, "deviceId")
+
+			var renameDeviceRequest dto.RenameDeviceRequest
+			err := _http.ReadRequestBodyAs(request, deviceControllerLogger, &renameDeviceRequest)
+			if err != nil {
+				deviceControllerLogger.ErrorAttrs(
+					ctx,
+					err,
+					"failed to parse request body",
+					slog.String("userEmail", jwt.GetUserEmailClaim(ctx)),
+					slog.String("deviceId", deviceId),
+					slog.Any("requestBody", renameDeviceRequest),
+				)
+
+				return nil, err
+			}
+
+			return deviceService.renameDevice(ctx, deviceId, renameDeviceRequest)
+		},
+	)
+}
+
+func handleRevokingDevice() http.HandlerFunc {
+	return _http.GetEmptyResponseSender(func(request *http.Request, responseWriter http.ResponseWriter) error {
+		ctx := request.Context()
+		deviceId := chi.URLParam(request, "deviceId")
+
+		err := deviceService.revokeDevice(ctx, deviceId)
+		if err != nil {
+			return err
+		}
+
+		// The credentials of this very device would be rejected from now on anyway.
+		if deviceId == jwt.GetDeviceIdClaim(ctx) {
+			user.SetAuthenticationCookies(responseWriter, nil)
+		}
+
+		return nil
+	})
+}
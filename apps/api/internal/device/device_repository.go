@@ -0,0 +1,87 @@
+package device
+
+import (
+	"context"
+	"time"
+
+	"github.com/cloudy-clip/api/internal/common/database"
+	_jetModel "github.com/cloudy-clip/api/internal/common/database/.jet/model"
+	"github.com/cloudy-clip/api/internal/common/database/.jet/table"
+	jet "github.com/go-jet/jet/v2/postgres"
+	"github.com/jackc/pgx/v5"
+)
+
+type DeviceRepository struct {
+}
+
+func NewDeviceRepository() *DeviceRepository {
+	return &DeviceRepository{}
+}
+
+func (deviceRepository *DeviceRepository) createDevice(
+	ctx context.Context,
+	transaction pgx.Tx,
+	deviceModel _jetModel.Device,
+) error {
+	queryBuilder := table.DeviceTable.
+		INSERT(table.DeviceTable.AllColumns).
+		MODEL(deviceModel)
+
+	if transaction != nil {
+		return database.ExecTx(ctx, transaction, queryBuilder)
+	}
+
+	return database.Exec(ctx, queryBuilder)
+}
+
+func (deviceRepository *DeviceRepository) getDevicesForUser(
+	ctx context.Context,
+	userId string,
+) ([]_jetModel.Device, error) {
+	deviceTable := table.DeviceTable
+	queryBuilder := deviceTable.
+		SELECT(deviceTable.AllColumns.As("")).
+		WHERE(deviceTable.UserID.EQ(jet.String(userId))).
+		ORDER_BY(deviceTable.CreatedAt.DESC(), deviceTable.DeviceID.DESC())
+
+	return database.SelectMany[_jetModel.Device](ctx, queryBuilder)
+}
+
+func (deviceRepository *DeviceRepository) updateDeviceName(
+	ctx context.Context,
+	userId string,
+	deviceId string,
+	name string,
+) (_jetModel.Device, error) {
+	deviceTable := table.DeviceTable
+	queryBuilder := deviceTable.
+		UPDATE(deviceTable.Name, deviceTable.UpdatedAt).
+		SET(name, time.Now()).
+		WHERE(
+			deviceTable.DeviceID.EQ(jet.String(deviceId)).
+				AND(deviceTable.UserID.EQ(jet.String(userId))),
+		).
+		RETURNING(deviceTable.AllColumns.As(""))
+
+	return database.SelectOne[_jetModel.Device](ctx, queryBuilder)
+}
+
+// revokeDevice keeps the time the device was first revoked at when it is revoked again.
+func (deviceRepository *DeviceRepository) revokeDevice(
+	ctx context.Context,
+	userId string,
+	deviceId string,
+) (_jetModel.Device, error) {
+	deviceTable := table.DeviceTable
+	now := time.Now()
+	queryBuilder := deviceTable.
+		UPDATE(deviceTable.RevokedAt, deviceTable.UpdatedAt).
+		SET(jet.COALESCE(deviceTable.RevokedAt, jet.TimestampzT(now)), now).
+		WHERE(
+			deviceTable.DeviceID.EQ(jet.String(deviceId)).
+				AND(deviceTable.UserID.EQ(jet.String(userId))),
+		).
+		RETURNING(deviceTable.AllColumns.As(""))
+
+	return database.SelectOne[_jetModel.Device](ctx, queryBuilder)
+}
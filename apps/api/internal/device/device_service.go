@@ -0,0 +1,187 @@
+package device
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/cloudy-clip/api/internal/common/database"
+	_jetModel "github.com/cloudy-clip/api/internal/common/database/.jet/model"
+	"github.com/cloudy-clip/api/internal/common/environment"
+	"github.com/cloudy-clip/api/internal/common/exception"
+	"github.com/cloudy-clip/api/internal/common/jwt"
+	_logger "github.com/cloudy-clip/api/internal/common/logger"
+	"github.com/cloudy-clip/api/internal/common/ulid"
+	"github.com/cloudy-clip/api/internal/device/dto"
+	"github.com/cloudy-clip/api/internal/user"
+	_userDto "github.com/cloudy-clip/api/internal/user/dto"
+	"github.com/jackc/pgx/v5"
+)
+
+var (
+	deviceServiceLogger *_logger.Logger
+)
+
+type DeviceService struct {
+}
+
+func NewDeviceService() *DeviceService {
+	deviceServiceLogger = _logger.NewLogger(
+		"DeviceService",
+		slog.Level(environment.Config.ApplicationLogLevel),
+	)
+
+	return &DeviceService{}
+}
+
+// registerDevice also returns credentials that are bound to the registered device,
+// which replace the ones used to register it.
+func (deviceService *DeviceService) registerDevice(
+	ctx context.Context,
+	registerDeviceRequest dto.RegisterDeviceRequest,
+	userIp string,
+	userAgent string,
+) (dto.Device, *_userDto.AuthenticatedUser, exception.Exception) {
+	registeredDevice, authenticatedUser, err := registerDevice(ctx, registerDeviceRequest, userIp, userAgent)
+	if err == nil {
+		return registeredDevice, authenticatedUser, nil
+	}
+
+	deviceServiceLogger.ErrorAttrs(
+		ctx,
+		err,
+		"failed to register device",
+		slog.String("userEmail", jwt.GetUserEmailClaim(ctx)),
+		slog.Any("requestBody", registerDeviceRequest),
+	)
+
+	return dto.Device{}, nil, exception.GetAsApplicationException(err, "failed to register device")
+}
+
+func registerDevice(
+	ctx context.Context,
+	registerDeviceRequest dto.RegisterDeviceRequest,
+	userIp string,
+	userAgent string,
+) (dto.Device, *_userDto.AuthenticatedUser, error) {
+	deviceId, err := ulid.GenerateWithRetry()
+	if err != nil {
+		return dto.Device{}, nil, err
+	}
+
+	now := time.Now()
+	deviceModel := _jetModel.Device{
+		DeviceID:   deviceId,
+		Name:       registerDeviceRequest.Name,
+		Platform:   registerDeviceRequest.Platform,
+		AppVersion: registerDeviceRequest.AppVersion,
+		UserID:     jwt.GetUserIdClaim(ctx),
+		LastSeenAt: now,
+		RevokedAt:  nil,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+
+	var authenticatedUser *_userDto.AuthenticatedUser
+
+	err = database.UseTransaction(ctx, func(transaction pgx.Tx) error {
+		err := deviceRepository.createDevice(ctx, transaction, deviceModel)
+		if err != nil {
+			return err
+		}
+
+		authenticatedUser, err = user.
+			GetUserService().
+			StartDeviceSession(ctx, deviceId, userIp, userAgent)
+
+		return err
+	})
+	if err == nil {
+		return dto.NewDevice(deviceModel, deviceId), authenticatedUser, nil
+	}
+
+	return dto.Device{}, nil, err
+}
+
+func (deviceService *DeviceService) getDevices(ctx context.Context) ([]dto.Device, exception.Exception) {
+	deviceModels, err := deviceRepository.getDevicesForUser(ctx, jwt.GetUserIdClaim(ctx))
+	if err == nil {
+		currentDeviceId := jwt.GetDeviceIdClaim(ctx)
+		devices := make([]dto.Device, 0, len(deviceModels))
+
+		for _, deviceModel := range deviceModels {
+			devices = append(devices, dto.NewDevice(deviceModel, currentDeviceId))
+		}
+
+		return devices, nil
+	}
+
+	deviceServiceLogger.ErrorAttrs(
+		ctx,
+		err,
+		"failed to get devices",
+		slog.String("userEmail", jwt.GetUserEmailClaim(ctx)),
+	)
+
+	return nil, exception.NewUnknownException("failed to get devices")
+}
+
+func (deviceService *DeviceService) renameDevice(
+	ctx context.Context,
+	deviceId string,
+	renameDeviceRequest dto.RenameDeviceRequest,
+) (dto.Device, exception.Exception) {
+	deviceModel, err := deviceRepository.updateDeviceName(
+		ctx,
+		jwt.GetUserIdClaim(ctx),
+		deviceId,
+		renameDeviceRequest.Name,
+	)
+	if err == nil {
+		return dto.NewDevice(deviceModel, jwt.GetDeviceIdClaim(ctx)), nil
+	}
+
+	deviceServiceLogger.ErrorAttrs(
+		ctx,
+		err,
+		"failed to rename device",
+		slog.String("userEmail", jwt.GetUserEmailClaim(ctx)),
+		slog.String("deviceId", deviceId),
+	)
+
+	if database.IsEmptyResultError(err) {
+		return dto.Device{}, exception.NewNotFoundException("no device was found")
+	}
+
+	return dto.Device{}, exception.NewUnknownException("failed to rename device")
+}
+
+// revokeDevice makes the credentials issued to the device stop working, its open event streams
+// are closed by their next heartbeat.
+func (deviceService *DeviceService) revokeDevice(ctx context.Context, deviceId string) exception.Exception {
+	_, err := deviceRepository.revokeDevice(ctx, jwt.GetUserIdClaim(ctx), deviceId)
+	if err == nil {
+		deviceServiceLogger.InfoAttrs(
+			ctx,
+			"revoked device",
+			slog.String("userEmail", jwt.GetUserEmailClaim(ctx)),
+			slog.String("deviceId", deviceId),
+		)
+
+		return nil
+	}
+
+	deviceServiceLogger.ErrorAttrs(
+		ctx,
+		err,
+		"failed to revoke device",
+		slog.String("userEmail", jwt.GetUserEmailClaim(ctx)),
+		slog.String("deviceId", deviceId),
+	)
+
+	if database.IsEmptyResultError(err) {
+		return exception.NewNotFoundException("no device was found")
+	}
+
+	return exception.NewUnknownException("failed to revoke device")
+}
@@ -0,0 +1,34 @@
+package dto
+
+import (
+	"time"
+
+	_jetModel "github.com/cloudy-clip/api/internal/common/database/.jet/model"
+	"github.com/cloudy-clip/api/internal/device/model"
+)
+
+type Device struct {
+	DeviceId        string               `json:"deviceId"`
+	Name            string               `json:"name"`
+	Platform        model.DevicePlatform `json:"platform"`
+	AppVersion      string               `json:"appVersion"`
+	IsCurrentDevice bool                 `json:"isCurrentDevice"`
+	LastSeenAt      time.Time            `json:"lastSeenAt"`
+	RevokedAt       *time.Time           `json:"revokedAt"`
+	CreatedAt       time.Time            `json:"createdAt"`
+	UpdatedAt       time.Time            `json:"updatedAt"`
+}
+
+func NewDevice(deviceModel _jetModel.Device, currentDeviceId string) Device {
+	return Device{
+		DeviceId:        deviceModel.DeviceID,
+		Name:            deviceModel.Name,
+		Platform:        deviceModel.Platform,
+		AppVersion:      deviceModel.AppVersion,
+		IsCurrentDevice: deviceModel.DeviceID == currentDeviceId,
+		LastSeenAt:      deviceModel.LastSeenAt,
+		RevokedAt:       deviceModel.RevokedAt,
+		CreatedAt:       deviceModel.CreatedAt,
+		UpdatedAt:       deviceModel.UpdatedAt,
+	}
+}
@@ -0,0 +1,9 @@
+package dto
+
+import "github.com/cloudy-clip/api/internal/device/model"
+
+type RegisterDeviceRequest struct {
+	Name       string               `json:"name" validate:"required,max=64"`
+	Platform   model.DevicePlatform `json:"platform" validate:"required"`
+	AppVersion string               `json:"appVersion" validate:"required,max=32"`
+}
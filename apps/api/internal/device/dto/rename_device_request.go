@@ -0,0 +1,5 @@
+package dto
+
+type RenameDeviceRequest struct {
+	Name string `json:"name" validate:"required,max=64"`
+}
@@ -0,0 +1,43 @@
+package model
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+type DevicePlatform string
+
+const (
+	DevicePlatformMacos   DevicePlatform = "MACOS"
+	DevicePlatformWindows DevicePlatform = "WINDOWS"
+	DevicePlatformLinux   DevicePlatform = "LINUX"
+)
+
+func (platform DevicePlatform) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + platform.String() + `"`), nil
+}
+
+func (platform DevicePlatform) String() string {
+	return string(platform)
+}
+
+func (platform *DevicePlatform) UnmarshalJSON(buf []byte) error {
+	var platformString string
+	err := json.Unmarshal(buf, &platformString)
+	if err != nil {
+		return err
+	}
+
+	switch platformString {
+	case "MACOS":
+		*platform = DevicePlatformMacos
+	case "WINDOWS":
+		*platform = DevicePlatformWindows
+	case "LINUX":
+		*platform = DevicePlatformLinux
+	default:
+		return errors.New("unknown device platform '" + platformString + "'")
+	}
+
+	return nil
+}
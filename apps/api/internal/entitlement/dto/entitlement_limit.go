@@ -0,0 +1,20 @@
+package dto
+
+import "github.com/cloudy-clip/api/internal/entitlement/model"
+
+type EntitlementLimit struct {
+	Type         model.EntitlementType `json:"type"`
+	Quantity     int                   `json:"quantity"`
+	IsRestricted bool                  `json:"isRestricted"`
+}
+
+// IsUnlimited returns true when the plan does not put any cap on this entitlement,
+// in which case `Quantity` carries no meaning.
+func (limit EntitlementLimit) IsUnlimited() bool {
+	return !limit.IsRestricted
+}
+
+// Allows returns true when `usage` stays within the limit.
+func (limit EntitlementLimit) Allows(usage int) bool {
+	return limit.IsUnlimited() || usage <= limit.Quantity
+}
@@ -0,0 +1,69 @@
+package dto
+
+import (
+	"github.com/cloudy-clip/api/internal/entitlement/model"
+	_subscriptionDto "github.com/cloudy-clip/api/internal/subscription/dto"
+	"github.com/pkg/errors"
+)
+
+type UserEntitlements struct {
+	PlanName        string           `json:"planName"`
+	WordCount       EntitlementLimit `json:"wordCount"`
+	ImageUpload     EntitlementLimit `json:"imageUpload"`
+	RetentionPeriod EntitlementLimit `json:"retentionPeriod"`
+}
+
+func NewUserEntitlements(plan *_subscriptionDto.Plan) UserEntitlements {
+	userEntitlements := UserEntitlements{
+		PlanName: plan.DisplayName,
+	}
+
+	for _, entitlement := range plan.Entitlements {
+		limit := EntitlementLimit{
+			Type:         model.EntitlementType(entitlement.Type),
+			Quantity:     entitlement.Quantity,
+			IsRestricted: entitlement.IsRestricted,
+		}
+
+		switch limit.Type {
+		case model.EntitlementTypeWordCount:
+			userEntitlements.WordCount = limit
+		case model.EntitlementTypeImageUpload:
+			userEntitlements.ImageUpload = limit
+		case model.EntitlementTypeRetentionPeriod:
+			userEntitlements.RetentionPeriod = limit
+		}
+	}
+
+	return userEntitlements
+}
+
+func (userEntitlements UserEntitlements) GetLimit(entitlementType model.EntitlementType) (EntitlementLimit, error) {
+	switch entitlementType {
+	case model.EntitlementTypeWordCount:
+		return userEntitlements.WordCount, nil
+	case model.EntitlementTypeImageUpload:
+		return userEntitlements.ImageUpload, nil
+	case model.EntitlementTypeRetentionPeriod:
+		return userEntitlements.RetentionPeriod, nil
+	}
+
+	return EntitlementLimit{}, errors.Errorf("unknown entitlement type '%s'", entitlementType)
+}
+
+// GetLimits returns the limits of every entitlement that the plan defines.
+func (userEntitlements UserEntitlements) GetLimits() []EntitlementLimit {
+	limits := make([]EntitlementLimit, 0, 3)
+
+	for _, limit := range []EntitlementLimit{
+		userEntitlements.WordCount,
+		userEntitlements.ImageUpload,
+		userEntitlements.RetentionPeriod,
+	} {
+		if limit.Type != "" {
+			limits = append(limits, limit)
+		}
+	}
+
+	return limits
+}
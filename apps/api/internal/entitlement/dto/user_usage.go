@@ -0,0 +1,24 @@
+package dto
+
+import "github.com/cloudy-clip/api/internal/entitlement/model"
+
+type UserUsage struct {
+	PlanName     string             `json:"planName"`
+	Entitlements []EntitlementUsage `json:"entitlements"`
+}
+
+type EntitlementUsage struct {
+	Type         model.EntitlementType `json:"type"`
+	Limit        int                   `json:"limit"`
+	IsRestricted bool                  `json:"isRestricted"`
+	CurrentUsage int                   `json:"currentUsage"`
+}
+
+func NewEntitlementUsage(limit EntitlementLimit, currentUsage int) EntitlementUsage {
+	return EntitlementUsage{
+		Type:         limit.Type,
+		Limit:        limit.Quantity,
+		IsRestricted: limit.IsRestricted,
+		CurrentUsage: currentUsage,
+	}
+}
@@ -0,0 +1,45 @@
+package entitlement
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/cloudy-clip/api/internal/common/environment"
+	_http "github.com/cloudy-clip/api/internal/common/http"
+	"github.com/cloudy-clip/api/internal/common/http/middleware/context"
+	"github.com/cloudy-clip/api/internal/common/jwt"
+	"github.com/cloudy-clip/api/internal/common/logger"
+	"github.com/go-chi/chi/v5"
+)
+
+var (
+	entitlementControllerLogger *logger.Logger
+)
+
+func SetupEntitlementControllerEndpoints(parentRouter chi.Router) {
+	entitlementRepository = NewEntitlementRepository()
+	entitlementService = NewEntitlementService()
+	entitlementControllerLogger = logger.NewLogger(
+		"EntitlementController", slog.Level(environment.Config.ApplicationLogLevel),
+	)
+
+	parentRouter.Route("/v1/users/me/usage", func(v1Router chi.Router) {
+		v1Router.Group(func(router chi.Router) {
+			router.Use(
+				context.CallSiteMiddleware("handleGettingUserUsage"),
+				jwt.JwtVerifierMiddleware(entitlementControllerLogger),
+			)
+			router.Get("/", handleGettingUserUsage())
+		})
+	})
+}
+
+// handleGettingUserUsage returns how much of every entitlement of the user's plan they have consumed.
+func handleGettingUserUsage() http.HandlerFunc {
+	return _http.GetResponseSender(
+		http.StatusOK,
+		func(request *http.Request, responseWriter http.ResponseWriter) (any, error) {
+			return entitlementService.getUserUsage(request.Context())
+		},
+	)
+}
@@ -0,0 +1,85 @@
+package entitlement
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	"github.com/cloudy-clip/api/internal/common/exception"
+	_http "github.com/cloudy-clip/api/internal/common/http"
+	"github.com/cloudy-clip/api/internal/common/jwt"
+	"github.com/cloudy-clip/api/internal/common/logger"
+	"github.com/cloudy-clip/api/internal/entitlement/model"
+)
+
+// EntitlementGuardMiddleware resolves the caller's entitlements and stores them in the request context,
+// then rejects the request if the caller has already used up any of the provided `entitlementTypes`.
+// It must be placed after `jwt.JwtVerifierMiddleware`.
+func EntitlementGuardMiddleware(
+	logger *logger.Logger,
+	entitlementTypes ...model.EntitlementType,
+) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		handler := func(responseWriter http.ResponseWriter, request *http.Request) {
+			ctx := request.Context()
+			userId := jwt.GetUserIdClaim(ctx)
+
+			userEntitlements, err := entitlementService.ResolveUserEntitlements(ctx, userId)
+			if err != nil {
+				logger.ErrorAttrs(
+					ctx,
+					err,
+					"failed to resolve user entitlements",
+					slog.String("userEmail", jwt.GetUserEmailClaim(ctx)),
+				)
+
+				_http.WriteErrorResponse(
+					request,
+					responseWriter,
+					exception.NewUnknownException("failed to resolve user entitlements"),
+				)
+
+				return
+			}
+
+			ctx = context.WithValue(ctx, userEntitlementsContextKey, userEntitlements)
+
+			for _, entitlementType := range entitlementTypes {
+				err := entitlementService.EnforceEntitlement(ctx, nil, userId, entitlementType, 1)
+				if err == nil {
+					continue
+				}
+
+				if exception.IsOfExceptionType[exception.EntitlementLimitExceededException](err) {
+					logger.WarnAttrs(
+						ctx,
+						"user has used up their entitlement",
+						slog.String("userEmail", jwt.GetUserEmailClaim(ctx)),
+						slog.String("plan", userEntitlements.PlanName),
+						slog.String("entitlementType", entitlementType.String()),
+					)
+				} else {
+					logger.ErrorAttrs(
+						ctx,
+						err,
+						"failed to enforce entitlement",
+						slog.String("userEmail", jwt.GetUserEmailClaim(ctx)),
+						slog.String("entitlementType", entitlementType.String()),
+					)
+				}
+
+				_http.WriteErrorResponse(
+					request,
+					responseWriter,
+					exception.GetAsApplicationException(err, "failed to enforce entitlement"),
+				)
+
+				return
+			}
+
+			next.ServeHTTP(responseWriter, request.WithContext(ctx))
+		}
+
+		return http.HandlerFunc(handler)
+	}
+}
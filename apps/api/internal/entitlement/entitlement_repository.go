@@ -0,0 +1,94 @@
+package entitlement
+
+import (
+	"context"
+	"time"
+
+	"github.com/cloudy-clip/api/internal/common/database"
+	_jetModel "github.com/cloudy-clip/api/internal/common/database/.jet/model"
+	"github.com/cloudy-clip/api/internal/common/database/.jet/table"
+	jet "github.com/go-jet/jet/v2/postgres"
+	"github.com/jackc/pgx/v5"
+)
+
+type EntitlementRepository struct {
+}
+
+func NewEntitlementRepository() *EntitlementRepository {
+	return &EntitlementRepository{}
+}
+
+func (entitlementRepository *EntitlementRepository) findUsageCounterForUser(
+	ctx context.Context,
+	transaction pgx.Tx,
+	userId string,
+) (_jetModel.UsageCounter, error) {
+	usageCounterTable := table.UsageCounterTable
+	queryBuilder := usageCounterTable.
+		SELECT(usageCounterTable.AllColumns.As("")).
+		WHERE(usageCounterTable.UserID.EQ(jet.String(userId))).
+		LIMIT(1)
+
+	if transaction != nil {
+		return database.SelectOneTx[_jetModel.UsageCounter](ctx, transaction, queryBuilder)
+	}
+
+	return database.SelectOne[_jetModel.UsageCounter](ctx, queryBuilder)
+}
+
+// adjustUsageCounter adds the deltas, which are negative when usage goes down, to the user's running totals.
+func (entitlementRepository *EntitlementRepository) adjustUsageCounter(
+	ctx context.Context,
+	transaction pgx.Tx,
+	userId string,
+	wordCountDelta int64,
+	imageCountDelta int32,
+) error {
+	usageCounterTable := table.UsageCounterTable
+	queryBuilder := usageCounterTable.
+		INSERT(usageCounterTable.AllColumns).
+		MODEL(_jetModel.UsageCounter{
+			UserID:     userId,
+			WordCount:  wordCountDelta,
+			ImageCount: imageCountDelta,
+			UpdatedAt:  time.Now(),
+		}).
+		ON_CONFLICT(usageCounterTable.UserID).
+		DO_UPDATE(
+			jet.SET(
+				usageCounterTable.WordCount.SET(usageCounterTable.WordCount.ADD(usageCounterTable.EXCLUDED.WordCount)),
+				usageCounterTable.ImageCount.SET(usageCounterTable.ImageCount.ADD(usageCounterTable.EXCLUDED.ImageCount)),
+				usageCounterTable.UpdatedAt.SET(usageCounterTable.EXCLUDED.UpdatedAt),
+			),
+		)
+
+	return database.ExecTx(ctx, transaction, queryBuilder)
+}
+
+// findOldestUnpinnedClipboardItemCreatedAt is served by the index on the user's items by creation time.
+func (entitlementRepository *EntitlementRepository) findOldestUnpinnedClipboardItemCreatedAt(
+	ctx context.Context,
+	transaction pgx.Tx,
+	userId string,
+) (time.Time, error) {
+	clipboardItemTable := table.ClipboardItemTable
+	queryBuilder := clipboardItemTable.
+		SELECT(clipboardItemTable.CreatedAt).
+		WHERE(
+			clipboardItemTable.UserID.EQ(jet.String(userId)).
+				AND(clipboardItemTable.IsPinned.IS_FALSE()),
+		).
+		ORDER_BY(clipboardItemTable.CreatedAt.ASC()).
+		LIMIT(1)
+
+	var createdAt time.Time
+	var err error
+
+	if transaction != nil {
+		err = database.SelectIntoTx(ctx, transaction, queryBuilder, &createdAt)
+	} else {
+		err = database.SelectInto(ctx, queryBuilder, &createdAt)
+	}
+
+	return createdAt, err
+}
@@ -0,0 +1,273 @@
+package entitlement
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	_clipboardModel "github.com/cloudy-clip/api/internal/clipboard/model"
+	"github.com/cloudy-clip/api/internal/common/database"
+	_jetModel "github.com/cloudy-clip/api/internal/common/database/.jet/model"
+	"github.com/cloudy-clip/api/internal/common/environment"
+	"github.com/cloudy-clip/api/internal/common/exception"
+	"github.com/cloudy-clip/api/internal/common/jwt"
+	_logger "github.com/cloudy-clip/api/internal/common/logger"
+	"github.com/cloudy-clip/api/internal/entitlement/dto"
+	"github.com/cloudy-clip/api/internal/entitlement/model"
+	"github.com/cloudy-clip/api/internal/subscription"
+	_subscriptionModel "github.com/cloudy-clip/api/internal/subscription/model"
+	"github.com/jackc/pgx/v5"
+	"github.com/pkg/errors"
+)
+
+type entitlementContextKey string
+
+const (
+	userEntitlementsContextKey entitlementContextKey = "userEntitlements"
+)
+
+var (
+	entitlementService       *EntitlementService
+	entitlementRepository    *EntitlementRepository
+	entitlementServiceLogger *_logger.Logger
+)
+
+type EntitlementService struct {
+}
+
+func GetEntitlementService() *EntitlementService {
+	return entitlementService
+}
+
+func NewEntitlementService() *EntitlementService {
+	entitlementServiceLogger = _logger.NewLogger(
+		"EntitlementService",
+		slog.Level(environment.Config.ApplicationLogLevel),
+	)
+
+	return &EntitlementService{}
+}
+
+// ResolveUserEntitlements returns the limits granted by the user's active subscription.
+// A canceled subscription keeps its limits until its grace period ends, after which the user,
+// like users without a subscription, gets the free plan's limits.
+func (entitlementService *EntitlementService) ResolveUserEntitlements(
+	ctx context.Context,
+	userId string,
+) (dto.UserEntitlements, error) {
+	if userEntitlements, ok := GetUserEntitlementsFromContext(ctx); ok {
+		return userEntitlements, nil
+	}
+
+	activeSubscription, err := subscription.
+		GetSubscriptionService().
+		FindActiveSubscriptionForUser(ctx, userId)
+	if err == nil && !hasCancellationGracePeriodEnded(activeSubscription.CanceledAt) {
+		return dto.NewUserEntitlements(activeSubscription.Plan), nil
+	}
+
+	if err != nil && !database.IsEmptyResultError(err) {
+		return dto.UserEntitlements{}, err
+	}
+
+	freePlan, err := subscription.
+		GetSubscriptionService().
+		FindPlanByOfferingId(ctx, _subscriptionModel.PlanOfferingIdFreeMonthly)
+	if err != nil {
+		return dto.UserEntitlements{}, err
+	}
+
+	return dto.NewUserEntitlements(freePlan), nil
+}
+
+func hasCancellationGracePeriodEnded(canceledAt *time.Time) bool {
+	if canceledAt == nil {
+		return false
+	}
+
+	return !time.Now().Before(canceledAt.AddDate(0, 0, subscription.CanceledSubscriptionGracePeriodDays))
+}
+
+// GetCurrentUsage returns how much of the given entitlement the user has consumed so far.
+// The retention period is consumed by the age in days of the user's oldest unpinned item.
+func (entitlementService *EntitlementService) GetCurrentUsage(
+	ctx context.Context,
+	transaction pgx.Tx,
+	userId string,
+	entitlementType model.EntitlementType,
+) (int, error) {
+	switch entitlementType {
+	case model.EntitlementTypeWordCount, model.EntitlementTypeImageUpload:
+		usageCounter, err := entitlementRepository.findUsageCounterForUser(ctx, transaction, userId)
+		if database.IsEmptyResultError(err) {
+			// The user has never stored anything.
+			return 0, nil
+		}
+
+		if err != nil {
+			return 0, err
+		}
+
+		if entitlementType == model.EntitlementTypeWordCount {
+			return int(usageCounter.WordCount), nil
+		}
+
+		return int(usageCounter.ImageCount), nil
+
+	case model.EntitlementTypeRetentionPeriod:
+		oldestCreatedAt, err := entitlementRepository.findOldestUnpinnedClipboardItemCreatedAt(ctx, transaction, userId)
+		if database.IsEmptyResultError(err) {
+			return 0, nil
+		}
+
+		if err != nil {
+			return 0, err
+		}
+
+		return int(time.Since(oldestCreatedAt) / (24 * time.Hour)), nil
+	}
+
+	return 0, errors.Errorf("usage is not tracked for entitlement type '%s'", entitlementType)
+}
+
+// TrackAddedClipboardItems adds the items to the user's usage counters as part of `transaction`,
+// which must be the one that stores the items.
+func (entitlementService *EntitlementService) TrackAddedClipboardItems(
+	ctx context.Context,
+	transaction pgx.Tx,
+	userId string,
+	clipboardItemModels ..._jetModel.ClipboardItem,
+) error {
+	return adjustUsageForClipboardItems(ctx, transaction, userId, clipboardItemModels, 1)
+}
+
+// TrackRemovedClipboardItems subtracts the items from the user's usage counters as part of `transaction`,
+// which must be the one that deletes the items.
+func (entitlementService *EntitlementService) TrackRemovedClipboardItems(
+	ctx context.Context,
+	transaction pgx.Tx,
+	userId string,
+	clipboardItemModels ..._jetModel.ClipboardItem,
+) error {
+	return adjustUsageForClipboardItems(ctx, transaction, userId, clipboardItemModels, -1)
+}
+
+func adjustUsageForClipboardItems(
+	ctx context.Context,
+	transaction pgx.Tx,
+	userId string,
+	clipboardItemModels []_jetModel.ClipboardItem,
+	sign int,
+) error {
+	var wordCountDelta int64
+	var imageCountDelta int32
+
+	for _, clipboardItemModel := range clipboardItemModels {
+		wordCountDelta += int64(clipboardItemModel.WordCount)
+		if clipboardItemModel.Type == _clipboardModel.ClipboardItemTypeImage {
+			imageCountDelta++
+		}
+	}
+
+	if wordCountDelta == 0 && imageCountDelta == 0 {
+		return nil
+	}
+
+	return entitlementRepository.adjustUsageCounter(
+		ctx,
+		transaction,
+		userId,
+		int64(sign)*wordCountDelta,
+		int32(sign)*imageCountDelta,
+	)
+}
+
+func (entitlementService *EntitlementService) getUserUsage(ctx context.Context) (dto.UserUsage, exception.Exception) {
+	userUsage, err := getUserUsage(ctx, jwt.GetUserIdClaim(ctx))
+	if err == nil {
+		return userUsage, nil
+	}
+
+	entitlementServiceLogger.ErrorAttrs(
+		ctx,
+		err,
+		"failed to get user usage",
+		slog.String("userEmail", jwt.GetUserEmailClaim(ctx)),
+	)
+
+	return dto.UserUsage{}, exception.NewUnknownException("failed to get user usage")
+}
+
+func getUserUsage(ctx context.Context, userId string) (dto.UserUsage, error) {
+	userEntitlements, err := entitlementService.ResolveUserEntitlements(ctx, userId)
+	if err != nil {
+		return dto.UserUsage{}, err
+	}
+
+	userUsage := dto.UserUsage{
+		PlanName:     userEntitlements.PlanName,
+		Entitlements: []dto.EntitlementUsage{},
+	}
+
+	for _, limit := range userEntitlements.GetLimits() {
+		currentUsage, err := entitlementService.GetCurrentUsage(ctx, nil, userId, limit.Type)
+		if err != nil {
+			return dto.UserUsage{}, err
+		}
+
+		userUsage.Entitlements = append(userUsage.Entitlements, dto.NewEntitlementUsage(limit, currentUsage))
+	}
+
+	return userUsage, nil
+}
+
+// EnforceEntitlement returns an `EntitlementLimitExceededException` when consuming
+// `additionalUsage` more of the given entitlement would put the user over their plan's limit.
+// Per-item entitlements such as the word count only compare `additionalUsage`, the size of the item
+// being stored, against the limit.
+func (entitlementService *EntitlementService) EnforceEntitlement(
+	ctx context.Context,
+	transaction pgx.Tx,
+	userId string,
+	entitlementType model.EntitlementType,
+	additionalUsage int,
+) error {
+	userEntitlements, err := entitlementService.ResolveUserEntitlements(ctx, userId)
+	if err != nil {
+		return err
+	}
+
+	limit, err := userEntitlements.GetLimit(entitlementType)
+	if err != nil {
+		return err
+	}
+
+	if limit.IsUnlimited() {
+		return nil
+	}
+
+	if entitlementType.IsPerItem() {
+		if limit.Allows(additionalUsage) {
+			return nil
+		}
+
+		return exception.NewEntitlementLimitExceededException(entitlementType.String(), limit.Quantity, 0)
+	}
+
+	currentUsage, err := entitlementService.GetCurrentUsage(ctx, transaction, userId, entitlementType)
+	if err != nil {
+		return err
+	}
+
+	if limit.Allows(currentUsage + additionalUsage) {
+		return nil
+	}
+
+	return exception.NewEntitlementLimitExceededException(entitlementType.String(), limit.Quantity, currentUsage)
+}
+
+func GetUserEntitlementsFromContext(ctx context.Context) (dto.UserEntitlements, bool) {
+	userEntitlements, ok := ctx.Value(userEntitlementsContextKey).(dto.UserEntitlements)
+
+	return userEntitlements, ok
+}
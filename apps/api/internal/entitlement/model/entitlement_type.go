@@ -0,0 +1,49 @@
+package model
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+type EntitlementType string
+
+const (
+	EntitlementTypeWordCount       EntitlementType = "WORD_COUNT"
+	EntitlementTypeImageUpload     EntitlementType = "IMAGE_UPLOAD"
+	EntitlementTypeRetentionPeriod EntitlementType = "RETENTION_PERIOD"
+)
+
+func (entitlementType EntitlementType) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + entitlementType.String() + `"`), nil
+}
+
+// IsPerItem returns true when the entitlement limits each clipboard item on its own
+// rather than everything the user has stored.
+func (entitlementType EntitlementType) IsPerItem() bool {
+	return entitlementType == EntitlementTypeWordCount
+}
+
+func (entitlementType EntitlementType) String() string {
+	return string(entitlementType)
+}
+
+func (entitlementType *EntitlementType) UnmarshalJSON(buf []byte) error {
+	var entitlementTypeString string
+	err := json.Unmarshal(buf, &entitlementTypeString)
+	if err != nil {
+		return err
+	}
+
+	switch entitlementTypeString {
+	case "WORD_COUNT":
+		*entitlementType = EntitlementTypeWordCount
+	case "IMAGE_UPLOAD":
+		*entitlementType = EntitlementTypeImageUpload
+	case "RETENTION_PERIOD":
+		*entitlementType = EntitlementTypeRetentionPeriod
+	default:
+		return errors.New("unknown entitlement type '" + entitlementTypeString + "'")
+	}
+
+	return nil
+}
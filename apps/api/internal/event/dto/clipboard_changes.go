@@ -0,0 +1,45 @@
+package dto
+
+import (
+	_clipboardDto "github.com/cloudy-clip/api/internal/clipboard/dto"
+	"github.com/cloudy-clip/api/internal/event/model"
+)
+
+type ClipboardChange struct {
+	Version         string                       `json:"version"`
+	Type            model.ClipboardChangeType    `json:"type"`
+	ClipboardItemId string                       `json:"clipboardItemId"`
+	ClipboardItem   *_clipboardDto.ClipboardItem `json:"clipboardItem"` // Null for tombstones
+}
+
+type ClipboardChanges struct {
+	Changes []ClipboardChange `json:"changes"`
+	// Passed as `since` to get the changes that come after these ones.
+	NextCursor string `json:"nextCursor"`
+	HasMore    bool   `json:"hasMore"`
+}
+
+func NewClipboardChanges(clipboardEvents []ClipboardEvent, since string, hasMore bool) ClipboardChanges {
+	clipboardChanges := ClipboardChanges{
+		Changes:    make([]ClipboardChange, 0, len(clipboardEvents)),
+		NextCursor: since,
+		HasMore:    hasMore,
+	}
+
+	for _, clipboardEvent := range clipboardEvents {
+		changeType := model.ClipboardChangeTypeUpsert
+		if clipboardEvent.Type == model.ClipboardEventTypeDeleted {
+			changeType = model.ClipboardChangeTypeTombstone
+		}
+
+		clipboardChanges.Changes = append(clipboardChanges.Changes, ClipboardChange{
+			Version:         clipboardEvent.EventId,
+			Type:            changeType,
+			ClipboardItemId: clipboardEvent.ClipboardItemId,
+			ClipboardItem:   clipboardEvent.ClipboardItem,
+		})
+		clipboardChanges.NextCursor = clipboardEvent.EventId
+	}
+
+	return clipboardChanges
+}
@@ -0,0 +1,36 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+
+	_clipboardDto "github.com/cloudy-clip/api/internal/clipboard/dto"
+	_jetModel "github.com/cloudy-clip/api/internal/common/database/.jet/model"
+	"github.com/cloudy-clip/api/internal/event/model"
+	"github.com/pkg/errors"
+)
+
+type ClipboardEvent struct {
+	EventId         string                       `json:"eventId"`
+	Type            model.ClipboardEventType     `json:"type"`
+	ClipboardItemId string                       `json:"clipboardItemId"`
+	ClipboardItem   *_clipboardDto.ClipboardItem `json:"clipboardItem"` // Null for deleted items
+	CreatedAt       time.Time                    `json:"createdAt"`
+}
+
+func NewClipboardEvent(clipboardEventModel _jetModel.ClipboardEvent) (ClipboardEvent, error) {
+	var clipboardItem *_clipboardDto.ClipboardItem
+
+	err := json.Unmarshal([]byte(clipboardEventModel.Payload), &clipboardItem)
+	if err != nil {
+		return ClipboardEvent{}, errors.WithStack(err)
+	}
+
+	return ClipboardEvent{
+		EventId:         clipboardEventModel.ClipboardEventID,
+		Type:            clipboardEventModel.Type,
+		ClipboardItemId: clipboardEventModel.ClipboardItemID,
+		ClipboardItem:   clipboardItem,
+		CreatedAt:       clipboardEventModel.CreatedAt,
+	}, nil
+}
@@ -0,0 +1,286 @@
+package event
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/cloudy-clip/api/internal/common/environment"
+	"github.com/cloudy-clip/api/internal/common/exception"
+	_http "github.com/cloudy-clip/api/internal/common/http"
+	"github.com/cloudy-clip/api/internal/common/http/middleware/context"
+	"github.com/cloudy-clip/api/internal/common/jwt"
+	"github.com/cloudy-clip/api/internal/common/logger"
+	"github.com/cloudy-clip/api/internal/event/dto"
+	"github.com/go-chi/chi/v5"
+	"github.com/oklog/ulid/v2"
+	"github.com/pkg/errors"
+)
+
+const (
+	// Event streams are exempted from the global request timeout, see `orchestrator`.
+	EventStreamEndpoint = "/api/v1/clipboard/events"
+	lastEventIdHeader   = "Last-Event-ID"
+	heartbeatInterval   = 30 * time.Second
+	// Sent instead of the missed events when there are too many of them to replay.
+	resyncRequiredEventName     = "RESYNC_REQUIRED"
+	defaultClipboardChangeLimit = 100
+	maxClipboardChangeLimit     = 500
+)
+
+var (
+	eventService          *EventService
+	eventRepository       *EventRepository
+	eventHub              *EventHub
+	eventControllerLogger *logger.Logger
+)
+
+func GetEventService() *EventService {
+	return eventService
+}
+
+func SetupEventControllerEndpoints(parentRouter chi.Router) {
+	eventRepository = NewEventRepository()
+	eventService = NewEventService()
+	eventHub = NewEventHub()
+	eventControllerLogger = logger.NewLogger(
+		"EventController", slog.Level(environment.Config.ApplicationLogLevel),
+	)
+
+	parentRouter.Route("/v1/clipboard/events", func(v1Router chi.Router) {
+		v1Router.Group(func(router chi.Router) {
+			router.Use(
+				context.CallSiteMiddleware("handleStreamingClipboardEvents"),
+				jwt.JwtVerifierMiddleware(eventControllerLogger),
+			)
+			router.Get("/", handleStreamingClipboardEvents())
+		})
+	})
+
+	parentRouter.Route("/v1/clipboard/changes", func(v1Router chi.Router) {
+		v1Router.Group(func(router chi.Router) {
+			router.Use(
+				context.CallSiteMiddleware("handleGettingClipboardChanges"),
+				jwt.JwtVerifierMiddleware(eventControllerLogger),
+			)
+			router.Get("/", handleGettingClipboardChanges())
+		})
+	})
+}
+
+// handleGettingClipboardChanges returns, in order, the latest change of every clipboard item that
+// changed after the `since` cursor, or of all items when `since` is omitted.
+func handleGettingClipboardChanges() http.HandlerFunc {
+	return _http.GetResponseSender(
+		http.StatusOK,
+		func(request *http.Request, responseWriter http.ResponseWriter) (any, error) {
+			ctx := request.Context()
+			queryParams := request.URL.Query()
+			since := queryParams.Get("since")
+
+			err := validateClipboardChangeCursor(since)
+			if err != nil {
+				eventControllerLogger.ErrorAttrs(
+					ctx,
+					err,
+					"failed to validate since query param",
+					slog.String("userEmail", jwt.GetUserEmailClaim(ctx)),
+					slog.String("queryParams", queryParams.Encode()),
+				)
+
+				return nil, err
+			}
+
+			limit, err := getClipboardChangeLimit(queryParams)
+			if err != nil {
+				eventControllerLogger.ErrorAttrs(
+					ctx,
+					err,
+					"failed to get limit query param",
+					slog.String("userEmail", jwt.GetUserEmailClaim(ctx)),
+					slog.String("queryParams", queryParams.Encode()),
+				)
+
+				return nil, err
+			}
+
+			return eventService.getClipboardChanges(ctx, since, limit)
+		},
+	)
+}
+
+func validateClipboardChangeCursor(cursor string) error {
+	if cursor == "" {
+		return nil
+	}
+
+	_, err := ulid.ParseStrict(cursor)
+	if err == nil {
+		return nil
+	}
+
+	return errors.WithStack(exception.NewValidationExceptionWithExtra(
+		"'since' query param is not a valid cursor",
+		map[string]any{
+			"since": cursor,
+		},
+	))
+}
+
+func getClipboardChangeLimit(queryParams url.Values) (int64, error) {
+	if queryParams.Get("limit") == "" {
+		return defaultClipboardChangeLimit, nil
+	}
+
+	limit, err := _http.GetQueryParamAsInt64(queryParams, "limit")
+	if err != nil {
+		return 0, err
+	}
+
+	if limit < 1 || limit > maxClipboardChangeLimit {
+		return 0, errors.WithStack(exception.NewValidationExceptionWithExtra(
+			fmt.Sprintf("'limit' query param must be between 1 and %d", maxClipboardChangeLimit),
+			map[string]any{
+				"limit": limit,
+			},
+		))
+	}
+
+	return limit, nil
+}
+
+// handleStreamingClipboardEvents streams the user's clipboard events as server-sent events.
+// Clients resume from where they left off by sending the last event ID they saw, either in
+// the `Last-Event-ID` header that `EventSource` sets on reconnection or the `lastEventId` query param.
+func handleStreamingClipboardEvents() http.HandlerFunc {
+	return func(responseWriter http.ResponseWriter, request *http.Request) {
+		ctx := request.Context()
+		userId := jwt.GetUserIdClaim(ctx)
+		lastEventId := request.Header.Get(lastEventIdHeader)
+		if lastEventId == "" {
+			lastEventId = request.URL.Query().Get("lastEventId")
+		}
+
+		// Subscribe before replaying so that nothing published in between is lost,
+		// live events that were already replayed are skipped below.
+		subscriber := eventHub.subscribe(userId)
+		defer eventHub.unsubscribe(subscriber)
+
+		var missedEvents []dto.ClipboardEvent
+		hasMoreMissedEvents := false
+
+		if lastEventId != "" {
+			missedEventsResult, hasMore, err := eventService.getClipboardEventsAfter(ctx, lastEventId)
+			if err != nil {
+				_http.WriteErrorResponse(request, responseWriter, err)
+
+				return
+			}
+
+			missedEvents = missedEventsResult
+			hasMoreMissedEvents = hasMore
+		}
+
+		responseController := http.NewResponseController(responseWriter)
+		// The stream stays open for as long as the client is connected.
+		_ = responseController.SetWriteDeadline(time.Time{})
+
+		responseWriter.Header().Set("Content-Type", "text/event-stream")
+		responseWriter.Header().Set("Cache-Control", "no-cache")
+		responseWriter.Header().Set("Connection", "keep-alive")
+		responseWriter.Header().Set("X-Accel-Buffering", "no")
+		responseWriter.WriteHeader(http.StatusOK)
+
+		if hasMoreMissedEvents {
+			missedEvents = nil
+
+			_, err := fmt.Fprintf(responseWriter, "event: %s\ndata: {}\n\n", resyncRequiredEventName)
+			if err != nil {
+				return
+			}
+		}
+
+		for _, missedEvent := range missedEvents {
+			err := writeClipboardEvent(responseWriter, missedEvent)
+			if err != nil {
+				return
+			}
+
+			lastEventId = missedEvent.EventId
+		}
+
+		heartbeatTicker := time.NewTicker(heartbeatInterval)
+		defer heartbeatTicker.Stop()
+
+		for {
+			err := responseController.Flush()
+			if err != nil {
+				return
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+
+			case <-heartbeatTicker.C:
+				if deviceId := jwt.GetDeviceIdClaim(ctx); deviceId != "" {
+					err = jwt.VerifyDevice(ctx, userId, deviceId)
+					if err != nil {
+						eventControllerLogger.WarnAttrs(
+							ctx,
+							"closed clipboard event stream of device that is no longer allowed to sync",
+							slog.String("userEmail", jwt.GetUserEmailClaim(ctx)),
+							slog.String("deviceId", deviceId),
+						)
+
+						return
+					}
+				}
+
+				_, err = fmt.Fprint(responseWriter, ": heartbeat\n\n")
+
+			case clipboardEvent, ok := <-subscriber.events:
+				if !ok {
+					eventControllerLogger.WarnAttrs(
+						ctx,
+						"dropped clipboard event stream that fell behind",
+						slog.String("userEmail", jwt.GetUserEmailClaim(ctx)),
+					)
+
+					return
+				}
+
+				if clipboardEvent.EventId <= lastEventId {
+					continue
+				}
+
+				err = writeClipboardEvent(responseWriter, clipboardEvent)
+				lastEventId = clipboardEvent.EventId
+			}
+
+			if err != nil {
+				return
+			}
+		}
+	}
+}
+
+func writeClipboardEvent(responseWriter http.ResponseWriter, clipboardEvent dto.ClipboardEvent) error {
+	payloadJson, err := json.Marshal(clipboardEvent)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(
+		responseWriter,
+		"id: %s\nevent: %s\ndata: %s\n\n",
+		clipboardEvent.EventId,
+		clipboardEvent.Type,
+		payloadJson,
+	)
+
+	return err
+}
@@ -0,0 +1,121 @@
+package event
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	_logger "github.com/cloudy-clip/api/internal/common/logger"
+	"github.com/cloudy-clip/api/internal/event/dto"
+)
+
+const (
+	// Subscribers that fall this far behind are dropped and expected to reconnect and resume.
+	subscriberBufferSize = 64
+	listenerReadyTimeout = 5 * time.Second
+)
+
+type eventSubscriber struct {
+	userId string
+	events chan dto.ClipboardEvent
+}
+
+// EventHub fans out clipboard events to the streams opened by each user's devices on this
+// instance. It only keeps a connection listening for notifications from other instances
+// while at least one stream is open.
+type EventHub struct {
+	mutex           sync.Mutex
+	subscribers     map[string]map[*eventSubscriber]struct{}
+	subscriberCount int
+	listenerReady   chan struct{}
+	stopListener    context.CancelFunc
+}
+
+func NewEventHub() *EventHub {
+	return &EventHub{
+		subscribers: make(map[string]map[*eventSubscriber]struct{}),
+	}
+}
+
+func (eventHub *EventHub) subscribe(userId string) *eventSubscriber {
+	subscriber := &eventSubscriber{
+		userId: userId,
+		events: make(chan dto.ClipboardEvent, subscriberBufferSize),
+	}
+
+	eventHub.mutex.Lock()
+
+	if eventHub.subscribers[userId] == nil {
+		eventHub.subscribers[userId] = make(map[*eventSubscriber]struct{})
+	}
+
+	eventHub.subscribers[userId][subscriber] = struct{}{}
+	eventHub.subscriberCount++
+
+	if eventHub.subscriberCount == 1 {
+		listenerCtx, stopListener := context.WithCancel(_logger.NewBackgroundContext("listenForClipboardEvents"))
+		eventHub.listenerReady = make(chan struct{})
+		eventHub.stopListener = stopListener
+
+		go listenForClipboardEvents(listenerCtx, eventHub, eventHub.listenerReady)
+	}
+
+	listenerReady := eventHub.listenerReady
+
+	eventHub.mutex.Unlock()
+
+	// Events committed before the listener is ready would be missed.
+	select {
+	case <-listenerReady:
+	case <-time.After(listenerReadyTimeout):
+	}
+
+	return subscriber
+}
+
+func (eventHub *EventHub) unsubscribe(subscriber *eventSubscriber) {
+	eventHub.mutex.Lock()
+	defer eventHub.mutex.Unlock()
+
+	eventHub.removeSubscriber(subscriber)
+}
+
+// removeSubscriber must be called while holding the lock.
+func (eventHub *EventHub) removeSubscriber(subscriber *eventSubscriber) {
+	userSubscribers := eventHub.subscribers[subscriber.userId]
+	if _, ok := userSubscribers[subscriber]; !ok {
+		return
+	}
+
+	delete(userSubscribers, subscriber)
+	if len(userSubscribers) == 0 {
+		delete(eventHub.subscribers, subscriber.userId)
+	}
+
+	close(subscriber.events)
+
+	eventHub.subscriberCount--
+	if eventHub.subscriberCount == 0 {
+		eventHub.stopListener()
+	}
+}
+
+func (eventHub *EventHub) hasSubscribers(userId string) bool {
+	eventHub.mutex.Lock()
+	defer eventHub.mutex.Unlock()
+
+	return len(eventHub.subscribers[userId]) > 0
+}
+
+func (eventHub *EventHub) broadcast(userId string, clipboardEvent dto.ClipboardEvent) {
+	eventHub.mutex.Lock()
+	defer eventHub.mutex.Unlock()
+
+	for subscriber := range eventHub.subscribers[userId] {
+		select {
+		case subscriber.events <- clipboardEvent:
+		default:
+			eventHub.removeSubscriber(subscriber)
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package event
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cloudy-clip/api/internal/common/database"
+)
+
+const (
+	clipboardEventChannel     = "clipboard_events"
+	listenerReconnectionDelay = 3 * time.Second
+)
+
+// Notification payloads only carry IDs because Postgres caps them at 8000 bytes,
+// instances load the event itself only when they have subscribers for that user.
+func newClipboardEventNotificationPayload(userId string, clipboardEventId string) string {
+	return userId + ":" + clipboardEventId
+}
+
+func listenForClipboardEvents(ctx context.Context, eventHub *EventHub, listenerReady chan struct{}) {
+	var markListenerAsReady sync.Once
+
+	for {
+		err := database.Listen(
+			ctx,
+			clipboardEventChannel,
+			func() {
+				markListenerAsReady.Do(func() {
+					close(listenerReady)
+				})
+			},
+			func(payload string) {
+				handleClipboardEventNotification(ctx, eventHub, payload)
+			},
+		)
+
+		if ctx.Err() != nil {
+			return
+		}
+
+		eventServiceLogger.ErrorAttrs(ctx, err, "stopped listening for clipboard events, reconnecting")
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(listenerReconnectionDelay):
+		}
+	}
+}
+
+func handleClipboardEventNotification(ctx context.Context, eventHub *EventHub, payload string) {
+	userId, clipboardEventId, ok := strings.Cut(payload, ":")
+	if !ok {
+		eventServiceLogger.ErrorAttrs(
+			ctx,
+			errors.New("malformed clipboard event notification"),
+			"failed to parse clipboard event notification",
+			slog.String("payload", payload),
+		)
+
+		return
+	}
+
+	if !eventHub.hasSubscribers(userId) {
+		return
+	}
+
+	clipboardEvent, err := findClipboardEvent(ctx, userId, clipboardEventId)
+	if err == nil {
+		eventHub.broadcast(userId, clipboardEvent)
+
+		return
+	}
+
+	// The event is gone when a later change of the same item superseded it, that change will follow.
+	if database.IsEmptyResultError(err) {
+		return
+	}
+
+	eventServiceLogger.ErrorAttrs(
+		ctx,
+		err,
+		"failed to find clipboard event",
+		slog.String("userId", userId),
+		slog.String("clipboardEventId", clipboardEventId),
+	)
+}
@@ -0,0 +1,128 @@
+package event
+
+import (
+	"context"
+
+	"github.com/cloudy-clip/api/internal/common/database"
+	_jetModel "github.com/cloudy-clip/api/internal/common/database/.jet/model"
+	"github.com/cloudy-clip/api/internal/common/database/.jet/table"
+	jet "github.com/go-jet/jet/v2/postgres"
+	"github.com/jackc/pgx/v5"
+)
+
+type EventRepository struct {
+}
+
+func NewEventRepository() *EventRepository {
+	return &EventRepository{}
+}
+
+// upsertClipboardEvent keeps only the latest event of every clipboard item, so that
+// the events double as a change log that holds one entry per item.
+func (eventRepository *EventRepository) upsertClipboardEvent(
+	ctx context.Context,
+	transaction pgx.Tx,
+	clipboardEventModel _jetModel.ClipboardEvent,
+) error {
+	clipboardEventTable := table.ClipboardEventTable
+	queryBuilder := clipboardEventTable.
+		INSERT(clipboardEventTable.AllColumns).
+		MODEL(clipboardEventModel).
+		ON_CONFLICT(clipboardEventTable.UserID, clipboardEventTable.ClipboardItemID).
+		DO_UPDATE(
+			jet.SET(
+				clipboardEventTable.ClipboardEventID.SET(clipboardEventTable.EXCLUDED.ClipboardEventID),
+				clipboardEventTable.Type.SET(clipboardEventTable.EXCLUDED.Type),
+				clipboardEventTable.Payload.SET(clipboardEventTable.EXCLUDED.Payload),
+				clipboardEventTable.CreatedAt.SET(clipboardEventTable.EXCLUDED.CreatedAt),
+			).
+				WHERE(clipboardEventTable.ClipboardEventID.LT(clipboardEventTable.EXCLUDED.ClipboardEventID)),
+		)
+
+	return database.ExecTx(ctx, transaction, queryBuilder)
+}
+
+// lockClipboardChangesForUser serializes clipboard changes of the user until `transaction` ends.
+func (eventRepository *EventRepository) lockClipboardChangesForUser(
+	ctx context.Context,
+	transaction pgx.Tx,
+	userId string,
+) error {
+	queryBuilder := jet.RawStatement(
+		"SELECT pg_advisory_xact_lock(hashtext(#lockKey))",
+		jet.RawArgs{
+			"#lockKey": clipboardEventChannel + ":" + userId,
+		},
+	)
+
+	return database.ExecTx(ctx, transaction, queryBuilder)
+}
+
+func (eventRepository *EventRepository) findLatestClipboardEventIdForUser(
+	ctx context.Context,
+	transaction pgx.Tx,
+	userId string,
+) (string, error) {
+	clipboardEventTable := table.ClipboardEventTable
+	queryBuilder := clipboardEventTable.
+		SELECT(jet.COALESCE(jet.MAX(clipboardEventTable.ClipboardEventID), jet.String("")).AS("clipboard_event_id")).
+		WHERE(clipboardEventTable.UserID.EQ(jet.String(userId)))
+
+	var latestClipboardEventId string
+
+	err := database.SelectIntoTx(ctx, transaction, queryBuilder, &latestClipboardEventId)
+
+	return latestClipboardEventId, err
+}
+
+func (eventRepository *EventRepository) notifyClipboardEventChannel(
+	ctx context.Context,
+	transaction pgx.Tx,
+	payload string,
+) error {
+	queryBuilder := jet.RawStatement(
+		"SELECT pg_notify(#channel, #payload)",
+		jet.RawArgs{
+			"#channel": clipboardEventChannel,
+			"#payload": payload,
+		},
+	)
+
+	return database.ExecTx(ctx, transaction, queryBuilder)
+}
+
+func (eventRepository *EventRepository) findClipboardEventById(
+	ctx context.Context,
+	userId string,
+	clipboardEventId string,
+) (_jetModel.ClipboardEvent, error) {
+	clipboardEventTable := table.ClipboardEventTable
+	queryBuilder := clipboardEventTable.
+		SELECT(clipboardEventTable.AllColumns.As("")).
+		WHERE(
+			clipboardEventTable.ClipboardEventID.EQ(jet.String(clipboardEventId)).
+				AND(clipboardEventTable.UserID.EQ(jet.String(userId))),
+		).
+		LIMIT(1)
+
+	return database.SelectOne[_jetModel.ClipboardEvent](ctx, queryBuilder)
+}
+
+func (eventRepository *EventRepository) getClipboardEventsAfter(
+	ctx context.Context,
+	userId string,
+	lastEventId string,
+	limit int64,
+) ([]_jetModel.ClipboardEvent, error) {
+	clipboardEventTable := table.ClipboardEventTable
+	queryBuilder := clipboardEventTable.
+		SELECT(clipboardEventTable.AllColumns.As("")).
+		WHERE(
+			clipboardEventTable.UserID.EQ(jet.String(userId)).
+				AND(clipboardEventTable.ClipboardEventID.GT(jet.String(lastEventId))),
+		).
+		ORDER_BY(clipboardEventTable.ClipboardEventID.ASC()).
+		LIMIT(limit)
+
+	return database.SelectMany[_jetModel.ClipboardEvent](ctx, queryBuilder)
+}
@@ -0,0 +1,192 @@
+package event
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"time"
+
+	_clipboardDto "github.com/cloudy-clip/api/internal/clipboard/dto"
+	_jetModel "github.com/cloudy-clip/api/internal/common/database/.jet/model"
+	"github.com/cloudy-clip/api/internal/common/environment"
+	"github.com/cloudy-clip/api/internal/common/exception"
+	"github.com/cloudy-clip/api/internal/common/jwt"
+	_logger "github.com/cloudy-clip/api/internal/common/logger"
+	"github.com/cloudy-clip/api/internal/common/ulid"
+	"github.com/cloudy-clip/api/internal/event/dto"
+	"github.com/cloudy-clip/api/internal/event/model"
+	"github.com/jackc/pgx/v5"
+	"github.com/pkg/errors"
+)
+
+const (
+	// Resuming clients that missed more events than this are asked to resync instead.
+	maxReplayedClipboardEventCount = 500
+)
+
+var (
+	eventServiceLogger *_logger.Logger
+)
+
+type EventService struct {
+}
+
+func NewEventService() *EventService {
+	eventServiceLogger = _logger.NewLogger(
+		"EventService",
+		slog.Level(environment.Config.ApplicationLogLevel),
+	)
+
+	return &EventService{}
+}
+
+// NextClipboardItemVersion returns the ULID to assign to the next change of one of the user's
+// clipboard items. Changes of the same user are serialized until `transaction` ends, and every
+// version sorts after all of the previous ones, so the change with the greater version always
+// wins and the versions double as change log cursors.
+func (eventService *EventService) NextClipboardItemVersion(
+	ctx context.Context,
+	transaction pgx.Tx,
+	userId string,
+) (string, error) {
+	err := eventRepository.lockClipboardChangesForUser(ctx, transaction, userId)
+	if err != nil {
+		return "", err
+	}
+
+	latestClipboardEventId, err := eventRepository.findLatestClipboardEventIdForUser(ctx, transaction, userId)
+	if err != nil {
+		return "", err
+	}
+
+	return ulid.GenerateAfter(latestClipboardEventId)
+}
+
+// PublishClipboardEvent records that the clipboard item was created, updated or deleted as of
+// its current version, then notifies every API instance once `transaction` commits so that they
+// can push the event to the user's connected devices.
+func (eventService *EventService) PublishClipboardEvent(
+	ctx context.Context,
+	transaction pgx.Tx,
+	eventType model.ClipboardEventType,
+	clipboardItemModel _jetModel.ClipboardItem,
+) error {
+	// Deleted items leave a tombstone behind that does not keep their content around.
+	payload := []byte("null")
+	if eventType != model.ClipboardEventTypeDeleted {
+		clipboardItemJson, err := json.Marshal(_clipboardDto.NewClipboardItem(clipboardItemModel))
+		if err != nil {
+			return errors.WithStack(err)
+		}
+
+		payload = clipboardItemJson
+	}
+
+	err := eventRepository.upsertClipboardEvent(
+		ctx,
+		transaction,
+		_jetModel.ClipboardEvent{
+			ClipboardEventID: clipboardItemModel.Version,
+			Type:             eventType,
+			ClipboardItemID:  clipboardItemModel.ClipboardItemID,
+			Payload:          string(payload),
+			UserID:           clipboardItemModel.UserID,
+			CreatedAt:        time.Now(),
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	return eventRepository.notifyClipboardEventChannel(
+		ctx,
+		transaction,
+		newClipboardEventNotificationPayload(clipboardItemModel.UserID, clipboardItemModel.Version),
+	)
+}
+
+func (eventService *EventService) getClipboardEventsAfter(
+	ctx context.Context,
+	lastEventId string,
+) ([]dto.ClipboardEvent, bool, exception.Exception) {
+	clipboardEvents, hasMore, err := getClipboardEventsAfter(
+		ctx,
+		jwt.GetUserIdClaim(ctx),
+		lastEventId,
+		maxReplayedClipboardEventCount,
+	)
+	if err == nil {
+		return clipboardEvents, hasMore, nil
+	}
+
+	eventServiceLogger.ErrorAttrs(
+		ctx,
+		err,
+		"failed to get clipboard events",
+		slog.String("userEmail", jwt.GetUserEmailClaim(ctx)),
+		slog.String("lastEventId", lastEventId),
+	)
+
+	return nil, false, exception.NewUnknownException("failed to get clipboard events")
+}
+
+func (eventService *EventService) getClipboardChanges(
+	ctx context.Context,
+	since string,
+	limit int64,
+) (dto.ClipboardChanges, exception.Exception) {
+	clipboardEvents, hasMore, err := getClipboardEventsAfter(ctx, jwt.GetUserIdClaim(ctx), since, limit)
+	if err == nil {
+		return dto.NewClipboardChanges(clipboardEvents, since, hasMore), nil
+	}
+
+	eventServiceLogger.ErrorAttrs(
+		ctx,
+		err,
+		"failed to get clipboard changes",
+		slog.String("userEmail", jwt.GetUserEmailClaim(ctx)),
+		slog.String("since", since),
+		slog.Int64("limit", limit),
+	)
+
+	return dto.ClipboardChanges{}, exception.NewUnknownException("failed to get clipboard changes")
+}
+
+func getClipboardEventsAfter(
+	ctx context.Context,
+	userId string,
+	lastEventId string,
+	limit int64,
+) ([]dto.ClipboardEvent, bool, error) {
+	clipboardEventModels, err := eventRepository.getClipboardEventsAfter(ctx, userId, lastEventId, limit+1)
+	if err != nil {
+		return nil, false, err
+	}
+
+	hasMore := int64(len(clipboardEventModels)) > limit
+	if hasMore {
+		clipboardEventModels = clipboardEventModels[:limit]
+	}
+
+	clipboardEvents := make([]dto.ClipboardEvent, 0, len(clipboardEventModels))
+
+	for _, clipboardEventModel := range clipboardEventModels {
+		clipboardEvent, err := dto.NewClipboardEvent(clipboardEventModel)
+		if err != nil {
+			return nil, false, err
+		}
+
+		clipboardEvents = append(clipboardEvents, clipboardEvent)
+	}
+
+	return clipboardEvents, hasMore, nil
+}
+
+func findClipboardEvent(ctx context.Context, userId string, clipboardEventId string) (dto.ClipboardEvent, error) {
+	clipboardEventModel, err := eventRepository.findClipboardEventById(ctx, userId, clipboardEventId)
+	if err != nil {
+		return dto.ClipboardEvent{}, err
+	}
+
+	return dto.NewClipboardEvent(clipboardEventModel)
+}
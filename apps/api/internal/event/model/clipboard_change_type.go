@@ -0,0 +1,40 @@
+package model
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+type ClipboardChangeType string
+
+const (
+	ClipboardChangeTypeUpsert    ClipboardChangeType = "UPSERT"
+	ClipboardChangeTypeTombstone ClipboardChangeType = "TOMBSTONE"
+)
+
+func (changeType ClipboardChangeType) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + changeType.String() + `"`), nil
+}
+
+func (changeType ClipboardChangeType) String() string {
+	return string(changeType)
+}
+
+func (changeType *ClipboardChangeType) UnmarshalJSON(buf []byte) error {
+	var changeTypeString string
+	err := json.Unmarshal(buf, &changeTypeString)
+	if err != nil {
+		return err
+	}
+
+	switch changeTypeString {
+	case "UPSERT":
+		*changeType = ClipboardChangeTypeUpsert
+	case "TOMBSTONE":
+		*changeType = ClipboardChangeTypeTombstone
+	default:
+		return errors.New("unknown clipboard change type '" + changeTypeString + "'")
+	}
+
+	return nil
+}
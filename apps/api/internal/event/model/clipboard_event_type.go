@@ -0,0 +1,43 @@
+package model
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+type ClipboardEventType string
+
+const (
+	ClipboardEventTypeCreated ClipboardEventType = "CREATED"
+	ClipboardEventTypeUpdated ClipboardEventType = "UPDATED"
+	ClipboardEventTypeDeleted ClipboardEventType = "DELETED"
+)
+
+func (eventType ClipboardEventType) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + eventType.String() + `"`), nil
+}
+
+func (eventType ClipboardEventType) String() string {
+	return string(eventType)
+}
+
+func (eventType *ClipboardEventType) UnmarshalJSON(buf []byte) error {
+	var eventTypeString string
+	err := json.Unmarshal(buf, &eventTypeString)
+	if err != nil {
+		return err
+	}
+
+	switch eventTypeString {
+	case "CREATED":
+		*eventType = ClipboardEventTypeCreated
+	case "UPDATED":
+		*eventType = ClipboardEventTypeUpdated
+	case "DELETED":
+		*eventType = ClipboardEventTypeDeleted
+	default:
+		return errors.New("unknown clipboard event type '" + eventTypeString + "'")
+	}
+
+	return nil
+}
@@ -10,10 +10,17 @@ import (
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/stripe/stripe-go/v79"
 	"github.com/cloudy-clip/api/internal/billing"
+	"github.com/cloudy-clip/api/internal/clipboard"
 	"github.com/cloudy-clip/api/internal/common/environment"
 	"github.com/cloudy-clip/api/internal/common/exception"
 	_http "github.com/cloudy-clip/api/internal/common/http"
 	"github.com/cloudy-clip/api/internal/common/logger"
+	"github.com/cloudy-clip/api/internal/device"
+	"github.com/cloudy-clip/api/internal/entitlement"
+	"github.com/cloudy-clip/api/internal/event"
+	"github.com/cloudy-clip/api/internal/retention"
+	"github.com/cloudy-clip/api/internal/share"
+	"github.com/cloudy-clip/api/internal/storage"
 	"github.com/cloudy-clip/api/internal/subscription"
 	"github.com/cloudy-clip/api/internal/task"
 	"github.com/cloudy-clip/api/internal/user"
@@ -28,11 +35,11 @@ func SetupControllerEndpoints(conf *Config, mux *chi.Mux) {
 		middleware.RealIP,
 		newGlobalErrorHandler(),
 		// 5MiB
-		middleware.RequestSize(5242880),
+		newRequestSizeMiddleware(5242880),
 		// Set a timeout value on the request context (ctx), that will signal
 		// through ctx.Done() that the request has timed out and further
 		// processing should be stopped.
-		middleware.Timeout(conf.ReadTimeout),
+		newRequestTimeoutMiddleware(conf.ReadTimeout),
 	)
 
 	orchestratorLogger := logger.NewLogger("Orchestrator", slog.Level(environment.Config.ApplicationLogLevel))
@@ -73,9 +80,16 @@ func SetupControllerEndpoints(conf *Config, mux *chi.Mux) {
 
 	mux.Route("/api", func(router chi.Router) {
 		user.SetupUserControllerEndpoints(router)
+		device.SetupDeviceControllerEndpoints(router)
+		storage.SetupStorageControllerEndpoints(router)
 		billing.SetupBillingControllerEndpoints(router)
 		subscription.SetupSubscriptionControllerEndpoints(router)
 		webhook.SetupWebhookControllerEndpoints(router)
 		task.SetupTaskControllerEndpoints(router)
+		entitlement.SetupEntitlementControllerEndpoints(router)
+		event.SetupEventControllerEndpoints(router)
+		clipboard.SetupClipboardControllerEndpoints(router)
+		share.SetupShareControllerEndpoints(router)
+		retention.SetupRetentionService()
 	})
 }
@@ -0,0 +1,34 @@
+package orchestrator
+
+import (
+	"net/http"
+
+	"github.com/cloudy-clip/api/internal/clipboard"
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+// newRequestSizeMiddleware applies `middleware.RequestSize` to every request except image uploads,
+// which enforce their own limit.
+func newRequestSizeMiddleware(maxBytes int64) func(next http.Handler) http.Handler {
+	requestSizeMiddleware := middleware.RequestSize(maxBytes)
+
+	return func(next http.Handler) http.Handler {
+		nextWithRequestSize := requestSizeMiddleware(next)
+
+		handler := func(responseWriter http.ResponseWriter, request *http.Request) {
+			if isImageUpload(request) {
+				next.ServeHTTP(responseWriter, request)
+
+				return
+			}
+
+			nextWithRequestSize.ServeHTTP(responseWriter, request)
+		}
+
+		return http.HandlerFunc(handler)
+	}
+}
+
+func isImageUpload(request *http.Request) bool {
+	return request.Method == http.MethodPost && request.URL.Path == clipboard.ImageUploadEndpoint
+}
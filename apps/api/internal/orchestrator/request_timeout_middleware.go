@@ -0,0 +1,35 @@
+package orchestrator
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/cloudy-clip/api/internal/event"
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+// newRequestTimeoutMiddleware applies `middleware.Timeout` to every request except event streams,
+// which stay open for as long as the client is connected, and image uploads, which set their own deadline.
+func newRequestTimeoutMiddleware(timeout time.Duration) func(next http.Handler) http.Handler {
+	timeoutMiddleware := middleware.Timeout(timeout)
+
+	return func(next http.Handler) http.Handler {
+		nextWithTimeout := timeoutMiddleware(next)
+
+		handler := func(responseWriter http.ResponseWriter, request *http.Request) {
+			if isEventStream(request) || isImageUpload(request) {
+				next.ServeHTTP(responseWriter, request)
+
+				return
+			}
+
+			nextWithTimeout.ServeHTTP(responseWriter, request)
+		}
+
+		return http.HandlerFunc(handler)
+	}
+}
+
+func isEventStream(request *http.Request) bool {
+	return request.Method == http.MethodGet && request.URL.Path == event.EventStreamEndpoint
+}
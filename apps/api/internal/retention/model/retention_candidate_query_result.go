@@ -0,0 +1,10 @@
+package model
+
+import "time"
+
+type RetentionCandidateQueryResult struct {
+	UserId         string     `db:"user_id"`
+	UserEmail      string     `db:"user_email"`
+	PlanOfferingId *string    `db:"plan_offering_id"` // Nullable
+	CanceledAt     *time.Time `db:"canceled_at"`      // Nullable
+}
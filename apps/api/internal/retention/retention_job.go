@@ -0,0 +1,55 @@
+package retention
+
+import (
+	"context"
+	"time"
+
+	"github.com/cloudy-clip/api/internal/common/database"
+	_logger "github.com/cloudy-clip/api/internal/common/logger"
+)
+
+const (
+	retentionPurgeJobInterval = time.Hour
+	retentionPurgeJobTimeout  = 10 * time.Minute
+	retentionPurgeJobLockKey  = "retention_purge_job"
+)
+
+// StartRetentionPurgeJob purges expired clipboard items right away, then once every hour
+// for as long as the process is alive. Runs are skipped while another instance is purging.
+func StartRetentionPurgeJob() {
+	go func() {
+		ticker := time.NewTicker(retentionPurgeJobInterval)
+		defer ticker.Stop()
+
+		runRetentionPurgeJob()
+
+		for range ticker.C {
+			runRetentionPurgeJob()
+		}
+	}()
+}
+
+func runRetentionPurgeJob() {
+	ctx, cancel := context.WithTimeout(
+		_logger.NewBackgroundContext("runRetentionPurgeJob"),
+		retentionPurgeJobTimeout,
+	)
+
+	defer cancel()
+
+	isLocked, err := database.UseSessionAdvisoryLock(ctx, retentionPurgeJobLockKey, func() error {
+		// Errors are already logged by the service, the next run will try again.
+		_ = retentionService.PurgeExpiredClipboardItems(ctx, time.Now())
+
+		return nil
+	})
+	if err != nil {
+		retentionServiceLogger.ErrorAttrs(ctx, err, "failed to take the retention purge job lock")
+
+		return
+	}
+
+	if !isLocked {
+		retentionServiceLogger.DebugAttrs(ctx, "skipped retention purge, another instance is running it")
+	}
+}
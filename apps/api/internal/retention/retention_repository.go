@@ -0,0 +1,63 @@
+package retention
+
+import (
+	"context"
+	"time"
+
+	"github.com/cloudy-clip/api/internal/common/database"
+	_jetModel "github.com/cloudy-clip/api/internal/common/database/.jet/model"
+	"github.com/cloudy-clip/api/internal/common/database/.jet/table"
+	"github.com/cloudy-clip/api/internal/retention/model"
+	jet "github.com/go-jet/jet/v2/postgres"
+	"github.com/jackc/pgx/v5"
+)
+
+type RetentionRepository struct {
+}
+
+func NewRetentionRepository() *RetentionRepository {
+	return &RetentionRepository{}
+}
+
+func (retentionRepository *RetentionRepository) findUsersWithUnpinnedClipboardItems(
+	ctx context.Context,
+) ([]model.RetentionCandidateQueryResult, error) {
+	clipboardItemTable := table.ClipboardItemTable
+	userTable := table.UserTable
+	subscriptionTable := table.SubscriptionTable
+	queryBuilder := clipboardItemTable.
+		SELECT(
+			userTable.UserID.AS("user_id"),
+			userTable.Email.AS("user_email"),
+			subscriptionTable.PlanOfferingID.AS("plan_offering_id"),
+			subscriptionTable.CanceledAt.AS("canceled_at"),
+		).
+		DISTINCT().
+		FROM(
+			clipboardItemTable.
+				INNER_JOIN(userTable, clipboardItemTable.UserID.EQ(userTable.UserID)).
+				LEFT_JOIN(subscriptionTable, clipboardItemTable.UserID.EQ(subscriptionTable.UserID)),
+		).
+		WHERE(clipboardItemTable.IsPinned.IS_FALSE())
+
+	return database.SelectMany[model.RetentionCandidateQueryResult](ctx, queryBuilder)
+}
+
+func (retentionRepository *RetentionRepository) deleteUnpinnedClipboardItemsCreatedBefore(
+	ctx context.Context,
+	transaction pgx.Tx,
+	userId string,
+	cutoff time.Time,
+) ([]_jetModel.ClipboardItem, error) {
+	clipboardItemTable := table.ClipboardItemTable
+	queryBuilder := clipboardItemTable.
+		DELETE().
+		WHERE(
+			clipboardItemTable.UserID.EQ(jet.String(userId)).
+				AND(clipboardItemTable.IsPinned.IS_FALSE()).
+				AND(clipboardItemTable.CreatedAt.LT(jet.TimestampzT(cutoff))),
+		).
+		RETURNING(clipboardItemTable.AllColumns.As(""))
+
+	return database.SelectManyTx[_jetModel.ClipboardItem](ctx, transaction, queryBuilder)
+}
@@ -0,0 +1,246 @@
+package retention
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/cloudy-clip/api/internal/clipboard"
+	"github.com/cloudy-clip/api/internal/common/database"
+	_jetModel "github.com/cloudy-clip/api/internal/common/database/.jet/model"
+	"github.com/cloudy-clip/api/internal/common/environment"
+	"github.com/cloudy-clip/api/internal/common/jwt"
+	_logger "github.com/cloudy-clip/api/internal/common/logger"
+	"github.com/cloudy-clip/api/internal/common/ulid"
+	"github.com/cloudy-clip/api/internal/entitlement"
+	_entitlementDto "github.com/cloudy-clip/api/internal/entitlement/dto"
+	"github.com/cloudy-clip/api/internal/event"
+	_eventModel "github.com/cloudy-clip/api/internal/event/model"
+	"github.com/cloudy-clip/api/internal/retention/model"
+	"github.com/cloudy-clip/api/internal/subscription"
+	_subscriptionModel "github.com/cloudy-clip/api/internal/subscription/model"
+	"github.com/cloudy-clip/api/internal/task"
+	_taskModel "github.com/cloudy-clip/api/internal/task/model"
+	"github.com/jackc/pgx/v5"
+)
+
+var (
+	retentionService       *RetentionService
+	retentionRepository    *RetentionRepository
+	retentionServiceLogger *_logger.Logger
+)
+
+type RetentionService struct {
+}
+
+type purgeCutoff struct {
+	createdBefore time.Time
+	reason        string
+}
+
+func GetRetentionService() *RetentionService {
+	return retentionService
+}
+
+func SetupRetentionService() {
+	retentionRepository = NewRetentionRepository()
+	retentionService = NewRetentionService()
+}
+
+func NewRetentionService() *RetentionService {
+	retentionServiceLogger = _logger.NewLogger(
+		"RetentionService",
+		slog.Level(environment.Config.ApplicationLogLevel),
+	)
+
+	return &RetentionService{}
+}
+
+// PurgeExpiredClipboardItems deletes unpinned clipboard items that fell outside of their owner's
+// retention window as of `now`, and records a task for every user whose items were deleted.
+func (retentionService *RetentionService) PurgeExpiredClipboardItems(ctx context.Context, now time.Time) error {
+	retentionCandidates, err := retentionRepository.findUsersWithUnpinnedClipboardItems(ctx)
+	if err != nil {
+		retentionServiceLogger.ErrorAttrs(ctx, err, "failed to find users with unpinned clipboard items")
+
+		return err
+	}
+
+	for _, retentionCandidate := range retentionCandidates {
+		userCtx := context.WithValue(ctx, jwt.JwtClaimEmail, retentionCandidate.UserEmail)
+
+		purgedItemCount, err := purgeExpiredClipboardItemsForUser(userCtx, retentionCandidate, now)
+		if err != nil {
+			retentionServiceLogger.ErrorAttrs(
+				userCtx,
+				err,
+				"failed to purge expired clipboard items",
+				slog.String("userEmail", retentionCandidate.UserEmail),
+			)
+
+			continue
+		}
+
+		if purgedItemCount > 0 {
+			retentionServiceLogger.InfoAttrs(
+				userCtx,
+				"purged expired clipboard items",
+				slog.String("userEmail", retentionCandidate.UserEmail),
+				slog.Int("purgedItemCount", purgedItemCount),
+			)
+		}
+	}
+
+	return nil
+}
+
+func purgeExpiredClipboardItemsForUser(
+	ctx context.Context,
+	retentionCandidate model.RetentionCandidateQueryResult,
+	now time.Time,
+) (int, error) {
+	cutoff, err := resolvePurgeCutoff(ctx, retentionCandidate, now)
+	if err != nil || cutoff == nil {
+		return 0, err
+	}
+
+	var purgedItems []_jetModel.ClipboardItem
+
+	err = database.UseTransaction(ctx, func(transaction pgx.Tx) error {
+		// Takes the user's clipboard lock before the rows are locked by the deletion, in the same order
+		// as when items are created, updated or deleted, so that none of them can deadlock with the purge.
+		version, err := event.
+			GetEventService().
+			NextClipboardItemVersion(ctx, transaction, retentionCandidate.UserId)
+		if err != nil {
+			return err
+		}
+
+		purgedItems, err = retentionRepository.deleteUnpinnedClipboardItemsCreatedBefore(
+			ctx,
+			transaction,
+			retentionCandidate.UserId,
+			cutoff.createdBefore,
+		)
+		if err != nil || len(purgedItems) == 0 {
+			return err
+		}
+
+		err = entitlement.
+			GetEntitlementService().
+			TrackRemovedClipboardItems(ctx, transaction, retentionCandidate.UserId, purgedItems...)
+		if err != nil {
+			return err
+		}
+
+		for index, purgedItem := range purgedItems {
+			if index > 0 {
+				version, err = ulid.GenerateAfter(version)
+				if err != nil {
+					return err
+				}
+			}
+
+			purgedItem.Version = version
+
+			err = event.
+				GetEventService().
+				PublishClipboardEvent(ctx, transaction, _eventModel.ClipboardEventTypeDeleted, purgedItem)
+			if err != nil {
+				return err
+			}
+		}
+
+		_, err = task.
+			GetTaskService().
+			AddCompletedTask(
+				ctx,
+				transaction,
+				_taskModel.TaskTypeRetentionPurge,
+				retentionCandidate.UserId,
+				fmt.Sprintf(
+					"purged %d unpinned clipboard item(s) created before %s because %s",
+					len(purgedItems),
+					cutoff.createdBefore.UTC().Format(time.RFC3339),
+					cutoff.reason,
+				),
+			)
+
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	for _, purgedItem := range purgedItems {
+		clipboard.GetClipboardService().DeleteStoredContent(ctx, purgedItem)
+	}
+
+	return len(purgedItems), nil
+}
+
+// resolvePurgeCutoff returns nil when none of the user's items are subject to being purged.
+func resolvePurgeCutoff(
+	ctx context.Context,
+	retentionCandidate model.RetentionCandidateQueryResult,
+	now time.Time,
+) (*purgeCutoff, error) {
+	if retentionCandidate.CanceledAt != nil {
+		purgeAt := retentionCandidate.CanceledAt.AddDate(0, 0, subscription.CanceledSubscriptionGracePeriodDays)
+		if now.Before(purgeAt) {
+			return nil, nil
+		}
+
+		// Once the items of the canceled subscription are purged, the user is left with the free
+		// plan, whose retention period applies to the items created since.
+		freePlanCutoff, err := resolvePlanPurgeCutoff(ctx, _subscriptionModel.PlanOfferingIdFreeMonthly, now)
+		if err != nil || (freePlanCutoff != nil && freePlanCutoff.createdBefore.After(purgeAt)) {
+			return freePlanCutoff, err
+		}
+
+		return &purgeCutoff{
+			createdBefore: purgeAt,
+			reason: fmt.Sprintf(
+				"subscription was canceled at %s and its %d-day grace period has ended",
+				retentionCandidate.CanceledAt.UTC().Format(time.RFC3339),
+				subscription.CanceledSubscriptionGracePeriodDays,
+			),
+		}, nil
+	}
+
+	offeringId := _subscriptionModel.PlanOfferingIdFreeMonthly
+	if retentionCandidate.PlanOfferingId != nil {
+		offeringId = *retentionCandidate.PlanOfferingId
+	}
+
+	return resolvePlanPurgeCutoff(ctx, offeringId, now)
+}
+
+// resolvePlanPurgeCutoff returns nil when the plan retains items for as long as they exist.
+func resolvePlanPurgeCutoff(
+	ctx context.Context,
+	offeringId string,
+	now time.Time,
+) (*purgeCutoff, error) {
+	plan, err := subscription.
+		GetSubscriptionService().
+		FindPlanByOfferingId(ctx, offeringId)
+	if err != nil {
+		return nil, err
+	}
+
+	retentionPeriod := _entitlementDto.NewUserEntitlements(plan).RetentionPeriod
+	if retentionPeriod.IsUnlimited() {
+		return nil, nil
+	}
+
+	return &purgeCutoff{
+		createdBefore: now.AddDate(0, 0, -retentionPeriod.Quantity),
+		reason: fmt.Sprintf(
+			"%s plan retains clipboard items for %d days",
+			plan.DisplayName,
+			retentionPeriod.Quantity,
+		),
+	}, nil
+}
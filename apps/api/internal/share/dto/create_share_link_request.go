@@ -0,0 +1,9 @@
+package dto
+
+type CreateShareLinkRequest struct {
+	ClipboardItemId string `json:"clipboardItemId" validate:"required,len=26"`
+	// Between a minute and 30 days.
+	ExpiresInSeconds int    `json:"expiresInSeconds" validate:"required,min=60,max=2592000"`
+	MaxViewCount     *int32 `json:"maxViewCount,omitempty" validate:"omitempty,min=1"`
+	Password         string `json:"password,omitempty" validate:"omitempty,max=64"`
+}
@@ -0,0 +1,6 @@
+package dto
+
+type ResolveShareLinkRequest struct {
+	Token    string `json:"token" validate:"required,max=64"`
+	Password string `json:"password,omitempty" validate:"omitempty,max=64"`
+}
@@ -0,0 +1,39 @@
+package dto
+
+import (
+	"time"
+
+	_jetModel "github.com/cloudy-clip/api/internal/common/database/.jet/model"
+)
+
+type ShareLink struct {
+	ShareLinkId     string     `json:"shareLinkId"`
+	ClipboardItemId string     `json:"clipboardItemId"`
+	HasPassword     bool       `json:"hasPassword"`
+	MaxViewCount    *int32     `json:"maxViewCount"`
+	ViewCount       int32      `json:"viewCount"`
+	ExpiresAt       time.Time  `json:"expiresAt"`
+	RevokedAt       *time.Time `json:"revokedAt"`
+	CreatedAt       time.Time  `json:"createdAt"`
+	UpdatedAt       time.Time  `json:"updatedAt"`
+}
+
+// CreatedShareLink is the only time the token is handed out, only its hash is kept around.
+type CreatedShareLink struct {
+	ShareLink
+	Token string `json:"token"`
+}
+
+func NewShareLink(shareLinkModel _jetModel.ShareLink) ShareLink {
+	return ShareLink{
+		ShareLinkId:     shareLinkModel.ShareLinkID,
+		ClipboardItemId: shareLinkModel.ClipboardItemID,
+		HasPassword:     shareLinkModel.Password != nil,
+		MaxViewCount:    shareLinkModel.MaxViewCount,
+		ViewCount:       shareLinkModel.ViewCount,
+		ExpiresAt:       shareLinkModel.ExpiresAt,
+		RevokedAt:       shareLinkModel.RevokedAt,
+		CreatedAt:       shareLinkModel.CreatedAt,
+		UpdatedAt:       shareLinkModel.UpdatedAt,
+	}
+}
@@ -0,0 +1,23 @@
+package dto
+
+import (
+	"time"
+
+	_jetModel "github.com/cloudy-clip/api/internal/common/database/.jet/model"
+)
+
+type ShareLinkView struct {
+	ShareLinkViewId string    `json:"shareLinkViewId"`
+	IpAddress       string    `json:"ipAddress"`
+	UserAgent       string    `json:"userAgent"`
+	ViewedAt        time.Time `json:"viewedAt"`
+}
+
+func NewShareLinkView(shareLinkViewModel _jetModel.ShareLinkView) ShareLinkView {
+	return ShareLinkView{
+		ShareLinkViewId: shareLinkViewModel.ShareLinkViewID,
+		IpAddress:       shareLinkViewModel.IPAddress,
+		UserAgent:       shareLinkViewModel.UserAgent,
+		ViewedAt:        shareLinkViewModel.ViewedAt,
+	}
+}
@@ -0,0 +1,18 @@
+package dto
+
+import (
+	"time"
+
+	_clipboardDto "github.com/cloudy-clip/api/internal/clipboard/dto"
+	"github.com/cloudy-clip/api/internal/clipboard/model"
+)
+
+// SharedClipboardItem is what anonymous viewers get to see of the shared item. Items whose content
+// lives in object storage come with a download URL instead of their content.
+type SharedClipboardItem struct {
+	Type        model.ClipboardItemType                 `json:"type"`
+	Content     string                                  `json:"content"`
+	DownloadUrl *_clipboardDto.ClipboardItemDownloadUrl `json:"downloadUrl"`
+	CreatedAt   time.Time                               `json:"createdAt"`
+	ExpiresAt   time.Time                               `json:"expiresAt"`
+}
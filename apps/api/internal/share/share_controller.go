@@ -0,0 +1,149 @@
+package share
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/cloudy-clip/api/internal/common/environment"
+	_http "github.com/cloudy-clip/api/internal/common/http"
+	"github.com/cloudy-clip/api/internal/common/http/middleware/context"
+	"github.com/cloudy-clip/api/internal/common/http/middleware/turnstile"
+	"github.com/cloudy-clip/api/internal/common/jwt"
+	"github.com/cloudy-clip/api/internal/common/logger"
+	"github.com/cloudy-clip/api/internal/share/dto"
+	"github.com/go-chi/chi/v5"
+)
+
+var (
+	shareService          *ShareService
+	shareRepository       *ShareRepository
+	shareControllerLogger *logger.Logger
+)
+
+func SetupShareControllerEndpoints(parentRouter chi.Router) {
+	shareRepository = NewShareRepository()
+	shareService = NewShareService()
+	shareControllerLogger = logger.NewLogger(
+		"ShareController", slog.Level(environment.Config.ApplicationLogLevel),
+	)
+
+	parentRouter.Route("/v1/share-links", func(v1Router chi.Router) {
+		v1Router.Group(func(router chi.Router) {
+			router.Use(
+				context.CallSiteMiddleware("handleShareLinkCreation"),
+				jwt.JwtVerifierMiddleware(shareControllerLogger),
+			)
+			router.Post("/", handleShareLinkCreation())
+		})
+
+		v1Router.Group(func(router chi.Router) {
+			router.Use(
+				context.CallSiteMiddleware("handleGettingShareLinks"),
+				jwt.JwtVerifierMiddleware(shareControllerLogger),
+			)
+			router.Get("/", handleGettingShareLinks())
+		})
+
+		v1Router.Group(func(router chi.Router) {
+			router.Use(
+				context.CallSiteMiddleware("handleRevokingShareLink"),
+				jwt.JwtVerifierMiddleware(shareControllerLogger),
+			)
+			router.Delete("/{shareLinkId}", handleRevokingShareLink())
+		})
+
+		v1Router.Group(func(router chi.Router) {
+			router.Use(
+				context.CallSiteMiddleware("handleGettingShareLinkViews"),
+				jwt.JwtVerifierMiddleware(shareControllerLogger),
+			)
+			router.Get("/{shareLinkId}/views", handleGettingShareLinkViews())
+		})
+
+		v1Router.Group(func(router chi.Router) {
+			router.Use(
+				context.CallSiteMiddleware("handleShareLinkResolution"),
+				turnstile.TurnstileTokenVerifierMiddleware(shareControllerLogger),
+			)
+			router.Post("/resolutions", handleShareLinkResolution())
+		})
+	})
+}
+
+func handleShareLinkCreation() http.HandlerFunc {
+	return _http.GetResponseSender(
+		http.StatusCreated,
+		func(request *http.Request, responseWriter http.ResponseWriter) (any, error) {
+			ctx := request.Context()
+
+			var createShareLinkRequest dto.CreateShareLinkRequest
+			err := _http.ReadRequestBodyAs(request, shareControllerLogger, &createShareLinkRequest)
+			if err != nil {
+				shareControllerLogger.ErrorAttrs(
+					ctx,
+					err,
+					"failed to parse request body",
+					slog.String("userEmail", jwt.GetUserEmailClaim(ctx)),
+				)
+
+				return nil, err
+			}
+
+			return shareService.createShareLink(ctx, createShareLinkRequest)
+		},
+	)
+}
+
+func handleGettingShareLinks() http.HandlerFunc {
+	return _http.GetResponseSender(
+		http.StatusOK,
+		func(request *http.Request, responseWriter http.ResponseWriter) (any, error) {
+			return shareService.getShareLinks(request.Context())
+		},
+	)
+}
+
+func handleRevokingShareLink() http.HandlerFunc {
+	return _http.GetEmptyResponseSender(func(request *http.Request, responseWriter http.ResponseWriter) error {
+		shareLinkId := chi.URLParam(request, "shareLinkId")
+
+		return shareService.revokeShareLink(request.Context(), shareLinkId)
+	})
+}
+
+func handleGettingShareLinkViews() http.HandlerFunc {
+	return _http.GetResponseSender(
+		http.StatusOK,
+		func(request *http.Request, responseWriter http.ResponseWriter) (any, error) {
+			shareLinkId := chi.URLParam(request, "shareLinkId")
+
+			return shareService.getShareLinkViews(request.Context(), shareLinkId)
+		},
+	)
+}
+
+// handleShareLinkResolution is public, the token travels in the request body rather than
+// the URL so that it does not end up in access logs.
+func handleShareLinkResolution() http.HandlerFunc {
+	return _http.GetResponseSender(
+		http.StatusOK,
+		func(request *http.Request, responseWriter http.ResponseWriter) (any, error) {
+			ctx := request.Context()
+
+			var resolveShareLinkRequest dto.ResolveShareLinkRequest
+			err := _http.ReadRequestBodyAs(request, shareControllerLogger, &resolveShareLinkRequest)
+			if err != nil {
+				shareControllerLogger.ErrorAttrs(ctx, err, "failed to parse request body")
+
+				return nil, err
+			}
+
+			return shareService.resolveShareLink(
+				ctx,
+				resolveShareLinkRequest,
+				request.RemoteAddr,
+				request.UserAgent(),
+			)
+		},
+	)
+}
@@ -0,0 +1,136 @@
+package share
+
+import (
+	"context"
+	"time"
+
+	"github.com/cloudy-clip/api/internal/common/database"
+	_jetModel "github.com/cloudy-clip/api/internal/common/database/.jet/model"
+	"github.com/cloudy-clip/api/internal/common/database/.jet/table"
+	jet "github.com/go-jet/jet/v2/postgres"
+	"github.com/jackc/pgx/v5"
+)
+
+type ShareRepository struct {
+}
+
+func NewShareRepository() *ShareRepository {
+	return &ShareRepository{}
+}
+
+func (shareRepository *ShareRepository) createShareLink(
+	ctx context.Context,
+	shareLinkModel _jetModel.ShareLink,
+) error {
+	queryBuilder := table.ShareLinkTable.
+		INSERT(table.ShareLinkTable.AllColumns).
+		MODEL(shareLinkModel)
+
+	return database.Exec(ctx, queryBuilder)
+}
+
+func (shareRepository *ShareRepository) getShareLinksForUser(
+	ctx context.Context,
+	userId string,
+) ([]_jetModel.ShareLink, error) {
+	shareLinkTable := table.ShareLinkTable
+	queryBuilder := shareLinkTable.
+		SELECT(shareLinkTable.AllColumns.As("")).
+		WHERE(shareLinkTable.UserID.EQ(jet.String(userId))).
+		ORDER_BY(shareLinkTable.CreatedAt.DESC(), shareLinkTable.ShareLinkID.DESC())
+
+	return database.SelectMany[_jetModel.ShareLink](ctx, queryBuilder)
+}
+
+func (shareRepository *ShareRepository) findShareLinkById(
+	ctx context.Context,
+	userId string,
+	shareLinkId string,
+) (_jetModel.ShareLink, error) {
+	shareLinkTable := table.ShareLinkTable
+	queryBuilder := shareLinkTable.
+		SELECT(shareLinkTable.AllColumns.As("")).
+		WHERE(
+			shareLinkTable.ShareLinkID.EQ(jet.String(shareLinkId)).
+				AND(shareLinkTable.UserID.EQ(jet.String(userId))),
+		).
+		LIMIT(1)
+
+	return database.SelectOne[_jetModel.ShareLink](ctx, queryBuilder)
+}
+
+// findShareLinkByTokenHashForUpdate locks the share link until `transaction` ends,
+// so that concurrent views cannot go over its max view count.
+func (shareRepository *ShareRepository) findShareLinkByTokenHashForUpdate(
+	ctx context.Context,
+	transaction pgx.Tx,
+	tokenHash string,
+) (_jetModel.ShareLink, error) {
+	shareLinkTable := table.ShareLinkTable
+	queryBuilder := shareLinkTable.
+		SELECT(shareLinkTable.AllColumns.As("")).
+		WHERE(shareLinkTable.TokenHash.EQ(jet.String(tokenHash))).
+		LIMIT(1).
+		FOR(jet.UPDATE())
+
+	return database.SelectOneTx[_jetModel.ShareLink](ctx, transaction, queryBuilder)
+}
+
+func (shareRepository *ShareRepository) incrementShareLinkViewCount(
+	ctx context.Context,
+	transaction pgx.Tx,
+	shareLinkId string,
+) error {
+	shareLinkTable := table.ShareLinkTable
+	queryBuilder := shareLinkTable.
+		UPDATE(shareLinkTable.ViewCount, shareLinkTable.UpdatedAt).
+		SET(shareLinkTable.ViewCount.ADD(jet.Int(1)), time.Now()).
+		WHERE(shareLinkTable.ShareLinkID.EQ(jet.String(shareLinkId)))
+
+	return database.ExecTx(ctx, transaction, queryBuilder)
+}
+
+// revokeShareLink keeps the time the share link was first revoked at when it is revoked again.
+func (shareRepository *ShareRepository) revokeShareLink(
+	ctx context.Context,
+	userId string,
+	shareLinkId string,
+) (_jetModel.ShareLink, error) {
+	shareLinkTable := table.ShareLinkTable
+	now := time.Now()
+	queryBuilder := shareLinkTable.
+		UPDATE(shareLinkTable.RevokedAt, shareLinkTable.UpdatedAt).
+		SET(jet.COALESCE(shareLinkTable.RevokedAt, jet.TimestampzT(now)), now).
+		WHERE(
+			shareLinkTable.ShareLinkID.EQ(jet.String(shareLinkId)).
+				AND(shareLinkTable.UserID.EQ(jet.String(userId))),
+		).
+		RETURNING(shareLinkTable.AllColumns.As(""))
+
+	return database.SelectOne[_jetModel.ShareLink](ctx, queryBuilder)
+}
+
+func (shareRepository *ShareRepository) createShareLinkView(
+	ctx context.Context,
+	transaction pgx.Tx,
+	shareLinkViewModel _jetModel.ShareLinkView,
+) error {
+	queryBuilder := table.ShareLinkViewTable.
+		INSERT(table.ShareLinkViewTable.AllColumns).
+		MODEL(shareLinkViewModel)
+
+	return database.ExecTx(ctx, transaction, queryBuilder)
+}
+
+func (shareRepository *ShareRepository) getShareLinkViews(
+	ctx context.Context,
+	shareLinkId string,
+) ([]_jetModel.ShareLinkView, error) {
+	shareLinkViewTable := table.ShareLinkViewTable
+	queryBuilder := shareLinkViewTable.
+		SELECT(shareLinkViewTable.AllColumns.As("")).
+		WHERE(shareLinkViewTable.ShareLinkID.EQ(jet.String(shareLinkId))).
+		ORDER_BY(shareLinkViewTable.ViewedAt.DESC(), shareLinkViewTable.ShareLinkViewID.DESC())
+
+	return database.SelectMany[_jetModel.ShareLinkView](ctx, queryBuilder)
+}
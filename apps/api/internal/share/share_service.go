@@ -0,0 +1,403 @@
+package share
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"log/slog"
+	"time"
+
+	"github.com/cloudy-clip/api/internal/clipboard"
+	"github.com/cloudy-clip/api/internal/common/database"
+	_jetModel "github.com/cloudy-clip/api/internal/common/database/.jet/model"
+	"github.com/cloudy-clip/api/internal/common/environment"
+	"github.com/cloudy-clip/api/internal/common/exception"
+	"github.com/cloudy-clip/api/internal/common/hashing"
+	"github.com/cloudy-clip/api/internal/common/jwt"
+	_logger "github.com/cloudy-clip/api/internal/common/logger"
+	"github.com/cloudy-clip/api/internal/common/ulid"
+	"github.com/cloudy-clip/api/internal/share/dto"
+	"github.com/jackc/pgx/v5"
+	"github.com/pkg/errors"
+)
+
+const (
+	shareLinkTokenByteCount = 32
+	maxUserAgentLength      = 512
+)
+
+var (
+	shareServiceLogger *_logger.Logger
+)
+
+type ShareService struct {
+}
+
+func NewShareService() *ShareService {
+	shareServiceLogger = _logger.NewLogger(
+		"ShareService",
+		slog.Level(environment.Config.ApplicationLogLevel),
+	)
+
+	return &ShareService{}
+}
+
+func (shareService *ShareService) createShareLink(
+	ctx context.Context,
+	createShareLinkRequest dto.CreateShareLinkRequest,
+) (dto.CreatedShareLink, exception.Exception) {
+	createdShareLink, err := createShareLink(ctx, createShareLinkRequest)
+	if err == nil {
+		return createdShareLink, nil
+	}
+
+	createShareLinkRequest.Password = "..."
+
+	shareServiceLogger.ErrorAttrs(
+		ctx,
+		err,
+		"failed to create share link",
+		slog.String("userEmail", jwt.GetUserEmailClaim(ctx)),
+		slog.Any("requestBody", createShareLinkRequest),
+	)
+
+	return dto.CreatedShareLink{}, exception.GetAsApplicationException(err, "failed to create share link")
+}
+
+func createShareLink(
+	ctx context.Context,
+	createShareLinkRequest dto.CreateShareLinkRequest,
+) (dto.CreatedShareLink, error) {
+	userId := jwt.GetUserIdClaim(ctx)
+
+	_, err := clipboard.
+		GetClipboardRepository().
+		FindClipboardItemById(ctx, nil, userId, createShareLinkRequest.ClipboardItemId)
+	if database.IsEmptyResultError(err) {
+		return dto.CreatedShareLink{}, exception.NewNotFoundException("no clipboard item was found")
+	}
+
+	if err != nil {
+		return dto.CreatedShareLink{}, err
+	}
+
+	shareLinkId, err := ulid.GenerateWithRetry()
+	if err != nil {
+		return dto.CreatedShareLink{}, err
+	}
+
+	token, err := generateShareLinkToken()
+	if err != nil {
+		return dto.CreatedShareLink{}, err
+	}
+
+	now := time.Now()
+	shareLinkModel := _jetModel.ShareLink{
+		ShareLinkID:     shareLinkId,
+		TokenHash:       hashShareLinkToken(token),
+		Password:        nil,
+		Salt:            nil,
+		MaxViewCount:    createShareLinkRequest.MaxViewCount,
+		ViewCount:       0,
+		ExpiresAt:       now.Add(time.Duration(createShareLinkRequest.ExpiresInSeconds) * time.Second),
+		RevokedAt:       nil,
+		ClipboardItemID: createShareLinkRequest.ClipboardItemId,
+		UserID:          userId,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+
+	if createShareLinkRequest.Password != "" {
+		salt, err := hashing.GenerateSalt()
+		if err != nil {
+			return dto.CreatedShareLink{}, err
+		}
+
+		hashedPassword := hex.EncodeToString(hashing.HashPassword(createShareLinkRequest.Password, salt))
+		hexSalt := hex.EncodeToString(salt)
+		shareLinkModel.Password = &hashedPassword
+		shareLinkModel.Salt = &hexSalt
+	}
+
+	err = shareRepository.createShareLink(ctx, shareLinkModel)
+	if err != nil {
+		return dto.CreatedShareLink{}, err
+	}
+
+	return dto.CreatedShareLink{
+		ShareLink: dto.NewShareLink(shareLinkModel),
+		Token:     token,
+	}, nil
+}
+
+func generateShareLinkToken() (string, error) {
+	tokenBytes := make([]byte, shareLinkTokenByteCount)
+	_, err := rand.Read(tokenBytes)
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(tokenBytes), nil
+}
+
+// The tokens are random enough that a fast hash does not make them any easier to guess.
+func hashShareLinkToken(token string) string {
+	tokenHash := sha256.Sum256([]byte(token))
+
+	return hex.EncodeToString(tokenHash[:])
+}
+
+func (shareService *ShareService) getShareLinks(ctx context.Context) ([]dto.ShareLink, exception.Exception) {
+	shareLinkModels, err := shareRepository.getShareLinksForUser(ctx, jwt.GetUserIdClaim(ctx))
+	if err == nil {
+		shareLinks := make([]dto.ShareLink, 0, len(shareLinkModels))
+
+		for _, shareLinkModel := range shareLinkModels {
+			shareLinks = append(shareLinks, dto.NewShareLink(shareLinkModel))
+		}
+
+		return shareLinks, nil
+	}
+
+	shareServiceLogger.ErrorAttrs(
+		ctx,
+		err,
+		"failed to get share links",
+		slog.String("userEmail", jwt.GetUserEmailClaim(ctx)),
+	)
+
+	return nil, exception.NewUnknownException("failed to get share links")
+}
+
+func (shareService *ShareService) revokeShareLink(ctx context.Context, shareLinkId string) exception.Exception {
+	_, err := shareRepository.revokeShareLink(ctx, jwt.GetUserIdClaim(ctx), shareLinkId)
+	if err == nil {
+		return nil
+	}
+
+	if database.IsEmptyResultError(err) {
+		return exception.NewNotFoundException("no share link was found")
+	}
+
+	shareServiceLogger.ErrorAttrs(
+		ctx,
+		err,
+		"failed to revoke share link",
+		slog.String("userEmail", jwt.GetUserEmailClaim(ctx)),
+		slog.String("shareLinkId", shareLinkId),
+	)
+
+	return exception.NewUnknownException("failed to revoke share link")
+}
+
+func (shareService *ShareService) getShareLinkViews(
+	ctx context.Context,
+	shareLinkId string,
+) ([]dto.ShareLinkView, exception.Exception) {
+	shareLinkViews, err := getShareLinkViews(ctx, shareLinkId)
+	if err == nil {
+		return shareLinkViews, nil
+	}
+
+	shareServiceLogger.ErrorAttrs(
+		ctx,
+		err,
+		"failed to get share link views",
+		slog.String("userEmail", jwt.GetUserEmailClaim(ctx)),
+		slog.String("shareLinkId", shareLinkId),
+	)
+
+	return nil, exception.GetAsApplicationException(err, "failed to get share link views")
+}
+
+func getShareLinkViews(ctx context.Context, shareLinkId string) ([]dto.ShareLinkView, error) {
+	_, err := shareRepository.findShareLinkById(ctx, jwt.GetUserIdClaim(ctx), shareLinkId)
+	if database.IsEmptyResultError(err) {
+		return nil, exception.NewNotFoundException("no share link was found")
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	shareLinkViewModels, err := shareRepository.getShareLinkViews(ctx, shareLinkId)
+	if err != nil {
+		return nil, err
+	}
+
+	shareLinkViews := make([]dto.ShareLinkView, 0, len(shareLinkViewModels))
+
+	for _, shareLinkViewModel := range shareLinkViewModels {
+		shareLinkViews = append(shareLinkViews, dto.NewShareLinkView(shareLinkViewModel))
+	}
+
+	return shareLinkViews, nil
+}
+
+// resolveShareLink returns the shared item to an anonymous viewer, counting this as one of its views.
+// Links that cannot be viewed anymore are indistinguishable from links that never existed.
+func (shareService *ShareService) resolveShareLink(
+	ctx context.Context,
+	resolveShareLinkRequest dto.ResolveShareLinkRequest,
+	viewerIp string,
+	viewerUserAgent string,
+) (dto.SharedClipboardItem, exception.Exception) {
+	sharedClipboardItem, err := resolveShareLink(ctx, resolveShareLinkRequest, viewerIp, viewerUserAgent)
+	if err == nil {
+		return sharedClipboardItem, nil
+	}
+
+	if exception.IsOfExceptionType[exception.NotFoundException](err) ||
+		exception.IsOfExceptionType[exception.UnauthorizedException](err) {
+		shareServiceLogger.WarnAttrs(
+			ctx,
+			"cannot resolve share link",
+			slog.String("reason", err.Error()),
+			slog.String("viewerIp", viewerIp),
+		)
+	} else {
+		shareServiceLogger.ErrorAttrs(
+			ctx,
+			err,
+			"failed to resolve share link",
+			slog.String("viewerIp", viewerIp),
+		)
+	}
+
+	return dto.SharedClipboardItem{}, exception.GetAsApplicationException(err, "failed to resolve share link")
+}
+
+func resolveShareLink(
+	ctx context.Context,
+	resolveShareLinkRequest dto.ResolveShareLinkRequest,
+	viewerIp string,
+	viewerUserAgent string,
+) (dto.SharedClipboardItem, error) {
+	var sharedClipboardItem dto.SharedClipboardItem
+
+	err := database.UseTransaction(ctx, func(transaction pgx.Tx) error {
+		shareLinkModel, err := shareRepository.findShareLinkByTokenHashForUpdate(
+			ctx,
+			transaction,
+			hashShareLinkToken(resolveShareLinkRequest.Token),
+		)
+		if database.IsEmptyResultError(err) {
+			return newUnavailableShareLinkException()
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if !isShareLinkViewable(shareLinkModel, time.Now()) {
+			return newUnavailableShareLinkException()
+		}
+
+		err = checkShareLinkPassword(shareLinkModel, resolveShareLinkRequest.Password)
+		if err != nil {
+			return err
+		}
+
+		clipboardItemModel, err := clipboard.
+			GetClipboardRepository().
+			FindClipboardItemById(ctx, transaction, shareLinkModel.UserID, shareLinkModel.ClipboardItemID)
+		if err != nil {
+			return err
+		}
+
+		err = shareRepository.incrementShareLinkViewCount(ctx, transaction, shareLinkModel.ShareLinkID)
+		if err != nil {
+			return err
+		}
+
+		shareLinkViewId, err := ulid.GenerateWithRetry()
+		if err != nil {
+			return err
+		}
+
+		if len(viewerUserAgent) > maxUserAgentLength {
+			viewerUserAgent = viewerUserAgent[:maxUserAgentLength]
+		}
+
+		err = shareRepository.createShareLinkView(
+			ctx,
+			transaction,
+			_jetModel.ShareLinkView{
+				ShareLinkViewID: shareLinkViewId,
+				IPAddress:       viewerIp,
+				UserAgent:       viewerUserAgent,
+				ShareLinkID:     shareLinkModel.ShareLinkID,
+				ViewedAt:        time.Now(),
+			},
+		)
+		if err != nil {
+			return err
+		}
+
+		sharedClipboardItem = dto.SharedClipboardItem{
+			Type:        clipboardItemModel.Type,
+			Content:     clipboardItemModel.Content,
+			DownloadUrl: nil,
+			CreatedAt:   clipboardItemModel.CreatedAt,
+			ExpiresAt:   shareLinkModel.ExpiresAt,
+		}
+
+		if clipboard.HasStoredContent(clipboardItemModel) {
+			downloadUrl, err := clipboard.GetClipboardService().SignDownloadUrl(clipboardItemModel)
+			if err != nil {
+				return err
+			}
+
+			// The object key is of no use to the viewer.
+			sharedClipboardItem.Content = ""
+			sharedClipboardItem.DownloadUrl = &downloadUrl
+		}
+
+		return nil
+	})
+
+	return sharedClipboardItem, err
+}
+
+func isShareLinkViewable(shareLinkModel _jetModel.ShareLink, now time.Time) bool {
+	if shareLinkModel.RevokedAt != nil || !now.Before(shareLinkModel.ExpiresAt) {
+		return false
+	}
+
+	return shareLinkModel.MaxViewCount == nil || shareLinkModel.ViewCount < *shareLinkModel.MaxViewCount
+}
+
+func checkShareLinkPassword(shareLinkModel _jetModel.ShareLink, passwordToCheck string) error {
+	if shareLinkModel.Password == nil {
+		return nil
+	}
+
+	if passwordToCheck != "" {
+		storedPassword, err := hex.DecodeString(*shareLinkModel.Password)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+
+		storedSalt, err := hex.DecodeString(*shareLinkModel.Salt)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+
+		if hashing.IsPasswordCorrect(passwordToCheck, storedPassword, storedSalt) {
+			return nil
+		}
+	}
+
+	return exception.NewUnauthorizedExceptionWithExtra(
+		"share link requires the correct password",
+		map[string]any{
+			"isPasswordRequired": true,
+		},
+	)
+}
+
+func newUnavailableShareLinkException() exception.NotFoundException {
+	return exception.NewNotFoundException("share link was not found or is no longer available")
+}
@@ -0,0 +1,165 @@
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// LocalObjectStorage keeps objects on the filesystem of the API instance, which serves them
+// itself through the URLs it signs. It is meant for development and single-instance deployments.
+type LocalObjectStorage struct {
+	directory     string
+	baseUrl       string
+	signingSecret []byte
+}
+
+func NewLocalObjectStorage(directory string, baseUrl string, signingSecret string) (*LocalObjectStorage, error) {
+	if directory == "" || baseUrl == "" {
+		return nil, errors.New("local object storage requires both a directory and a base URL")
+	}
+
+	signingSecretBytes, err := hex.DecodeString(signingSecret)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	err = os.MkdirAll(directory, 0o750)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return &LocalObjectStorage{
+		directory:     directory,
+		baseUrl:       strings.TrimSuffix(baseUrl, "/"),
+		signingSecret: signingSecretBytes,
+	}, nil
+}
+
+func (localObjectStorage *LocalObjectStorage) PutObject(
+	ctx context.Context,
+	key string,
+	body io.Reader,
+	size int64,
+	contentType string,
+) error {
+	objectPath, err := localObjectStorage.resolveObjectPath(key)
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(filepath.Dir(objectPath), 0o750)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	// Writing to a temporary file first means that readers never see a partially written object.
+	temporaryFile, err := os.CreateTemp(filepath.Dir(objectPath), ".upload-*")
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer os.Remove(temporaryFile.Name())
+
+	writtenByteCount, err := io.Copy(temporaryFile, body)
+	closeErr := temporaryFile.Close()
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	if closeErr != nil {
+		return errors.WithStack(closeErr)
+	}
+
+	if writtenByteCount != size {
+		return errors.Errorf("expected object '%s' to have %d bytes but got %d", key, size, writtenByteCount)
+	}
+
+	return errors.WithStack(os.Rename(temporaryFile.Name(), objectPath))
+}
+
+func (localObjectStorage *LocalObjectStorage) DeleteObject(ctx context.Context, key string) error {
+	objectPath, err := localObjectStorage.resolveObjectPath(key)
+	if err != nil {
+		return err
+	}
+
+	err = os.Remove(objectPath)
+	if err == nil || errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+
+	return errors.WithStack(err)
+}
+
+func (localObjectStorage *LocalObjectStorage) SignDownloadUrl(key string, expiresIn time.Duration) (string, error) {
+	_, err := localObjectStorage.resolveObjectPath(key)
+	if err != nil {
+		return "", err
+	}
+
+	expiresAt := strconv.FormatInt(time.Now().Add(expiresIn).Unix(), 10)
+	queryParams := url.Values{
+		"expires":   []string{expiresAt},
+		"signature": []string{localObjectStorage.computeSignature(key, expiresAt)},
+	}
+
+	return fmt.Sprintf("%s/%s?%s", localObjectStorage.baseUrl, key, queryParams.Encode()), nil
+}
+
+// OpenSignedObject opens the object that a URL returned by `SignDownloadUrl` points to,
+// as long as that URL has not expired and was not tampered with.
+func (localObjectStorage *LocalObjectStorage) OpenSignedObject(
+	key string,
+	expiresAt string,
+	signature string,
+) (*os.File, error) {
+	expiresAtUnix, err := strconv.ParseInt(expiresAt, 10, 64)
+	if err != nil || time.Now().Unix() > expiresAtUnix {
+		return nil, ErrObjectNotFound
+	}
+
+	expectedSignature := localObjectStorage.computeSignature(key, expiresAt)
+	if !hmac.Equal([]byte(expectedSignature), []byte(signature)) {
+		return nil, ErrObjectNotFound
+	}
+
+	objectPath, err := localObjectStorage.resolveObjectPath(key)
+	if err != nil {
+		return nil, err
+	}
+
+	objectFile, err := os.Open(objectPath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrObjectNotFound
+	}
+
+	return objectFile, errors.WithStack(err)
+}
+
+func (localObjectStorage *LocalObjectStorage) resolveObjectPath(key string) (string, error) {
+	// Rejects empty keys and keys that would escape the storage directory, such as "../secret".
+	if !fs.ValidPath(key) || key == "." {
+		return "", errors.Errorf("invalid object key '%s'", key)
+	}
+
+	return filepath.Join(localObjectStorage.directory, filepath.FromSlash(key)), nil
+}
+
+func (localObjectStorage *LocalObjectStorage) computeSignature(key string, expiresAt string) string {
+	mac := hmac.New(sha256.New, localObjectStorage.signingSecret)
+	mac.Write([]byte(key + "\n" + expiresAt))
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
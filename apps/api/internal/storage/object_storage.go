@@ -0,0 +1,62 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/cloudy-clip/api/internal/common/environment"
+)
+
+const (
+	ObjectStorageBackendLocal = "LOCAL"
+	ObjectStorageBackendS3    = "S3"
+)
+
+var (
+	ErrObjectNotFound = errors.New("object was not found")
+	objectStorage     ObjectStorage
+)
+
+// ObjectStorage stores the binary content of clipboard items, such as images,
+// which clients download directly from the backend through short-lived signed URLs.
+type ObjectStorage interface {
+	// PutObject streams exactly `size` bytes from `body` into the object, replacing it if it exists.
+	PutObject(ctx context.Context, key string, body io.Reader, size int64, contentType string) error
+	// DeleteObject does not fail when the object does not exist.
+	DeleteObject(ctx context.Context, key string) error
+	SignDownloadUrl(key string, expiresIn time.Duration) (string, error)
+}
+
+func GetObjectStorage() ObjectStorage {
+	return objectStorage
+}
+
+func NewObjectStorage() (ObjectStorage, error) {
+	switch environment.Config.StorageBackend {
+	case ObjectStorageBackendLocal:
+		return NewLocalObjectStorage(
+			environment.Config.StorageLocalDirectory,
+			environment.Config.StorageLocalBaseUrl,
+			environment.Config.SigningSecret,
+		)
+
+	case ObjectStorageBackendS3:
+		return NewS3ObjectStorage(
+			environment.Config.StorageS3Endpoint,
+			environment.Config.StorageS3Region,
+			environment.Config.StorageS3Bucket,
+			environment.Config.StorageS3AccessKeyId,
+			environment.Config.StorageS3SecretAccessKey,
+			environment.Config.StorageS3UsePathStyle,
+		)
+	}
+
+	return nil, fmt.Errorf("unknown object storage backend '%s'", environment.Config.StorageBackend)
+}
+
+func GetDownloadUrlTtl() time.Duration {
+	return time.Duration(environment.Config.StorageUrlTtlSeconds) * time.Second
+}
@@ -0,0 +1,133 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// How much of an error response is kept for the error message.
+	maxS3ErrorResponseSize = 1024
+)
+
+// S3ObjectStorage talks to AWS S3 or any service that implements its API, such as MinIO or R2.
+type S3ObjectStorage struct {
+	endpoint     *url.URL
+	bucket       string
+	usePathStyle bool
+	signer       S3RequestSigner
+	httpClient   *http.Client
+}
+
+func NewS3ObjectStorage(
+	endpoint string,
+	region string,
+	bucket string,
+	accessKeyId string,
+	secretAccessKey string,
+	usePathStyle bool,
+) (*S3ObjectStorage, error) {
+	if endpoint == "" || region == "" || bucket == "" || accessKeyId == "" || secretAccessKey == "" {
+		return nil, errors.New("S3 object storage requires an endpoint, region, bucket and credentials")
+	}
+
+	endpointUrl, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return &S3ObjectStorage{
+		endpoint:     endpointUrl,
+		bucket:       bucket,
+		usePathStyle: usePathStyle,
+		signer: S3RequestSigner{
+			AccessKeyId:     accessKeyId,
+			SecretAccessKey: secretAccessKey,
+			Region:          region,
+		},
+		httpClient: &http.Client{},
+	}, nil
+}
+
+func (s3ObjectStorage *S3ObjectStorage) PutObject(
+	ctx context.Context,
+	key string,
+	body io.Reader,
+	size int64,
+	contentType string,
+) error {
+	request, err := http.NewRequestWithContext(ctx, http.MethodPut, s3ObjectStorage.objectUrl(key).String(), body)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	// The body is streamed straight through, so its hash is not known upfront.
+	request.ContentLength = size
+	if size == 0 {
+		request.Body = http.NoBody
+	}
+
+	request.Header.Set("Content-Type", contentType)
+	s3ObjectStorage.signer.Sign(request, S3UnsignedPayload, time.Now())
+
+	return s3ObjectStorage.send(request, http.StatusOK)
+}
+
+func (s3ObjectStorage *S3ObjectStorage) DeleteObject(ctx context.Context, key string) error {
+	request, err := http.NewRequestWithContext(ctx, http.MethodDelete, s3ObjectStorage.objectUrl(key).String(), nil)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	s3ObjectStorage.signer.Sign(request, S3EmptyPayloadSha256, time.Now())
+
+	return s3ObjectStorage.send(request, http.StatusNoContent, http.StatusOK, http.StatusNotFound)
+}
+
+func (s3ObjectStorage *S3ObjectStorage) SignDownloadUrl(key string, expiresIn time.Duration) (string, error) {
+	return s3ObjectStorage.signer.Presign(http.MethodGet, s3ObjectStorage.objectUrl(key), expiresIn, time.Now()), nil
+}
+
+func (s3ObjectStorage *S3ObjectStorage) objectUrl(key string) *url.URL {
+	objectUrl := *s3ObjectStorage.endpoint
+	basePath := strings.TrimSuffix(objectUrl.Path, "/")
+
+	if s3ObjectStorage.usePathStyle {
+		objectUrl.Path = basePath + "/" + s3ObjectStorage.bucket + "/" + key
+	} else {
+		objectUrl.Host = s3ObjectStorage.bucket + "." + objectUrl.Host
+		objectUrl.Path = basePath + "/" + key
+	}
+
+	return &objectUrl
+}
+
+func (s3ObjectStorage *S3ObjectStorage) send(request *http.Request, expectedStatusCodes ...int) error {
+	response, err := s3ObjectStorage.httpClient.Do(request)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer response.Body.Close()
+
+	for _, expectedStatusCode := range expectedStatusCodes {
+		if response.StatusCode == expectedStatusCode {
+			return nil
+		}
+	}
+
+	responseBody, _ := io.ReadAll(io.LimitReader(response.Body, maxS3ErrorResponseSize))
+
+	return errors.Errorf(
+		"S3 responded to %s %s with status %d: %s",
+		request.Method,
+		request.URL.Path,
+		response.StatusCode,
+		responseBody,
+	)
+}
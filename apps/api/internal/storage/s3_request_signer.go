@@ -0,0 +1,196 @@
+package storage
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	s3SigningAlgorithm   = "AWS4-HMAC-SHA256"
+	s3AmzDateFormat      = "20060102T150405Z"
+	s3ShortDateFormat    = "20060102"
+	S3UnsignedPayload    = "UNSIGNED-PAYLOAD"
+	S3EmptyPayloadSha256 = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+)
+
+// S3RequestSigner signs requests with AWS Signature Version 4, which every S3-compatible service accepts.
+type S3RequestSigner struct {
+	AccessKeyId     string
+	SecretAccessKey string
+	Region          string
+}
+
+// Sign adds the `Authorization` header to `request` along with the headers it depends on,
+// every header that is already set on `request` is signed.
+func (signer S3RequestSigner) Sign(request *http.Request, payloadHash string, now time.Time) {
+	amzDate := now.UTC().Format(s3AmzDateFormat)
+	request.Header.Set("X-Amz-Date", amzDate)
+	request.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	canonicalHeaders, signedHeaders := canonicalizeS3Headers(request)
+	canonicalRequest := strings.Join(
+		[]string{
+			request.Method,
+			canonicalizeS3Path(request.URL.Path),
+			canonicalizeS3Query(request.URL.Query()),
+			canonicalHeaders,
+			signedHeaders,
+			payloadHash,
+		},
+		"\n",
+	)
+
+	scope := signer.scope(now)
+	signature := signer.computeSignature(now, amzDate, scope, canonicalRequest)
+
+	request.Header.Set(
+		"Authorization",
+		s3SigningAlgorithm+
+			" Credential="+signer.AccessKeyId+"/"+scope+
+			", SignedHeaders="+signedHeaders+
+			", Signature="+signature,
+	)
+}
+
+// Presign returns `objectUrl` with a signature in its query string that lets anyone holding it
+// send a `method` request to it until it expires.
+func (signer S3RequestSigner) Presign(method string, objectUrl *url.URL, expiresIn time.Duration, now time.Time) string {
+	amzDate := now.UTC().Format(s3AmzDateFormat)
+	scope := signer.scope(now)
+
+	queryParams := objectUrl.Query()
+	queryParams.Set("X-Amz-Algorithm", s3SigningAlgorithm)
+	queryParams.Set("X-Amz-Credential", signer.AccessKeyId+"/"+scope)
+	queryParams.Set("X-Amz-Date", amzDate)
+	queryParams.Set("X-Amz-Expires", strconv.FormatInt(int64(expiresIn.Seconds()), 10))
+	queryParams.Set("X-Amz-SignedHeaders", "host")
+
+	canonicalQuery := canonicalizeS3Query(queryParams)
+	canonicalRequest := strings.Join(
+		[]string{
+			method,
+			canonicalizeS3Path(objectUrl.Path),
+			canonicalQuery,
+			"host:" + objectUrl.Host + "\n",
+			"host",
+			S3UnsignedPayload,
+		},
+		"\n",
+	)
+
+	signature := signer.computeSignature(now, amzDate, scope, canonicalRequest)
+
+	presignedUrl := *objectUrl
+	presignedUrl.RawQuery = canonicalQuery + "&X-Amz-Signature=" + signature
+
+	return presignedUrl.String()
+}
+
+func (signer S3RequestSigner) scope(now time.Time) string {
+	return now.UTC().Format(s3ShortDateFormat) + "/" + signer.Region + "/s3/aws4_request"
+}
+
+func (signer S3RequestSigner) computeSignature(
+	now time.Time,
+	amzDate string,
+	scope string,
+	canonicalRequest string,
+) string {
+	canonicalRequestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := s3SigningAlgorithm + "\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(canonicalRequestHash[:])
+
+	signingKey := hmacSha256([]byte("AWS4"+signer.SecretAccessKey), now.UTC().Format(s3ShortDateFormat))
+	signingKey = hmacSha256(signingKey, signer.Region)
+	signingKey = hmacSha256(signingKey, "s3")
+	signingKey = hmacSha256(signingKey, "aws4_request")
+
+	return hex.EncodeToString(hmacSha256(signingKey, stringToSign))
+}
+
+func canonicalizeS3Headers(request *http.Request) (string, string) {
+	headerValues := map[string]string{
+		"host": request.URL.Host,
+	}
+
+	for headerName, values := range request.Header {
+		trimmedValues := make([]string, 0, len(values))
+		for _, value := range values {
+			trimmedValues = append(trimmedValues, strings.Join(strings.Fields(value), " "))
+		}
+
+		headerValues[strings.ToLower(headerName)] = strings.Join(trimmedValues, ",")
+	}
+
+	headerNames := make([]string, 0, len(headerValues))
+	for headerName := range headerValues {
+		headerNames = append(headerNames, headerName)
+	}
+
+	sort.Strings(headerNames)
+
+	var canonicalHeaders strings.Builder
+	for _, headerName := range headerNames {
+		canonicalHeaders.WriteString(headerName + ":" + headerValues[headerName] + "\n")
+	}
+
+	return canonicalHeaders.String(), strings.Join(headerNames, ";")
+}
+
+func canonicalizeS3Path(path string) string {
+	if path == "" {
+		return "/"
+	}
+
+	segments := strings.Split(path, "/")
+	for index, segment := range segments {
+		segments[index] = encodeS3UriComponent(segment)
+	}
+
+	return strings.Join(segments, "/")
+}
+
+func canonicalizeS3Query(queryParams url.Values) string {
+	encodedParams := make([]string, 0, len(queryParams))
+	for name, values := range queryParams {
+		for _, value := range values {
+			encodedParams = append(encodedParams, encodeS3UriComponent(name)+"="+encodeS3UriComponent(value))
+		}
+	}
+
+	sort.Strings(encodedParams)
+
+	return strings.Join(encodedParams, "&")
+}
+
+// encodeS3UriComponent percent-encodes every byte except the unreserved characters of RFC 3986,
+// unlike `url.QueryEscape` which turns spaces into "+".
+func encodeS3UriComponent(component string) string {
+	var encoded strings.Builder
+
+	for _, character := range []byte(component) {
+		if ('A' <= character && character <= 'Z') ||
+			('a' <= character && character <= 'z') ||
+			('0' <= character && character <= '9') ||
+			character == '-' || character == '.' || character == '_' || character == '~' {
+			encoded.WriteByte(character)
+		} else {
+			encoded.WriteString("%" + strings.ToUpper(hex.EncodeToString([]byte{character})))
+		}
+	}
+
+	return encoded.String()
+}
+
+func hmacSha256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+
+	return mac.Sum(nil)
+}
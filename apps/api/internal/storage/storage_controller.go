@@ -0,0 +1,91 @@
+package storage
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/cloudy-clip/api/internal/common/environment"
+	"github.com/cloudy-clip/api/internal/common/exception"
+	_http "github.com/cloudy-clip/api/internal/common/http"
+	"github.com/cloudy-clip/api/internal/common/http/middleware/context"
+	"github.com/cloudy-clip/api/internal/common/logger"
+	"github.com/go-chi/chi/v5"
+	"github.com/pkg/errors"
+)
+
+var (
+	storageControllerLogger *logger.Logger
+)
+
+func SetupStorageControllerEndpoints(parentRouter chi.Router) {
+	storageControllerLogger = logger.NewLogger(
+		"StorageController", slog.Level(environment.Config.ApplicationLogLevel),
+	)
+
+	var err error
+	objectStorage, err = NewObjectStorage()
+	if err != nil {
+		panic(err)
+	}
+
+	localObjectStorage, ok := objectStorage.(*LocalObjectStorage)
+	if !ok {
+		// Signed URLs of every other backend point straight at the backend.
+		return
+	}
+
+	parentRouter.Route("/v1/storage/objects", func(v1Router chi.Router) {
+		v1Router.Group(func(router chi.Router) {
+			router.Use(
+				context.CallSiteMiddleware("handleLocalObjectDownload"),
+			)
+			router.Get("/*", handleLocalObjectDownload(localObjectStorage))
+		})
+	})
+}
+
+// handleLocalObjectDownload serves the URLs signed by `LocalObjectStorage`. The signature stands in for
+// the jwt, so that the URLs can be opened by anything the client hands them to.
+func handleLocalObjectDownload(localObjectStorage *LocalObjectStorage) http.HandlerFunc {
+	return func(responseWriter http.ResponseWriter, request *http.Request) {
+		ctx := request.Context()
+		key := chi.URLParam(request, "*")
+		queryParams := request.URL.Query()
+
+		objectFile, err := localObjectStorage.OpenSignedObject(
+			key,
+			queryParams.Get("expires"),
+			queryParams.Get("signature"),
+		)
+		if err != nil {
+			if errors.Is(err, ErrObjectNotFound) {
+				_http.WriteErrorResponse(
+					request,
+					responseWriter,
+					exception.NewNotFoundException("object was not found or its url has expired"),
+				)
+
+				return
+			}
+
+			storageControllerLogger.ErrorAttrs(ctx, err, "failed to open object", slog.String("key", key))
+
+			_http.WriteErrorResponse(request, responseWriter, exception.NewUnknownException("failed to open object"))
+
+			return
+		}
+		defer objectFile.Close()
+
+		objectFileInfo, err := objectFile.Stat()
+		if err != nil {
+			storageControllerLogger.ErrorAttrs(ctx, err, "failed to stat object", slog.String("key", key))
+
+			_http.WriteErrorResponse(request, responseWriter, exception.NewUnknownException("failed to open object"))
+
+			return
+		}
+
+		responseWriter.Header().Set("Cache-Control", "private, max-age=0")
+		http.ServeContent(responseWriter, request, "", objectFileInfo.ModTime(), objectFile)
+	}
+}
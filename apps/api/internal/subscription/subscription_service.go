@@ -32,6 +32,12 @@ import (
 	taskModel "github.com/cloudy-clip/api/internal/task/model"
 )
 
+const (
+	// How many days a canceled subscription keeps its plan's entitlements and data before
+	// the user is moved back to the free plan.
+	CanceledSubscriptionGracePeriodDays = 30
+)
+
 var (
 	subscriptionServiceLogger *_logger.Logger
 	planCache                 otter.Cache[bool, []dto.Plan]
@@ -545,6 +551,25 @@ func (subscriptionService *SubscriptionService) FindActiveSubscriptionForUser(
 	return nil, err
 }
 
+func (subscriptionService *SubscriptionService) FindPlanByOfferingId(
+	ctx context.Context,
+	offeringId string,
+) (*dto.Plan, error) {
+	allPlans, err := getAllPlans(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	indexOfPlan := slices.IndexFunc(allPlans, func(plan dto.Plan) bool {
+		return plan.OfferingId == offeringId
+	})
+	if indexOfPlan == -1 {
+		return nil, exception.NewNotFoundException("no plan was found for offering ID '" + offeringId + "'")
+	}
+
+	return &allPlans[indexOfPlan], nil
+}
+
 func (subscriptionService *SubscriptionService) getSubscriptionCancellationRefundAmount(
 	ctx context.Context,
 ) (int64, exception.Exception) {
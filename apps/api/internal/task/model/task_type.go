@@ -12,6 +12,7 @@ const (
 	TaskTypeSubscriptionUpdatePayment TaskType = "SUBSCRIPTION_UPDATE_PAYMENT"
 	TaskTypeReactivationPayment       TaskType = "REACTIVATION_PAYMENT"
 	TaskTypeSubscriptionCancellation  TaskType = "SUBSCRIPTION_CANCELLATION"
+	TaskTypeRetentionPurge            TaskType = "RETENTION_PURGE"
 )
 
 func (taskType TaskType) MarshalJSON() ([]byte, error) {
@@ -38,6 +39,8 @@ func (taskType *TaskType) UnmarshalJSON(buf []byte) error {
 		*taskType = TaskTypeReactivationPayment
 	case "SUBSCRIPTION_CANCELLATION":
 		*taskType = TaskTypeSubscriptionCancellation
+	case "RETENTION_PURGE":
+		*taskType = TaskTypeRetentionPurge
 	default:
 		return errors.New("unknown task type '" + taskTypeString + "'")
 	}
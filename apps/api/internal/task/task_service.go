@@ -57,6 +57,34 @@ func (taskService *TaskService) AddTask(
 	return "", err
 }
 
+// AddCompletedTask records a task that already finished successfully, with `comment`
+// describing what was done, e.g. for background jobs that run without user interaction.
+func (taskService *TaskService) AddCompletedTask(
+	ctx context.Context,
+	transaction pgx.Tx,
+	taskType model.TaskType,
+	userId string,
+	comment string,
+) (string, error) {
+	taskId, err := ulid.Generate()
+	if err == nil {
+		return taskId, taskRepository.AddTask(
+			ctx,
+			transaction,
+			_jetModel.Task{
+				TaskID:    taskId,
+				Type:      taskType,
+				Status:    model.TaskStatusSuccess,
+				UpdatedAt: time.Now(),
+				UserID:    userId,
+				Comment:   &comment,
+			},
+		)
+	}
+
+	return "", err
+}
+
 func (taskService *TaskService) GetTask(ctx context.Context, taskId string) (dto.Task, exception.Exception) {
 	taskModel, err := taskRepository.findTaskById(ctx, taskId)
 	if err == nil {
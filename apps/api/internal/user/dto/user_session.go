@@ -9,6 +9,7 @@ type UserSession struct {
 	ExpiresAt time.Time `json:"expiresAt"`
 	Ip        string    `json:"ip"`
 	UserAgent string    `json:"userAgent"`
+	DeviceId  string    `json:"deviceId,omitempty"`
 }
 
 func (userSession *UserSession) IsExpired() bool {
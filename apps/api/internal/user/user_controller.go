@@ -25,6 +25,10 @@ var userService *UserService
 var userRepository *UserRepository
 var userControllerLogger *logger.Logger
 
+func GetUserService() *UserService {
+	return userService
+}
+
 func SetupUserControllerEndpoints(parentRouter chi.Router) {
 	userRepository = NewUserRepository()
 	userService = NewUserService()
@@ -181,7 +185,7 @@ func handleUserCreation() http.HandlerFunc {
 				request.UserAgent(),
 			)
 			if err == nil {
-				setAuthenticationCookies(responseWriter, authenticatedUser)
+				SetAuthenticationCookies(responseWriter, authenticatedUser)
 			}
 
 			return authenticatedUser, err
@@ -189,7 +193,8 @@ func handleUserCreation() http.HandlerFunc {
 	)
 }
 
-func setAuthenticationCookies(responseWriter http.ResponseWriter, authenticatedUser *dto.AuthenticatedUser) {
+// SetAuthenticationCookies removes the cookies when `authenticatedUser` is nil.
+func SetAuthenticationCookies(responseWriter http.ResponseWriter, authenticatedUser *dto.AuthenticatedUser) {
 	if authenticatedUser != nil {
 		_http.SetCookieWithExpiration(
 			responseWriter,
@@ -269,7 +274,7 @@ func handleLogin() http.HandlerFunc {
 				request.UserAgent(),
 			)
 			if err == nil {
-				setAuthenticationCookies(responseWriter, authenticatedUser)
+				SetAuthenticationCookies(responseWriter, authenticatedUser)
 			}
 
 			return authenticatedUser, err
@@ -308,7 +313,7 @@ func handleUserSessionRestoration() http.HandlerFunc {
 				request.UserAgent(),
 			)
 			if authenticatedUser != nil {
-				setAuthenticationCookies(responseWriter, authenticatedUser)
+				SetAuthenticationCookies(responseWriter, authenticatedUser)
 			}
 
 			return authenticatedUser, err
@@ -317,7 +322,7 @@ func handleUserSessionRestoration() http.HandlerFunc {
 }
 
 func handleLogout(responseWriter http.ResponseWriter, request *http.Request) {
-	setAuthenticationCookies(responseWriter, nil)
+	SetAuthenticationCookies(responseWriter, nil)
 
 	_http.WriteNoContentResponse(responseWriter)
 
@@ -404,7 +409,7 @@ func handleGoogleLogin() http.HandlerFunc {
 				request.UserAgent(),
 			)
 			if err == nil {
-				setAuthenticationCookies(responseWriter, authenticatedUser)
+				SetAuthenticationCookies(responseWriter, authenticatedUser)
 
 				_http.RemoveCookie(responseWriter, Oauth2StateCookieName)
 			}
@@ -447,7 +452,7 @@ func handleFacebookLogin() http.HandlerFunc {
 				request.UserAgent(),
 			)
 			if err == nil {
-				setAuthenticationCookies(responseWriter, authenticatedUser)
+				SetAuthenticationCookies(responseWriter, authenticatedUser)
 
 				_http.RemoveCookie(responseWriter, Oauth2StateCookieName)
 			}
@@ -490,7 +495,7 @@ func handleDiscordLogin() http.HandlerFunc {
 				request.UserAgent(),
 			)
 			if err == nil {
-				setAuthenticationCookies(responseWriter, authenticatedUser)
+				SetAuthenticationCookies(responseWriter, authenticatedUser)
 
 				_http.RemoveCookie(responseWriter, Oauth2StateCookieName)
 			}
@@ -5,7 +5,6 @@ import (
 	"crypto/aes"
 	"crypto/cipher"
 	"crypto/rand"
-	"crypto/subtle"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
@@ -24,6 +23,7 @@ import (
 	"github.com/cloudy-clip/api/internal/common/email"
 	"github.com/cloudy-clip/api/internal/common/environment"
 	"github.com/cloudy-clip/api/internal/common/exception"
+	"github.com/cloudy-clip/api/internal/common/hashing"
 	"github.com/cloudy-clip/api/internal/common/jwt"
 	_logger "github.com/cloudy-clip/api/internal/common/logger"
 	"github.com/cloudy-clip/api/internal/common/ulid"
@@ -32,7 +32,6 @@ import (
 	"github.com/cloudy-clip/api/internal/user/dto"
 	userException "github.com/cloudy-clip/api/internal/user/exception"
 	"github.com/cloudy-clip/api/internal/user/model"
-	"golang.org/x/crypto/argon2"
 	"golang.org/x/oauth2"
 	"golang.org/x/oauth2/facebook"
 	"golang.org/x/oauth2/google"
@@ -100,7 +99,7 @@ func createUser(
 		return nil, err
 	}
 
-	salt, err := generateSalt()
+	salt, err := hashing.GenerateSalt()
 	if err != nil {
 		return nil, err
 	}
@@ -128,7 +127,7 @@ func createUser(
 			return err
 		}
 
-		authenticatedUser, err = establishAuthenticatedUserSession(ctx, userModel, userIp, userAgent)
+		authenticatedUser, err = establishAuthenticatedUserSession(ctx, userModel, userIp, userAgent, "")
 		if err != nil {
 			return err
 		}
@@ -146,30 +145,8 @@ func createUser(
 	return nil, err
 }
 
-func generateSalt() ([]byte, error) {
-	salt := make([]byte, 32)
-	_, err := rand.Read(salt)
-	if err != nil {
-		return nil, errors.WithStack(err)
-	}
-
-	return salt, nil
-}
-
 func hashAndStringifyPassword(password string, salt []byte) string {
-	return hex.EncodeToString(hashPassword(password, salt))
-}
-
-func hashPassword(password string, salt []byte) []byte {
-	// https://cheatsheetseries.owasp.org/cheatsheets/Password_Storage_Cheat_Sheet.html#password-hashing-algorithms
-	const (
-		t         = 5
-		m         = 7168
-		p         = 1
-		keyLength = 32
-	)
-
-	return argon2.IDKey([]byte(password), salt, t, m, p, keyLength)
+	return hex.EncodeToString(hashing.HashPassword(password, salt))
 }
 
 func sendAccountVerificationEmail(
@@ -227,6 +204,7 @@ func establishAuthenticatedUserSession(
 	user *_jetModel.User,
 	userIp string,
 	userAgent string,
+	deviceId string,
 ) (*dto.AuthenticatedUser, error) {
 	userClaims := jwt.UserClaims{
 		UserId:         user.UserID,
@@ -234,6 +212,7 @@ func establishAuthenticatedUserSession(
 		DisplayName:    user.DisplayName,
 		Status:         user.Status.String(),
 		LastLoggedInAt: user.LastLoggedInAt,
+		DeviceId:       deviceId,
 	}
 	accessToken, err := jwt.Sign(&userClaims)
 	if err != nil {
@@ -252,7 +231,7 @@ func establishAuthenticatedUserSession(
 		return nil, err
 	}
 
-	userSession, err := createUserSession(user, userIp, userAgent)
+	userSession, err := createUserSession(user, userIp, userAgent, deviceId)
 	if err == nil {
 		return dto.NewAuthenticatedUser(user, accessToken, userClaims.ExpiresAt.Time, userSession), nil
 	}
@@ -260,7 +239,12 @@ func establishAuthenticatedUserSession(
 	return nil, err
 }
 
-func createUserSession(user *_jetModel.User, userIp string, userAgent string) (*dto.UserSession, error) {
+func createUserSession(
+	user *_jetModel.User,
+	userIp string,
+	userAgent string,
+	deviceId string,
+) (*dto.UserSession, error) {
 	aesGcm, nonce, err := createAesGcmCipher(true)
 	if err != nil {
 		return nil, err
@@ -274,6 +258,7 @@ func createUserSession(user *_jetModel.User, userIp string, userAgent string) (*
 		),
 		Ip:        userIp,
 		UserAgent: userAgent,
+		DeviceId:  deviceId,
 	}
 
 	payloadToEncrypt, err := json.Marshal(userSession)
@@ -380,9 +365,7 @@ func checkPassword(user *_jetModel.User, passwordToCheck string) error {
 		return errors.WithStack(err)
 	}
 
-	hashedProvidedCurrentPassword := hashPassword(passwordToCheck, storedSalt)
-
-	if subtle.ConstantTimeCompare(storedPassword, hashedProvidedCurrentPassword) != 1 {
+	if !hashing.IsPasswordCorrect(passwordToCheck, storedPassword, storedSalt) {
 		return userException.ErrWrongPassword
 	}
 
@@ -732,7 +715,7 @@ func checkPasswordAndLogin(
 ) (*dto.AuthenticatedUser, error) {
 	err := checkPassword(user, passwordToCheck)
 	if err == nil {
-		authenticatedUser, err := startAuthenticationSession(ctx, user, userIp, userAgent)
+		authenticatedUser, err := startAuthenticationSession(ctx, user, userIp, userAgent, "")
 		if err != nil {
 			return nil, err
 		}
@@ -803,8 +786,9 @@ func startAuthenticationSession(
 	user *_jetModel.User,
 	userIp string,
 	userAgent string,
+	deviceId string,
 ) (*dto.AuthenticatedUser, error) {
-	authenticatedUser, err := establishAuthenticatedUserSession(ctx, user, userIp, userAgent)
+	authenticatedUser, err := establishAuthenticatedUserSession(ctx, user, userIp, userAgent, deviceId)
 	if err != nil {
 		return nil, err
 	}
@@ -824,6 +808,22 @@ func startAuthenticationSession(
 	return authenticatedUser, nil
 }
 
+// StartDeviceSession issues the current user new credentials that are bound to one of their registered devices,
+// so that they stop working once that device is revoked.
+func (userService *UserService) StartDeviceSession(
+	ctx context.Context,
+	deviceId string,
+	userIp string,
+	userAgent string,
+) (*dto.AuthenticatedUser, error) {
+	foundUser, err := user.FindUserById(ctx, nil, jwt.GetUserIdClaim(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	return startAuthenticationSession(ctx, &foundUser, userIp, userAgent, deviceId)
+}
+
 func sendAccountIsBlockedDueToMultipleLoginAttemptsEmail(ctx context.Context, user *_jetModel.User) {
 	emailMessageBuilder := email.
 		NewEmailBuilder().
@@ -985,6 +985,21 @@ func loginUserFromPreviousSession(
 		return nil, nil
 	}
 
+	if userSession.DeviceId != "" {
+		err := jwt.VerifyDevice(ctx, userSession.UserId, userSession.DeviceId)
+		if err != nil {
+			userServiceLogger.WarnAttrs(
+				ctx,
+				"device of user session is no longer allowed to sign in",
+				slog.String("userEmail", userSession.Email),
+				slog.String("deviceId", userSession.DeviceId),
+				slog.String("reason", err.Error()),
+			)
+
+			return nil, nil
+		}
+	}
+
 	foundUser, err := user.FindUserById(ctx, nil, userSession.UserId)
 	if err != nil {
 		return nil, err
@@ -993,7 +1008,7 @@ func loginUserFromPreviousSession(
 	if foundUser.Status == model.UserStatusActive ||
 		(foundUser.Status == model.UserStatusUnverified &&
 			!hasUserPassedEmailVerificationWindow(&foundUser, VerificationGracePeriodInDays)) {
-		return startAuthenticationSession(ctx, &foundUser, userIp, userAgent)
+		return startAuthenticationSession(ctx, &foundUser, userIp, userAgent, userSession.DeviceId)
 	}
 
 	return nil, nil
@@ -1381,7 +1396,7 @@ func logInOauth2User(
 ) (*dto.AuthenticatedUser, error) {
 	switch user.Status {
 	case model.UserStatusActive:
-		return startAuthenticationSession(ctx, user, userIp, userAgent)
+		return startAuthenticationSession(ctx, user, userIp, userAgent, "")
 
 	case model.UserStatusInactive:
 		user.Status = model.UserStatusActive
@@ -1389,14 +1404,14 @@ func logInOauth2User(
 
 		err := userRepository.updateUser(ctx, nil, user)
 		if err == nil {
-			return startAuthenticationSession(ctx, user, userIp, userAgent)
+			return startAuthenticationSession(ctx, user, userIp, userAgent, "")
 		}
 
 		return nil, err
 
 	case model.UserStatusUnverified:
 		if !hasUserPassedEmailVerificationWindow(user, VerificationGracePeriodInDays) {
-			return startAuthenticationSession(ctx, user, userIp, userAgent)
+			return startAuthenticationSession(ctx, user, userIp, userAgent, "")
 		}
 
 		err := userRepository.blockUserPermanently(
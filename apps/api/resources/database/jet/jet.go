@@ -7,6 +7,9 @@ import (
 	"strings"
 
 	_billingModel "github.com/cloudy-clip/api/internal/billing/model"
+	_clipboardModel "github.com/cloudy-clip/api/internal/clipboard/model"
+	_deviceModel "github.com/cloudy-clip/api/internal/device/model"
+	_eventModel "github.com/cloudy-clip/api/internal/event/model"
 	_subscriptionModel "github.com/cloudy-clip/api/internal/subscription/model"
 	_taskModel "github.com/cloudy-clip/api/internal/task/model"
 	_userModel "github.com/cloudy-clip/api/internal/user/model"
@@ -36,6 +39,9 @@ func main() {
 	}
 
 	modelPropertyToTypeMap := map[string]any{
+		"ClipboardEvent:Type":               _eventModel.ClipboardEventTypeCreated,
+		"ClipboardItem:Type":                _clipboardModel.ClipboardItemTypeText,
+		"Device:Platform":                   _deviceModel.DevicePlatformMacos,
 		"Subscription:CancellationReason":   _subscriptionModel.SubscriptionCancellationReasonRequestedByUser,
 		"Payment:Status":                    _billingModel.PaymentStatusDraft,
 		"Payment:PaymentReason":             _billingModel.PaymentReasonSubscriptionCancellation,
@@ -0,0 +1,209 @@
+package clipboard
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cloudy-clip/api/internal/clipboard/dto"
+	"github.com/cloudy-clip/api/internal/clipboard/model"
+	"github.com/cloudy-clip/api/test/debug"
+	test "github.com/cloudy-clip/api/test/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClipboardApi(t1 *testing.T) {
+	test.Integration(t1, func(testServer *httptest.Server) {
+		sessionCookie, _ := test.CreateAndLoginUser(t1, testServer)
+		headers := map[string]string{
+			"Cookie": sessionCookie,
+		}
+
+		t1.Run("1. can create clipboard item", func(t2 *testing.T) {
+			response, responseBody := test.SendPostRequest(
+				t2,
+				testServer,
+				"/api/v1/clipboard/items",
+				dto.CreateClipboardItemRequest{
+					Type:    model.ClipboardItemTypeText,
+					Content: "Hello world",
+				},
+				headers,
+			)
+
+			require.Equal(t2, http.StatusCreated, response.StatusCode)
+			require.Subset(
+				t2,
+				responseBody["payload"],
+				debug.JsonParse(`
+					{
+						"type": "TEXT",
+						"content": "Hello world",
+						"isPinned": false,
+						"pinnedAt": null
+					}
+				`),
+			)
+			require.NotEmpty(t2, test.GetValueFromMap(responseBody["payload"], "clipboardItemId"))
+		})
+
+		t1.Run("2. returns 400 when clipboard item type is unknown", func(t2 *testing.T) {
+			response, _ := test.SendPostRequest(
+				t2,
+				testServer,
+				"/api/v1/clipboard/items",
+				map[string]any{
+					"type":    "VIDEO",
+					"content": "Hello world",
+				},
+				headers,
+			)
+
+			require.Equal(t2, http.StatusBadRequest, response.StatusCode)
+		})
+
+		t1.Run("3. can paginate clipboard items", func(t2 *testing.T) {
+			sessionCookie, _ := test.CreateAndLoginUser(t2, testServer)
+			headers := map[string]string{
+				"Cookie": sessionCookie,
+			}
+
+			for _, content := range []string{"first", "second", "third"} {
+				response, _ := test.SendPostRequest(
+					t2,
+					testServer,
+					"/api/v1/clipboard/items",
+					dto.CreateClipboardItemRequest{
+						Type:    model.ClipboardItemTypeText,
+						Content: content,
+					},
+					headers,
+				)
+
+				require.Equal(t2, http.StatusCreated, response.StatusCode)
+			}
+
+			response, responseBody := test.SendGetRequest(
+				t2,
+				testServer,
+				"/api/v1/clipboard/items?offset=0&limit=2",
+				headers,
+			)
+
+			require.Equal(t2, http.StatusOK, response.StatusCode)
+
+			payload := responseBody["payload"].(map[string]any)
+			page := payload["page"].([]any)
+
+			require.EqualValues(t2, 3, payload["total"])
+			require.Len(t2, page, 2)
+			require.Equal(t2, "third", page[0].(map[string]any)["content"])
+			require.Equal(t2, "second", page[1].(map[string]any)["content"])
+		})
+
+		t1.Run("4. can pin and unpin clipboard item", func(t2 *testing.T) {
+			clipboardItemId := createClipboardItem(t2, testServer, headers, "pin me")
+
+			response, responseBody := test.SendPatchRequest(
+				t2,
+				testServer,
+				"/api/v1/clipboard/items/"+clipboardItemId,
+				map[string]any{
+					"isPinned": true,
+				},
+				headers,
+			)
+
+			require.Equal(t2, http.StatusOK, response.StatusCode)
+			require.Equal(t2, true, responseBody["payload"].(map[string]any)["isPinned"])
+			require.NotNil(t2, responseBody["payload"].(map[string]any)["pinnedAt"])
+
+			response, responseBody = test.SendPatchRequest(
+				t2,
+				testServer,
+				"/api/v1/clipboard/items/"+clipboardItemId,
+				map[string]any{
+					"isPinned": false,
+				},
+				headers,
+			)
+
+			require.Equal(t2, http.StatusOK, response.StatusCode)
+			require.Equal(t2, false, responseBody["payload"].(map[string]any)["isPinned"])
+			require.Nil(t2, responseBody["payload"].(map[string]any)["pinnedAt"])
+		})
+
+		t1.Run("5. can delete clipboard item", func(t2 *testing.T) {
+			clipboardItemId := createClipboardItem(t2, testServer, headers, "delete me")
+
+			response, _ := test.SendDeleteRequest(
+				t2,
+				testServer,
+				"/api/v1/clipboard/items/"+clipboardItemId,
+				headers,
+			)
+
+			require.Equal(t2, http.StatusNoContent, response.StatusCode)
+
+			response, responseBody := test.SendGetRequest(
+				t2,
+				testServer,
+				"/api/v1/clipboard/items/"+clipboardItemId,
+				headers,
+			)
+
+			require.Equal(t2, http.StatusNotFound, response.StatusCode)
+			require.Equal(t2, "no clipboard item was found", responseBody["message"])
+		})
+
+		t1.Run("6. cannot access clipboard items of another user", func(t2 *testing.T) {
+			clipboardItemId := createClipboardItem(t2, testServer, headers, "private")
+
+			anotherSessionCookie, _ := test.CreateAndLoginUser(t2, testServer)
+
+			response, _ := test.SendGetRequest(
+				t2,
+				testServer,
+				"/api/v1/clipboard/items/"+clipboardItemId,
+				map[string]string{
+					"Cookie": anotherSessionCookie,
+				},
+			)
+
+			require.Equal(t2, http.StatusNotFound, response.StatusCode)
+		})
+
+		t1.Run("7. returns 401 when jwt is missing", func(t2 *testing.T) {
+			response, _ := test.SendGetRequest(
+				t2,
+				testServer,
+				"/api/v1/clipboard/items?offset=0&limit=10",
+				nil,
+			)
+
+			require.Equal(t2, http.StatusUnauthorized, response.StatusCode)
+		})
+	})
+}
+
+func createClipboardItem(
+	t *testing.T,
+	testServer *httptest.Server,
+	headers map[string]string,
+	content string,
+) string {
+	response, responseBody := test.SendPostRequest(
+		t,
+		testServer,
+		"/api/v1/clipboard/items",
+		dto.CreateClipboardItemRequest{
+			Type:    model.ClipboardItemTypeText,
+			Content: content,
+		},
+		headers,
+	)
+
+	require.Equal(t, http.StatusCreated, response.StatusCode)
+
+	return responseBody["payload"].(map[string]any)["clipboardItemId"].(string)
+}
@@ -0,0 +1,180 @@
+package clipboard
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	data "github.com/cloudy-clip/api/test"
+	"github.com/cloudy-clip/api/test/debug"
+	test "github.com/cloudy-clip/api/test/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClipboardImages(t1 *testing.T) {
+	test.Integration(t1, func(testServer *httptest.Server) {
+		t1.Run("1. uploads an image and downloads it through its signed url", func(t2 *testing.T) {
+			sessionCookie, _, _ := test.StartPaidPlan(t2, testServer, data.LitePlanMonthlyOfferingId)
+			headers := map[string]string{
+				"Cookie": sessionCookie,
+			}
+			imageBytes := generatePng(t2)
+
+			response, responseBody := test.SendPostRequest(
+				t2,
+				testServer,
+				"/api/v1/clipboard/images",
+				imageBytes,
+				headers,
+			)
+
+			require.Equal(t2, http.StatusCreated, response.StatusCode)
+			require.Equal(t2, "IMAGE", test.GetValueFromMap(responseBody, "payload", "type"))
+
+			clipboardItemId := test.GetValueFromMap(responseBody, "payload", "clipboardItemId").(string)
+
+			response, responseBody = test.SendGetRequest(
+				t2,
+				testServer,
+				"/api/v1/clipboard/items/"+clipboardItemId+"/download-url",
+				headers,
+			)
+
+			require.Equal(t2, http.StatusOK, response.StatusCode)
+			require.NotEmpty(t2, test.GetValueFromMap(responseBody, "payload", "expiresAt"))
+
+			downloadUrl, err := url.Parse(test.GetValueFromMap(responseBody, "payload", "url").(string))
+			require.NoError(t2, err)
+
+			downloadResponse, err := http.Get(testServer.URL + downloadUrl.RequestURI())
+			require.NoError(t2, err)
+			defer downloadResponse.Body.Close()
+
+			downloadedBytes, err := io.ReadAll(downloadResponse.Body)
+			require.NoError(t2, err)
+			require.Equal(t2, http.StatusOK, downloadResponse.StatusCode)
+			require.Equal(t2, "image/png", downloadResponse.Header.Get("Content-Type"))
+			require.Equal(t2, imageBytes, downloadedBytes)
+
+			tamperedQuery := downloadUrl.Query()
+			tamperedQuery.Set("expires", "9999999999")
+			downloadUrl.RawQuery = tamperedQuery.Encode()
+
+			response, _ = test.SendGetRequest(t2, testServer, downloadUrl.RequestURI(), nil)
+
+			require.Equal(t2, http.StatusNotFound, response.StatusCode)
+		})
+
+		t1.Run("2. returns 415 when uploaded file is not an image", func(t2 *testing.T) {
+			sessionCookie, _, _ := test.StartPaidPlan(t2, testServer, data.LitePlanMonthlyOfferingId)
+
+			response, responseBody := test.SendPostRequest(
+				t2,
+				testServer,
+				"/api/v1/clipboard/images",
+				[]byte("definitely not an image"),
+				map[string]string{
+					"Cookie": sessionCookie,
+				},
+			)
+
+			require.Equal(t2, http.StatusUnsupportedMediaType, response.StatusCode)
+			require.Equal(t2, "text/plain; charset=utf-8", test.GetValueFromMap(responseBody, "payload", "extra", "contentType"))
+		})
+
+		t1.Run("3. returns 413 when image exceeds the upload size limit", func(t2 *testing.T) {
+			sessionCookie, _, _ := test.StartPaidPlan(t2, testServer, data.LitePlanMonthlyOfferingId)
+			imageBytes := append(generatePng(t2), make([]byte, 1048576)...)
+
+			response, responseBody := test.SendPostRequest(
+				t2,
+				testServer,
+				"/api/v1/clipboard/images",
+				imageBytes,
+				map[string]string{
+					"Cookie": sessionCookie,
+				},
+			)
+
+			require.Equal(t2, http.StatusRequestEntityTooLarge, response.StatusCode)
+			require.Subset(
+				t2,
+				responseBody["payload"],
+				debug.JsonParse(`
+					{
+						"extra": {
+							"maxSizeBytes": 1048576
+						}
+					}
+				`),
+			)
+		})
+
+		t1.Run("4. returns 403 when free user uploads an image", func(t2 *testing.T) {
+			sessionCookie := test.StartFreePlan(t2, testServer, data.FreePlanMonthlyOfferingId)
+
+			response, _ := test.SendPostRequest(
+				t2,
+				testServer,
+				"/api/v1/clipboard/images",
+				generatePng(t2),
+				map[string]string{
+					"Cookie": sessionCookie,
+				},
+			)
+
+			require.Equal(t2, http.StatusForbidden, response.StatusCode)
+		})
+
+		t1.Run("5. returns 404 when clipboard item has nothing to download", func(t2 *testing.T) {
+			sessionCookie, _ := test.CreateAndLoginUser(t2, testServer)
+			headers := map[string]string{
+				"Cookie": sessionCookie,
+			}
+
+			_, responseBody := test.SendPostRequest(
+				t2,
+				testServer,
+				"/api/v1/clipboard/items",
+				map[string]any{
+					"type":    "TEXT",
+					"content": "hello",
+				},
+				headers,
+			)
+			clipboardItemId := test.GetValueFromMap(responseBody, "payload", "clipboardItemId").(string)
+
+			response, _ := test.SendGetRequest(
+				t2,
+				testServer,
+				"/api/v1/clipboard/items/"+clipboardItemId+"/download-url",
+				headers,
+			)
+
+			require.Equal(t2, http.StatusNotFound, response.StatusCode)
+		})
+
+		t1.Run("6. returns 401 when jwt is missing", func(t2 *testing.T) {
+			response, _ := test.SendPostRequest(t2, testServer, "/api/v1/clipboard/images", generatePng(t2), nil)
+
+			require.Equal(t2, http.StatusUnauthorized, response.StatusCode)
+		})
+	})
+}
+
+func generatePng(t *testing.T) []byte {
+	generatedImage := image.NewRGBA(image.Rect(0, 0, 4, 4))
+	generatedImage.Set(1, 1, color.RGBA{R: 255, A: 255})
+
+	var imageBuffer bytes.Buffer
+	err := png.Encode(&imageBuffer, generatedImage)
+	require.NoError(t, err)
+
+	return imageBuffer.Bytes()
+}
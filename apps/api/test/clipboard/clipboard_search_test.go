@@ -0,0 +1,257 @@
+package clipboard
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cloudy-clip/api/internal/clipboard/dto"
+	"github.com/cloudy-clip/api/internal/clipboard/model"
+	data "github.com/cloudy-clip/api/test"
+	test "github.com/cloudy-clip/api/test/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClipboardSearch(t1 *testing.T) {
+	test.Integration(t1, func(testServer *httptest.Server) {
+		t1.Run("1. returns matching clipboard items with highlighted fragments", func(t2 *testing.T) {
+			sessionCookie, _ := test.CreateAndLoginUser(t2, testServer)
+			headers := map[string]string{
+				"Cookie": sessionCookie,
+			}
+
+			clipboardItemId := createClipboardItem(t2, testServer, headers, "the quick brown fox")
+			createClipboardItem(t2, testServer, headers, "lorem ipsum")
+
+			response, responseBody := test.SendGetRequest(
+				t2,
+				testServer,
+				"/api/v1/clipboard/items/search?q=fox&offset=0&limit=10",
+				headers,
+			)
+
+			require.Equal(t2, http.StatusOK, response.StatusCode)
+			require.Equal(t2, float64(1), test.GetValueFromMap(responseBody, "payload", "total"))
+
+			page := test.GetValueFromMap(responseBody, "payload", "page").([]any)
+
+			require.Len(t2, page, 1)
+			require.Equal(t2, clipboardItemId, test.GetValueFromMap(page[0], "clipboardItemId"))
+			require.Contains(t2, test.GetValueFromMap(page[0], "highlight"), "<mark>fox</mark>")
+		})
+
+		t1.Run("2. escapes the content of highlighted fragments", func(t2 *testing.T) {
+			sessionCookie, _ := test.CreateAndLoginUser(t2, testServer)
+			headers := map[string]string{
+				"Cookie": sessionCookie,
+			}
+
+			createClipboardItem(t2, testServer, headers, `<img src=x onerror="alert(1)"> fox & hound`)
+
+			response, responseBody := test.SendGetRequest(
+				t2,
+				testServer,
+				"/api/v1/clipboard/items/search?q=fox&offset=0&limit=10",
+				headers,
+			)
+
+			require.Equal(t2, http.StatusOK, response.StatusCode)
+
+			page := test.GetValueFromMap(responseBody, "payload", "page").([]any)
+			highlight := test.GetValueFromMap(page[0], "highlight").(string)
+
+			require.Contains(t2, highlight, "<mark>fox</mark>")
+			require.Contains(t2, highlight, "&lt;img")
+			require.Contains(t2, highlight, "&amp; hound")
+			require.NotContains(t2, highlight, "<img")
+		})
+
+		t1.Run("3. filters by type and pinned state", func(t2 *testing.T) {
+			sessionCookie, _ := test.CreateAndLoginUser(t2, testServer)
+			headers := map[string]string{
+				"Cookie": sessionCookie,
+			}
+
+			pinnedClipboardItemId := createClipboardItem(t2, testServer, headers, "pinned note")
+			createClipboardItem(t2, testServer, headers, "unpinned note")
+
+			response, _ := test.SendPatchRequest(
+				t2,
+				testServer,
+				"/api/v1/clipboard/items/"+pinnedClipboardItemId,
+				map[string]any{
+					"isPinned": true,
+				},
+				headers,
+			)
+
+			require.Equal(t2, http.StatusOK, response.StatusCode)
+
+			response, responseBody := test.SendPostRequest(
+				t2,
+				testServer,
+				"/api/v1/clipboard/items",
+				dto.CreateClipboardItemRequest{
+					Type:    model.ClipboardItemTypeUrl,
+					Content: "https://example.com/note",
+				},
+				headers,
+			)
+
+			require.Equal(t2, http.StatusCreated, response.StatusCode)
+
+			urlClipboardItemId := test.GetValueFromMap(responseBody, "payload", "clipboardItemId")
+
+			_, responseBody = test.SendGetRequest(
+				t2,
+				testServer,
+				"/api/v1/clipboard/items/search?type=URL&offset=0&limit=10",
+				headers,
+			)
+			page := test.GetValueFromMap(responseBody, "payload", "page").([]any)
+
+			require.Len(t2, page, 1)
+			require.Equal(t2, urlClipboardItemId, test.GetValueFromMap(page[0], "clipboardItemId"))
+			require.Nil(t2, test.GetValueFromMap(page[0], "highlight"))
+
+			_, responseBody = test.SendGetRequest(
+				t2,
+				testServer,
+				"/api/v1/clipboard/items/search?q=note&type=TEXT&isPinned=true&offset=0&limit=10",
+				headers,
+			)
+			page = test.GetValueFromMap(responseBody, "payload", "page").([]any)
+
+			require.Len(t2, page, 1)
+			require.Equal(t2, pinnedClipboardItemId, test.GetValueFromMap(page[0], "clipboardItemId"))
+		})
+
+		t1.Run("4. filters by creation date range", func(t2 *testing.T) {
+			sessionCookie, _ := test.CreateAndLoginUser(t2, testServer)
+			headers := map[string]string{
+				"Cookie": sessionCookie,
+			}
+
+			createClipboardItem(t2, testServer, headers, "created just now")
+
+			now := time.Now()
+			query := url.Values{
+				"createdFrom": {now.Add(-time.Hour).Format(time.RFC3339)},
+				"createdTo":   {now.Add(time.Hour).Format(time.RFC3339)},
+				"offset":      {"0"},
+				"limit":       {"10"},
+			}
+
+			_, responseBody := test.SendGetRequest(
+				t2,
+				testServer,
+				"/api/v1/clipboard/items/search?"+query.Encode(),
+				headers,
+			)
+
+			require.Equal(t2, float64(1), test.GetValueFromMap(responseBody, "payload", "total"))
+
+			query.Set("createdTo", now.Add(-time.Hour).Format(time.RFC3339))
+			query.Set("createdFrom", now.Add(-2*time.Hour).Format(time.RFC3339))
+
+			_, responseBody = test.SendGetRequest(
+				t2,
+				testServer,
+				"/api/v1/clipboard/items/search?"+query.Encode(),
+				headers,
+			)
+
+			require.Equal(t2, float64(0), test.GetValueFromMap(responseBody, "payload", "total"))
+		})
+
+		t1.Run("5. filters by source device", func(t2 *testing.T) {
+			sessionCookie, _ := test.CreateAndLoginUser(t2, testServer)
+			deviceSessionCookie, deviceId := test.RegisterDevice(t2, testServer, sessionCookie, "Work laptop")
+
+			createClipboardItem(t2, testServer, map[string]string{"Cookie": sessionCookie}, "from the browser")
+			clipboardItemId := createClipboardItem(
+				t2,
+				testServer,
+				map[string]string{"Cookie": deviceSessionCookie},
+				"from the laptop",
+			)
+
+			_, responseBody := test.SendGetRequest(
+				t2,
+				testServer,
+				"/api/v1/clipboard/items/search?offset=0&limit=10&deviceId="+deviceId,
+				map[string]string{
+					"Cookie": sessionCookie,
+				},
+			)
+			page := test.GetValueFromMap(responseBody, "payload", "page").([]any)
+
+			require.Len(t2, page, 1)
+			require.Equal(t2, clipboardItemId, test.GetValueFromMap(page[0], "clipboardItemId"))
+			require.Equal(t2, deviceId, test.GetValueFromMap(page[0], "deviceId"))
+		})
+
+		t1.Run("6. returns 400 when a query param is invalid", func(t2 *testing.T) {
+			sessionCookie, _ := test.CreateAndLoginUser(t2, testServer)
+			headers := map[string]string{
+				"Cookie": sessionCookie,
+			}
+
+			for _, query := range []string{
+				"type=VIDEO&offset=0&limit=10",
+				"isPinned=maybe&offset=0&limit=10",
+				"createdFrom=yesterday&offset=0&limit=10",
+				"q=" + strings.Repeat("a", 257) + "&offset=0&limit=10",
+				"q=fox",
+			} {
+				response, _ := test.SendGetRequest(t2, testServer, "/api/v1/clipboard/items/search?"+query, headers)
+
+				require.Equal(t2, http.StatusBadRequest, response.StatusCode, query)
+			}
+		})
+
+		t1.Run("7. returns 401 when jwt is missing", func(t2 *testing.T) {
+			response, _ := test.SendGetRequest(
+				t2,
+				testServer,
+				"/api/v1/clipboard/items/search?offset=0&limit=10",
+				nil,
+			)
+
+			require.Equal(t2, http.StatusUnauthorized, response.StatusCode)
+		})
+
+		t1.Run("8. indexes the beginning of content that is too large to index in full", func(t2 *testing.T) {
+			sessionCookie, _, _ := test.StartPaidPlan(t2, testServer, data.EssentialPlanMonthlyOfferingId)
+			headers := map[string]string{
+				"Cookie": sessionCookie,
+			}
+
+			var content strings.Builder
+			content.WriteString("fox")
+			for i := 0; content.Len() < 3*1024*1024; i++ {
+				content.WriteString(" word" + strconv.Itoa(i))
+			}
+
+			clipboardItemId := createClipboardItem(t2, testServer, headers, content.String())
+
+			response, responseBody := test.SendGetRequest(
+				t2,
+				testServer,
+				"/api/v1/clipboard/items/search?q=fox&offset=0&limit=10",
+				headers,
+			)
+
+			require.Equal(t2, http.StatusOK, response.StatusCode)
+
+			page := test.GetValueFromMap(responseBody, "payload", "page").([]any)
+
+			require.Len(t2, page, 1)
+			require.Equal(t2, clipboardItemId, test.GetValueFromMap(page[0], "clipboardItemId"))
+		})
+	})
+}
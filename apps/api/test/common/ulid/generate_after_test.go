@@ -0,0 +1,50 @@
+package ulid
+
+import (
+	"testing"
+
+	"github.com/cloudy-clip/api/internal/common/ulid"
+	test "github.com/cloudy-clip/api/test/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateAfter(t *testing.T) {
+	test.Test(t, func() {
+		t.Run("1. returns a new ULID when there is no previous ULID", func(t2 *testing.T) {
+			nextId, err := ulid.GenerateAfter("")
+
+			require.NoError(t2, err)
+			require.Len(t2, nextId, 26)
+		})
+
+		t.Run("2. returns a ULID that sorts after a previous ULID from the future", func(t2 *testing.T) {
+			previousId := "7ZZZZZZZZZ0000000000000000"
+
+			nextId, err := ulid.GenerateAfter(previousId)
+
+			require.NoError(t2, err)
+			require.Equal(t2, "7ZZZZZZZZZ0000000000000001", nextId)
+		})
+
+		t.Run("3. carries over when incrementing entropy", func(t2 *testing.T) {
+			previousId := "7ZZZZZZZZZ000000000000007Z"
+
+			nextId, err := ulid.GenerateAfter(previousId)
+
+			require.NoError(t2, err)
+			require.Equal(t2, "7ZZZZZZZZZ0000000000000080", nextId)
+		})
+
+		t.Run("4. returns error when entropy overflows", func(t2 *testing.T) {
+			_, err := ulid.GenerateAfter("7ZZZZZZZZZZZZZZZZZZZZZZZZZ")
+
+			require.Error(t2, err)
+		})
+
+		t.Run("5. returns error when previous ULID is malformed", func(t2 *testing.T) {
+			_, err := ulid.GenerateAfter("not-a-ulid")
+
+			require.Error(t2, err)
+		})
+	})
+}
@@ -0,0 +1,169 @@
+package device
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cloudy-clip/api/test/debug"
+	test "github.com/cloudy-clip/api/test/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDevices(t1 *testing.T) {
+	test.Integration(t1, func(testServer *httptest.Server) {
+		t1.Run("1. registers device and lists it as the current device", func(t2 *testing.T) {
+			sessionCookie, _ := test.CreateAndLoginUser(t2, testServer)
+			deviceSessionCookie, deviceId := test.RegisterDevice(t2, testServer, sessionCookie, "Work laptop")
+
+			response, responseBody := test.SendGetRequest(
+				t2,
+				testServer,
+				"/api/v1/users/me/devices",
+				map[string]string{
+					"Cookie": deviceSessionCookie,
+				},
+			)
+
+			require.Equal(t2, http.StatusOK, response.StatusCode)
+
+			devices := responseBody["payload"].([]any)
+
+			require.Len(t2, devices, 1)
+			require.Subset(
+				t2,
+				devices[0],
+				map[string]any{
+					"deviceId":        deviceId,
+					"name":            "Work laptop",
+					"platform":        "MACOS",
+					"appVersion":      "1.0.0",
+					"isCurrentDevice": true,
+					"revokedAt":       nil,
+				},
+			)
+		})
+
+		t1.Run("2. renames device", func(t2 *testing.T) {
+			sessionCookie, _ := test.CreateAndLoginUser(t2, testServer)
+			_, deviceId := test.RegisterDevice(t2, testServer, sessionCookie, "MacBook Pro")
+
+			response, responseBody := test.SendPatchRequest(
+				t2,
+				testServer,
+				"/api/v1/users/me/devices/"+deviceId,
+				map[string]any{
+					"name": "Home laptop",
+				},
+				map[string]string{
+					"Cookie": sessionCookie,
+				},
+			)
+
+			require.Equal(t2, http.StatusOK, response.StatusCode)
+			require.Equal(t2, "Home laptop", test.GetValueFromMap(responseBody, "payload", "name"))
+			require.Equal(t2, false, test.GetValueFromMap(responseBody, "payload", "isCurrentDevice"))
+		})
+
+		t1.Run("3. rejects credentials of revoked device but not of other devices", func(t2 *testing.T) {
+			sessionCookie, _ := test.CreateAndLoginUser(t2, testServer)
+			lostDeviceSessionCookie, lostDeviceId := test.RegisterDevice(t2, testServer, sessionCookie, "Lost laptop")
+			otherDeviceSessionCookie, _ := test.RegisterDevice(t2, testServer, sessionCookie, "Desktop")
+
+			response, _ := test.SendDeleteRequest(
+				t2,
+				testServer,
+				"/api/v1/users/me/devices/"+lostDeviceId,
+				map[string]string{
+					"Cookie": otherDeviceSessionCookie,
+				},
+			)
+
+			require.Equal(t2, http.StatusNoContent, response.StatusCode)
+
+			response, _ = test.SendGetRequest(
+				t2,
+				testServer,
+				"/api/v1/clipboard/changes",
+				map[string]string{
+					"Cookie": lostDeviceSessionCookie,
+				},
+			)
+
+			require.Equal(t2, http.StatusUnauthorized, response.StatusCode)
+
+			response, _ = test.SendGetRequest(
+				t2,
+				testServer,
+				"/api/v1/users/me/sessions/my",
+				map[string]string{
+					"Cookie": lostDeviceSessionCookie,
+				},
+			)
+
+			require.Equal(t2, http.StatusNotFound, response.StatusCode)
+
+			response, responseBody := test.SendGetRequest(
+				t2,
+				testServer,
+				"/api/v1/users/me/devices",
+				map[string]string{
+					"Cookie": otherDeviceSessionCookie,
+				},
+			)
+
+			require.Equal(t2, http.StatusOK, response.StatusCode)
+
+			for _, device := range responseBody["payload"].([]any) {
+				if test.GetValueFromMap(device, "deviceId") == lostDeviceId {
+					require.NotNil(t2, test.GetValueFromMap(device, "revokedAt"))
+				} else {
+					require.Nil(t2, test.GetValueFromMap(device, "revokedAt"))
+				}
+			}
+		})
+
+		t1.Run("4. returns 404 when device belongs to another user", func(t2 *testing.T) {
+			sessionCookie, _ := test.CreateAndLoginUser(t2, testServer)
+			anotherSessionCookie, _ := test.CreateAndLoginUser(t2, testServer)
+			_, deviceId := test.RegisterDevice(t2, testServer, anotherSessionCookie, "Not mine")
+
+			response, responseBody := test.SendDeleteRequest(
+				t2,
+				testServer,
+				"/api/v1/users/me/devices/"+deviceId,
+				map[string]string{
+					"Cookie": sessionCookie,
+				},
+			)
+
+			require.Equal(t2, http.StatusNotFound, response.StatusCode, debug.JsonStringify(responseBody))
+		})
+
+		t1.Run("5. returns 400 when platform is unknown", func(t2 *testing.T) {
+			sessionCookie, _ := test.CreateAndLoginUser(t2, testServer)
+
+			response, _ := test.SendPostRequest(
+				t2,
+				testServer,
+				"/api/v1/users/me/devices",
+				map[string]any{
+					"name":       "Phone",
+					"platform":   "ANDROID",
+					"appVersion": "1.0.0",
+				},
+				map[string]string{
+					"Cookie": sessionCookie,
+				},
+			)
+
+			require.Equal(t2, http.StatusBadRequest, response.StatusCode)
+		})
+
+		t1.Run("6. returns 401 when jwt is missing", func(t2 *testing.T) {
+			response, _ := test.SendGetRequest(t2, testServer, "/api/v1/users/me/devices", nil)
+
+			require.Equal(t2, http.StatusUnauthorized, response.StatusCode)
+		})
+	})
+}
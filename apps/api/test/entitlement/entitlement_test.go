@@ -0,0 +1,220 @@
+package entitlement
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cloudy-clip/api/internal/clipboard/dto"
+	"github.com/cloudy-clip/api/internal/clipboard/model"
+	"github.com/cloudy-clip/api/internal/common/database"
+	"github.com/cloudy-clip/api/internal/common/database/.jet/table"
+	"github.com/cloudy-clip/api/internal/subscription"
+	data "github.com/cloudy-clip/api/test"
+	"github.com/cloudy-clip/api/test/debug"
+	test "github.com/cloudy-clip/api/test/utils"
+	jet "github.com/go-jet/jet/v2/postgres"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEntitlementEnforcement(t1 *testing.T) {
+	test.Integration(t1, func(testServer *httptest.Server) {
+		t1.Run("1. returns 403 when free user exceeds word count limit", func(t2 *testing.T) {
+			sessionCookie := test.StartFreePlan(t2, testServer, data.FreePlanMonthlyOfferingId)
+
+			response, responseBody := test.SendPostRequest(
+				t2,
+				testServer,
+				"/api/v1/clipboard/items",
+				dto.CreateClipboardItemRequest{
+					Type:    model.ClipboardItemTypeText,
+					Content: generateWords(251),
+				},
+				map[string]string{
+					"Cookie": sessionCookie,
+				},
+			)
+
+			require.Equal(t2, http.StatusForbidden, response.StatusCode)
+			require.Subset(
+				t2,
+				responseBody["payload"],
+				debug.JsonParse(`
+					{
+						"code": "EntitlementLimitExceededException",
+						"extra": {
+							"entitlementType": "WORD_COUNT",
+							"limit": 250,
+							"currentUsage": 0
+						}
+					}
+				`),
+			)
+		})
+
+		t1.Run("2. does not include previously stored words in the word count limit", func(t2 *testing.T) {
+			sessionCookie := test.StartFreePlan(t2, testServer, data.FreePlanMonthlyOfferingId)
+			headers := map[string]string{
+				"Cookie": sessionCookie,
+			}
+
+			for _, wordCount := range []int{200, 250} {
+				response, _ := test.SendPostRequest(
+					t2,
+					testServer,
+					"/api/v1/clipboard/items",
+					dto.CreateClipboardItemRequest{
+						Type:    model.ClipboardItemTypeText,
+						Content: generateWords(wordCount),
+					},
+					headers,
+				)
+
+				require.Equal(t2, http.StatusCreated, response.StatusCode)
+			}
+		})
+
+		t1.Run("3. returns 403 when free user uploads an image", func(t2 *testing.T) {
+			sessionCookie := test.StartFreePlan(t2, testServer, data.FreePlanMonthlyOfferingId)
+
+			response, responseBody := test.SendPostRequest(
+				t2,
+				testServer,
+				"/api/v1/clipboard/items",
+				dto.CreateClipboardItemRequest{
+					Type:    model.ClipboardItemTypeImage,
+					Content: "aW1hZ2U=",
+				},
+				map[string]string{
+					"Cookie": sessionCookie,
+				},
+			)
+
+			require.Equal(t2, http.StatusForbidden, response.StatusCode)
+			require.Subset(
+				t2,
+				responseBody["payload"],
+				debug.JsonParse(`
+					{
+						"code": "EntitlementLimitExceededException",
+						"extra": {
+							"entitlementType": "IMAGE_UPLOAD",
+							"limit": 0,
+							"currentUsage": 0
+						}
+					}
+				`),
+			)
+		})
+
+		t1.Run("4. paid user is not restricted by word count", func(t2 *testing.T) {
+			sessionCookie, _, _ := test.StartPaidPlan(t2, testServer, data.LitePlanMonthlyOfferingId)
+
+			response, _ := test.SendPostRequest(
+				t2,
+				testServer,
+				"/api/v1/clipboard/items",
+				dto.CreateClipboardItemRequest{
+					Type:    model.ClipboardItemTypeText,
+					Content: generateWords(300),
+				},
+				map[string]string{
+					"Cookie": sessionCookie,
+				},
+			)
+
+			require.Equal(t2, http.StatusCreated, response.StatusCode)
+		})
+
+		t1.Run("5. accepts concurrent items that are each within the word count limit", func(t2 *testing.T) {
+			sessionCookie := test.StartFreePlan(t2, testServer, data.FreePlanMonthlyOfferingId)
+			headers := map[string]string{
+				"Cookie": sessionCookie,
+			}
+
+			statusCodes := make(chan int, 5)
+			var waitGroup sync.WaitGroup
+
+			for range cap(statusCodes) {
+				waitGroup.Add(1)
+
+				go func() {
+					defer waitGroup.Done()
+
+					response, _ := test.SendPostRequest(
+						t2,
+						testServer,
+						"/api/v1/clipboard/items",
+						dto.CreateClipboardItemRequest{
+							Type:    model.ClipboardItemTypeText,
+							Content: generateWords(200),
+						},
+						headers,
+					)
+
+					statusCodes <- response.StatusCode
+				}()
+			}
+
+			waitGroup.Wait()
+			close(statusCodes)
+
+			for statusCode := range statusCodes {
+				require.Equal(t2, http.StatusCreated, statusCode)
+			}
+		})
+
+		t1.Run("6. keeps paid limits of a canceled subscription until its grace period ends", func(t2 *testing.T) {
+			sessionCookie, testUser, _ := test.StartPaidPlan(t2, testServer, data.LitePlanMonthlyOfferingId)
+			headers := map[string]string{
+				"Cookie": sessionCookie,
+			}
+
+			test.CancelPaidPlan(t2, testServer, sessionCookie)
+
+			response, _ := test.SendPostRequest(
+				t2,
+				testServer,
+				"/api/v1/clipboard/items",
+				dto.CreateClipboardItemRequest{
+					Type:    model.ClipboardItemTypeText,
+					Content: generateWords(300),
+				},
+				headers,
+			)
+
+			require.Equal(t2, http.StatusCreated, response.StatusCode)
+
+			err := database.Exec(
+				context.Background(),
+				table.SubscriptionTable.
+					UPDATE(table.SubscriptionTable.CanceledAt).
+					SET(time.Now().AddDate(0, 0, -subscription.CanceledSubscriptionGracePeriodDays-1)).
+					WHERE(table.SubscriptionTable.UserID.EQ(jet.String(testUser.UserId))),
+			)
+
+			require.NoError(t2, err)
+
+			response, _ = test.SendPostRequest(
+				t2,
+				testServer,
+				"/api/v1/clipboard/items",
+				dto.CreateClipboardItemRequest{
+					Type:    model.ClipboardItemTypeText,
+					Content: generateWords(300),
+				},
+				headers,
+			)
+
+			require.Equal(t2, http.StatusForbidden, response.StatusCode)
+		})
+	})
+}
+
+func generateWords(count int) string {
+	return strings.TrimSpace(strings.Repeat("word ", count))
+}
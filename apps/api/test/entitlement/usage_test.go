@@ -0,0 +1,155 @@
+package entitlement
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cloudy-clip/api/internal/clipboard/dto"
+	"github.com/cloudy-clip/api/internal/clipboard/model"
+	data "github.com/cloudy-clip/api/test"
+	"github.com/cloudy-clip/api/test/debug"
+	test "github.com/cloudy-clip/api/test/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserUsage(t1 *testing.T) {
+	test.Integration(t1, func(testServer *httptest.Server) {
+		t1.Run("1. returns every entitlement of the plan with no usage", func(t2 *testing.T) {
+			sessionCookie := test.StartFreePlan(t2, testServer, data.FreePlanMonthlyOfferingId)
+
+			response, responseBody := test.SendGetRequest(
+				t2,
+				testServer,
+				"/api/v1/users/me/usage",
+				map[string]string{
+					"Cookie": sessionCookie,
+				},
+			)
+
+			require.Equal(t2, http.StatusOK, response.StatusCode)
+			require.Equal(
+				t2,
+				debug.JsonParse(`
+					{
+						"planName": "Free",
+						"entitlements": [
+							{
+								"type": "WORD_COUNT",
+								"limit": 250,
+								"isRestricted": true,
+								"currentUsage": 0
+							},
+							{
+								"type": "IMAGE_UPLOAD",
+								"limit": 0,
+								"isRestricted": true,
+								"currentUsage": 0
+							},
+							{
+								"type": "RETENTION_PERIOD",
+								"limit": 30,
+								"isRestricted": true,
+								"currentUsage": 0
+							}
+						]
+					}
+				`),
+				responseBody["payload"],
+			)
+		})
+
+		t1.Run("2. keeps word count up to date as items are created and deleted", func(t2 *testing.T) {
+			sessionCookie := test.StartFreePlan(t2, testServer, data.FreePlanMonthlyOfferingId)
+			headers := map[string]string{
+				"Cookie": sessionCookie,
+			}
+
+			createTextClipboardItem(t2, testServer, headers, generateWords(10))
+			deletedClipboardItemId := createTextClipboardItem(t2, testServer, headers, generateWords(20))
+
+			require.EqualValues(t2, 30, getCurrentUsage(t2, testServer, headers, "WORD_COUNT"))
+
+			response, _ := test.SendDeleteRequest(
+				t2,
+				testServer,
+				"/api/v1/clipboard/items/"+deletedClipboardItemId,
+				headers,
+			)
+
+			require.Equal(t2, http.StatusNoContent, response.StatusCode)
+			require.EqualValues(t2, 10, getCurrentUsage(t2, testServer, headers, "WORD_COUNT"))
+		})
+
+		t1.Run("3. counts stored images", func(t2 *testing.T) {
+			sessionCookie, _, _ := test.StartPaidPlan(t2, testServer, data.LitePlanMonthlyOfferingId)
+			headers := map[string]string{
+				"Cookie": sessionCookie,
+			}
+
+			response, _ := test.SendPostRequest(
+				t2,
+				testServer,
+				"/api/v1/clipboard/items",
+				dto.CreateClipboardItemRequest{
+					Type:    model.ClipboardItemTypeImage,
+					Content: "aW1hZ2U=",
+				},
+				headers,
+			)
+
+			require.Equal(t2, http.StatusCreated, response.StatusCode)
+			require.EqualValues(t2, 1, getCurrentUsage(t2, testServer, headers, "IMAGE_UPLOAD"))
+			require.EqualValues(t2, 0, getCurrentUsage(t2, testServer, headers, "WORD_COUNT"))
+		})
+
+		t1.Run("4. returns 401 when jwt is missing", func(t2 *testing.T) {
+			response, _ := test.SendGetRequest(t2, testServer, "/api/v1/users/me/usage", nil)
+
+			require.Equal(t2, http.StatusUnauthorized, response.StatusCode)
+		})
+	})
+}
+
+func createTextClipboardItem(
+	t *testing.T,
+	testServer *httptest.Server,
+	headers map[string]string,
+	content string,
+) string {
+	response, responseBody := test.SendPostRequest(
+		t,
+		testServer,
+		"/api/v1/clipboard/items",
+		dto.CreateClipboardItemRequest{
+			Type:    model.ClipboardItemTypeText,
+			Content: content,
+		},
+		headers,
+	)
+
+	require.Equal(t, http.StatusCreated, response.StatusCode)
+
+	return test.GetValueFromMap(responseBody, "payload", "clipboardItemId").(string)
+}
+
+func getCurrentUsage(
+	t *testing.T,
+	testServer *httptest.Server,
+	headers map[string]string,
+	entitlementType string,
+) any {
+	response, responseBody := test.SendGetRequest(t, testServer, "/api/v1/users/me/usage", headers)
+
+	require.Equal(t, http.StatusOK, response.StatusCode)
+
+	for _, entitlementUsage := range test.GetValueFromMap(responseBody, "payload", "entitlements").([]any) {
+		if test.GetValueFromMap(entitlementUsage, "type") == entitlementType {
+			return test.GetValueFromMap(entitlementUsage, "currentUsage")
+		}
+	}
+
+	t.Fatalf("usage of entitlement type '%s' was not found", entitlementType)
+
+	return nil
+}
@@ -0,0 +1,186 @@
+package event
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	test "github.com/cloudy-clip/api/test/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClipboardChanges(t1 *testing.T) {
+	test.Integration(t1, func(testServer *httptest.Server) {
+		t1.Run("1. returns changes in order along with the next cursor", func(t2 *testing.T) {
+			sessionCookie, _ := test.CreateAndLoginUser(t2, testServer)
+			headers := map[string]string{
+				"Cookie": sessionCookie,
+			}
+
+			firstClipboardItemId := createClipboardItem(t2, testServer, headers, "first")
+			secondClipboardItemId := createClipboardItem(t2, testServer, headers, "second")
+
+			response, responseBody := test.SendGetRequest(t2, testServer, "/api/v1/clipboard/changes", headers)
+
+			require.Equal(t2, http.StatusOK, response.StatusCode)
+
+			changes := test.GetValueFromMap(responseBody, "payload", "changes").([]any)
+
+			require.Len(t2, changes, 2)
+			require.Equal(t2, firstClipboardItemId, test.GetValueFromMap(changes[0], "clipboardItemId"))
+			require.Equal(t2, "UPSERT", test.GetValueFromMap(changes[0], "type"))
+			require.Equal(t2, secondClipboardItemId, test.GetValueFromMap(changes[1], "clipboardItemId"))
+			require.Equal(
+				t2,
+				test.GetValueFromMap(changes[1], "version"),
+				test.GetValueFromMap(responseBody, "payload", "nextCursor"),
+			)
+			require.Equal(t2, false, test.GetValueFromMap(responseBody, "payload", "hasMore"))
+		})
+
+		t1.Run("2. returns only changes after the cursor", func(t2 *testing.T) {
+			sessionCookie, _ := test.CreateAndLoginUser(t2, testServer)
+			headers := map[string]string{
+				"Cookie": sessionCookie,
+			}
+
+			createClipboardItem(t2, testServer, headers, "already synced")
+
+			_, responseBody := test.SendGetRequest(t2, testServer, "/api/v1/clipboard/changes", headers)
+			cursor := test.GetValueFromMap(responseBody, "payload", "nextCursor").(string)
+
+			clipboardItemId := createClipboardItem(t2, testServer, headers, "not synced yet")
+
+			response, responseBody := test.SendGetRequest(
+				t2,
+				testServer,
+				"/api/v1/clipboard/changes?since="+cursor,
+				headers,
+			)
+
+			require.Equal(t2, http.StatusOK, response.StatusCode)
+
+			changes := test.GetValueFromMap(responseBody, "payload", "changes").([]any)
+
+			require.Len(t2, changes, 1)
+			require.Equal(t2, clipboardItemId, test.GetValueFromMap(changes[0], "clipboardItemId"))
+			require.Greater(t2, test.GetValueFromMap(changes[0], "version").(string), cursor)
+
+			response, responseBody = test.SendGetRequest(
+				t2,
+				testServer,
+				"/api/v1/clipboard/changes?since="+test.GetValueFromMap(responseBody, "payload", "nextCursor").(string),
+				headers,
+			)
+
+			require.Equal(t2, http.StatusOK, response.StatusCode)
+			require.Empty(t2, test.GetValueFromMap(responseBody, "payload", "changes"))
+		})
+
+		t1.Run("3. collapses updates and deletions into the latest change of every item", func(t2 *testing.T) {
+			sessionCookie, _ := test.CreateAndLoginUser(t2, testServer)
+			headers := map[string]string{
+				"Cookie": sessionCookie,
+			}
+
+			pinnedClipboardItemId := createClipboardItem(t2, testServer, headers, "pinned")
+			deletedClipboardItemId := createClipboardItem(t2, testServer, headers, "deleted")
+
+			response, responseBody := test.SendPatchRequest(
+				t2,
+				testServer,
+				"/api/v1/clipboard/items/"+pinnedClipboardItemId,
+				map[string]any{
+					"isPinned": true,
+				},
+				headers,
+			)
+
+			require.Equal(t2, http.StatusOK, response.StatusCode)
+
+			pinnedVersion := test.GetValueFromMap(responseBody, "payload", "version")
+
+			response, _ = test.SendDeleteRequest(
+				t2,
+				testServer,
+				"/api/v1/clipboard/items/"+deletedClipboardItemId,
+				headers,
+			)
+
+			require.Equal(t2, http.StatusNoContent, response.StatusCode)
+
+			_, responseBody = test.SendGetRequest(t2, testServer, "/api/v1/clipboard/changes", headers)
+			changes := test.GetValueFromMap(responseBody, "payload", "changes").([]any)
+
+			require.Len(t2, changes, 2)
+			require.Subset(
+				t2,
+				changes[0],
+				map[string]any{
+					"version":         pinnedVersion,
+					"type":            "UPSERT",
+					"clipboardItemId": pinnedClipboardItemId,
+				},
+			)
+			require.Equal(t2, true, test.GetValueFromMap(changes[0], "clipboardItem", "isPinned"))
+			require.Subset(
+				t2,
+				changes[1],
+				map[string]any{
+					"type":            "TOMBSTONE",
+					"clipboardItemId": deletedClipboardItemId,
+					"clipboardItem":   nil,
+				},
+			)
+		})
+
+		t1.Run("4. pages through changes using the limit", func(t2 *testing.T) {
+			sessionCookie, _ := test.CreateAndLoginUser(t2, testServer)
+			headers := map[string]string{
+				"Cookie": sessionCookie,
+			}
+
+			createClipboardItem(t2, testServer, headers, "first")
+			lastClipboardItemId := createClipboardItem(t2, testServer, headers, "second")
+
+			_, responseBody := test.SendGetRequest(t2, testServer, "/api/v1/clipboard/changes?limit=1", headers)
+
+			require.Len(t2, test.GetValueFromMap(responseBody, "payload", "changes"), 1)
+			require.Equal(t2, true, test.GetValueFromMap(responseBody, "payload", "hasMore"))
+
+			_, responseBody = test.SendGetRequest(
+				t2,
+				testServer,
+				"/api/v1/clipboard/changes?limit=1&since="+
+					test.GetValueFromMap(responseBody, "payload", "nextCursor").(string),
+				headers,
+			)
+			changes := test.GetValueFromMap(responseBody, "payload", "changes").([]any)
+
+			require.Len(t2, changes, 1)
+			require.Equal(t2, lastClipboardItemId, test.GetValueFromMap(changes[0], "clipboardItemId"))
+			require.Equal(t2, false, test.GetValueFromMap(responseBody, "payload", "hasMore"))
+		})
+
+		t1.Run("5. returns 400 when since or limit is invalid", func(t2 *testing.T) {
+			sessionCookie, _ := test.CreateAndLoginUser(t2, testServer)
+			headers := map[string]string{
+				"Cookie": sessionCookie,
+			}
+
+			response, _ := test.SendGetRequest(t2, testServer, "/api/v1/clipboard/changes?since=yesterday", headers)
+
+			require.Equal(t2, http.StatusBadRequest, response.StatusCode)
+
+			response, _ = test.SendGetRequest(t2, testServer, "/api/v1/clipboard/changes?limit=501", headers)
+
+			require.Equal(t2, http.StatusBadRequest, response.StatusCode)
+		})
+
+		t1.Run("6. returns 401 when jwt is missing", func(t2 *testing.T) {
+			response, _ := test.SendGetRequest(t2, testServer, "/api/v1/clipboard/changes", nil)
+
+			require.Equal(t2, http.StatusUnauthorized, response.StatusCode)
+		})
+	})
+}
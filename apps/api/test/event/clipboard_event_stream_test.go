@@ -0,0 +1,166 @@
+package event
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/cloudy-clip/api/internal/clipboard/dto"
+	"github.com/cloudy-clip/api/internal/clipboard/model"
+	"github.com/cloudy-clip/api/test/debug"
+	test "github.com/cloudy-clip/api/test/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClipboardEventStream(t1 *testing.T) {
+	test.Integration(t1, func(testServer *httptest.Server) {
+		t1.Run("1. pushes clipboard item changes to connected devices", func(t2 *testing.T) {
+			sessionCookie, _ := test.CreateAndLoginUser(t2, testServer)
+			headers := map[string]string{
+				"Cookie": sessionCookie,
+			}
+
+			eventStream := test.OpenEventStream(t2, testServer, "/api/v1/clipboard/events", headers)
+			defer eventStream.Close()
+
+			require.Equal(t2, http.StatusOK, eventStream.Response.StatusCode)
+			require.Equal(t2, "text/event-stream", eventStream.Response.Header.Get("Content-Type"))
+
+			clipboardItemId := createClipboardItem(t2, testServer, headers, "Hello world")
+
+			event := eventStream.NextEvent(t2, 5*time.Second)
+
+			require.Equal(t2, "CREATED", event.Event)
+			require.NotEmpty(t2, event.Id)
+			require.Subset(
+				t2,
+				debug.JsonParse(event.Data),
+				map[string]any{
+					"eventId":         event.Id,
+					"type":            "CREATED",
+					"clipboardItemId": clipboardItemId,
+				},
+			)
+
+			response, _ := test.SendDeleteRequest(
+				t2,
+				testServer,
+				"/api/v1/clipboard/items/"+clipboardItemId,
+				headers,
+			)
+
+			require.Equal(t2, http.StatusNoContent, response.StatusCode)
+
+			event = eventStream.NextEvent(t2, 5*time.Second)
+
+			require.Equal(t2, "DELETED", event.Event)
+			require.Subset(
+				t2,
+				debug.JsonParse(event.Data),
+				map[string]any{
+					"type":            "DELETED",
+					"clipboardItemId": clipboardItemId,
+					"clipboardItem":   nil,
+				},
+			)
+		})
+
+		t1.Run("2. replays missed events when resuming from last event ID", func(t2 *testing.T) {
+			sessionCookie, _ := test.CreateAndLoginUser(t2, testServer)
+			headers := map[string]string{
+				"Cookie": sessionCookie,
+			}
+
+			eventStream := test.OpenEventStream(t2, testServer, "/api/v1/clipboard/events", headers)
+
+			createClipboardItem(t2, testServer, headers, "first")
+
+			lastSeenEvent := eventStream.NextEvent(t2, 5*time.Second)
+			eventStream.Close()
+
+			missedClipboardItemId := createClipboardItem(t2, testServer, headers, "second")
+
+			eventStream = test.OpenEventStream(
+				t2,
+				testServer,
+				"/api/v1/clipboard/events",
+				map[string]string{
+					"Cookie":        sessionCookie,
+					"Last-Event-ID": lastSeenEvent.Id,
+				},
+			)
+			defer eventStream.Close()
+
+			event := eventStream.NextEvent(t2, 5*time.Second)
+
+			require.Equal(t2, "CREATED", event.Event)
+			require.Greater(t2, event.Id, lastSeenEvent.Id)
+			require.Equal(t2, missedClipboardItemId, test.GetValueFromMap(debug.JsonParse(event.Data), "clipboardItemId"))
+		})
+
+		t1.Run("3. does not push events of other users", func(t2 *testing.T) {
+			sessionCookie, _ := test.CreateAndLoginUser(t2, testServer)
+			anotherSessionCookie, _ := test.CreateAndLoginUser(t2, testServer)
+
+			eventStream := test.OpenEventStream(
+				t2,
+				testServer,
+				"/api/v1/clipboard/events",
+				map[string]string{
+					"Cookie": sessionCookie,
+				},
+			)
+			defer eventStream.Close()
+
+			createClipboardItem(
+				t2,
+				testServer,
+				map[string]string{
+					"Cookie": anotherSessionCookie,
+				},
+				"private",
+			)
+			ownClipboardItemId := createClipboardItem(
+				t2,
+				testServer,
+				map[string]string{
+					"Cookie": sessionCookie,
+				},
+				"mine",
+			)
+
+			event := eventStream.NextEvent(t2, 5*time.Second)
+
+			require.Equal(t2, ownClipboardItemId, test.GetValueFromMap(debug.JsonParse(event.Data), "clipboardItemId"))
+		})
+
+		t1.Run("4. returns 401 when jwt is missing", func(t2 *testing.T) {
+			response, _ := test.SendGetRequest(t2, testServer, "/api/v1/clipboard/events", nil)
+
+			require.Equal(t2, http.StatusUnauthorized, response.StatusCode)
+		})
+	})
+}
+
+func createClipboardItem(
+	t *testing.T,
+	testServer *httptest.Server,
+	headers map[string]string,
+	content string,
+) string {
+	response, responseBody := test.SendPostRequest(
+		t,
+		testServer,
+		"/api/v1/clipboard/items",
+		dto.CreateClipboardItemRequest{
+			Type:    model.ClipboardItemTypeText,
+			Content: content,
+		},
+		headers,
+	)
+
+	require.Equal(t, http.StatusCreated, response.StatusCode)
+
+	return test.GetValueFromMap(responseBody, "payload", "clipboardItemId").(string)
+}
@@ -0,0 +1,44 @@
+package orchestrator
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/cloudy-clip/api/internal/common/environment"
+	test "github.com/cloudy-clip/api/test/utils"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestTimeoutMiddleware(t1 *testing.T) {
+	test.Integration(t1, func(testServer *httptest.Server) {
+		readTimeout := time.Duration(environment.Config.ServerReadTimeout) * time.Second
+
+		// Waits for the request to time out, or answers normally once it is clear that it will not.
+		testServer.Config.Handler.(*chi.Mux).Get(
+			"/api/v1/slow",
+			func(responseWriter http.ResponseWriter, request *http.Request) {
+				select {
+				case <-request.Context().Done():
+				case <-time.After(2 * readTimeout):
+					responseWriter.WriteHeader(http.StatusOK)
+				}
+			},
+		)
+
+		t1.Run("1. times out requests that accept an event stream on other endpoints", func(t2 *testing.T) {
+			response, _ := test.SendGetRequest(
+				t2,
+				testServer,
+				"/api/v1/slow",
+				map[string]string{
+					"Accept": "text/event-stream",
+				},
+			)
+
+			require.Equal(t2, http.StatusGatewayTimeout, response.StatusCode)
+		})
+	})
+}
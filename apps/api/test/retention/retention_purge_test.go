@@ -0,0 +1,219 @@
+package retention
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/cloudy-clip/api/internal/clipboard/dto"
+	"github.com/cloudy-clip/api/internal/clipboard/model"
+	"github.com/cloudy-clip/api/internal/common/database"
+	"github.com/cloudy-clip/api/internal/common/database/.jet/table"
+	_logger "github.com/cloudy-clip/api/internal/common/logger"
+	"github.com/cloudy-clip/api/internal/retention"
+	"github.com/cloudy-clip/api/internal/subscription"
+	data "github.com/cloudy-clip/api/test"
+	test "github.com/cloudy-clip/api/test/utils"
+	jet "github.com/go-jet/jet/v2/postgres"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetentionPurge(t1 *testing.T) {
+	test.Integration(t1, func(testServer *httptest.Server) {
+		jobCtx := _logger.NewBackgroundContext("runRetentionPurgeJob")
+
+		t1.Run("1. purges unpinned items past free plan retention period", func(t2 *testing.T) {
+			sessionCookie := test.StartFreePlan(t2, testServer, data.FreePlanMonthlyOfferingId)
+			headers := map[string]string{
+				"Cookie": sessionCookie,
+			}
+
+			createClipboardItem(t2, testServer, headers, "purge me")
+			pinnedClipboardItemId := createClipboardItem(t2, testServer, headers, "keep me")
+
+			response, _ := test.SendPatchRequest(
+				t2,
+				testServer,
+				"/api/v1/clipboard/items/"+pinnedClipboardItemId,
+				map[string]any{
+					"isPinned": true,
+				},
+				headers,
+			)
+
+			require.Equal(t2, http.StatusOK, response.StatusCode)
+
+			err := retention.
+				GetRetentionService().
+				PurgeExpiredClipboardItems(jobCtx, time.Now().AddDate(0, 0, 31))
+
+			require.NoError(t2, err)
+
+			response, responseBody := test.SendGetRequest(
+				t2,
+				testServer,
+				"/api/v1/clipboard/items?offset=0&limit=10",
+				headers,
+			)
+
+			require.Equal(t2, http.StatusOK, response.StatusCode)
+
+			payload := responseBody["payload"].(map[string]any)
+			page := payload["page"].([]any)
+
+			require.EqualValues(t2, 1, payload["total"])
+			require.Equal(t2, pinnedClipboardItemId, page[0].(map[string]any)["clipboardItemId"])
+		})
+
+		t1.Run("2. keeps items that are still within retention period", func(t2 *testing.T) {
+			sessionCookie := test.StartFreePlan(t2, testServer, data.FreePlanMonthlyOfferingId)
+			headers := map[string]string{
+				"Cookie": sessionCookie,
+			}
+
+			createClipboardItem(t2, testServer, headers, "keep me")
+
+			err := retention.
+				GetRetentionService().
+				PurgeExpiredClipboardItems(jobCtx, time.Now().AddDate(0, 0, 29))
+
+			require.NoError(t2, err)
+
+			response, responseBody := test.SendGetRequest(
+				t2,
+				testServer,
+				"/api/v1/clipboard/items?offset=0&limit=10",
+				headers,
+			)
+
+			require.Equal(t2, http.StatusOK, response.StatusCode)
+			require.EqualValues(t2, 1, test.GetValueFromMap(responseBody, "payload", "total"))
+		})
+
+		t1.Run("3. does not purge items of plans with unlimited retention period", func(t2 *testing.T) {
+			sessionCookie, _, _ := test.StartPaidPlan(t2, testServer, data.EssentialPlanMonthlyOfferingId)
+			headers := map[string]string{
+				"Cookie": sessionCookie,
+			}
+
+			createClipboardItem(t2, testServer, headers, "keep me forever")
+
+			err := retention.
+				GetRetentionService().
+				PurgeExpiredClipboardItems(jobCtx, time.Now().AddDate(1, 0, 0))
+
+			require.NoError(t2, err)
+
+			response, responseBody := test.SendGetRequest(
+				t2,
+				testServer,
+				"/api/v1/clipboard/items?offset=0&limit=10",
+				headers,
+			)
+
+			require.Equal(t2, http.StatusOK, response.StatusCode)
+			require.EqualValues(t2, 1, test.GetValueFromMap(responseBody, "payload", "total"))
+		})
+
+		t1.Run("4. applies free plan retention period to items created after grace period ends", func(t2 *testing.T) {
+			sessionCookie, testUser, _ := test.StartPaidPlan(t2, testServer, data.EssentialPlanMonthlyOfferingId)
+			headers := map[string]string{
+				"Cookie": sessionCookie,
+			}
+
+			createClipboardItem(t2, testServer, headers, "purge me with the subscription")
+			test.CancelPaidPlan(t2, testServer, sessionCookie)
+
+			// Moves the cancellation, along with the item created before it, past the grace period.
+			canceledAt := time.Now().AddDate(0, 0, -subscription.CanceledSubscriptionGracePeriodDays-1)
+			backdateCanceledSubscription(t2, testUser.UserId, canceledAt)
+
+			keptClipboardItemId := createClipboardItem(t2, testServer, headers, "keep me")
+
+			for _, now := range []time.Time{time.Now(), time.Now().AddDate(0, 0, 29)} {
+				err := retention.
+					GetRetentionService().
+					PurgeExpiredClipboardItems(jobCtx, now)
+
+				require.NoError(t2, err)
+			}
+
+			response, responseBody := test.SendGetRequest(
+				t2,
+				testServer,
+				"/api/v1/clipboard/items?offset=0&limit=10",
+				headers,
+			)
+
+			require.Equal(t2, http.StatusOK, response.StatusCode)
+
+			payload := responseBody["payload"].(map[string]any)
+			page := payload["page"].([]any)
+
+			require.EqualValues(t2, 1, payload["total"])
+			require.Equal(t2, keptClipboardItemId, page[0].(map[string]any)["clipboardItemId"])
+
+			err := retention.
+				GetRetentionService().
+				PurgeExpiredClipboardItems(jobCtx, time.Now().AddDate(0, 0, 31))
+
+			require.NoError(t2, err)
+
+			response, responseBody = test.SendGetRequest(
+				t2,
+				testServer,
+				"/api/v1/clipboard/items?offset=0&limit=10",
+				headers,
+			)
+
+			require.Equal(t2, http.StatusOK, response.StatusCode)
+			require.EqualValues(t2, 0, test.GetValueFromMap(responseBody, "payload", "total"))
+		})
+	})
+}
+
+func backdateCanceledSubscription(t *testing.T, userId string, canceledAt time.Time) {
+	err := database.Exec(
+		context.Background(),
+		table.SubscriptionTable.
+			UPDATE(table.SubscriptionTable.CanceledAt).
+			SET(canceledAt).
+			WHERE(table.SubscriptionTable.UserID.EQ(jet.String(userId))),
+	)
+
+	require.NoError(t, err)
+
+	err = database.Exec(
+		context.Background(),
+		table.ClipboardItemTable.
+			UPDATE(table.ClipboardItemTable.CreatedAt).
+			SET(canceledAt.Add(-time.Hour)).
+			WHERE(table.ClipboardItemTable.UserID.EQ(jet.String(userId))),
+	)
+
+	require.NoError(t, err)
+}
+
+func createClipboardItem(
+	t *testing.T,
+	testServer *httptest.Server,
+	headers map[string]string,
+	content string,
+) string {
+	response, responseBody := test.SendPostRequest(
+		t,
+		testServer,
+		"/api/v1/clipboard/items",
+		dto.CreateClipboardItemRequest{
+			Type:    model.ClipboardItemTypeText,
+			Content: content,
+		},
+		headers,
+	)
+
+	require.Equal(t, http.StatusCreated, response.StatusCode)
+
+	return test.GetValueFromMap(responseBody, "payload", "clipboardItemId").(string)
+}
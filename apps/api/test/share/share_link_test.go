@@ -0,0 +1,303 @@
+package share
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cloudy-clip/api/internal/common/http/middleware/turnstile"
+	"github.com/cloudy-clip/api/test/debug"
+	test "github.com/cloudy-clip/api/test/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShareLinks(t1 *testing.T) {
+	test.Integration(t1, func(testServer *httptest.Server) {
+		t1.Run("1. resolves share link anonymously and records the view for the owner", func(t2 *testing.T) {
+			sessionCookie, _ := test.CreateAndLoginUser(t2, testServer)
+			headers := map[string]string{
+				"Cookie": sessionCookie,
+			}
+			clipboardItemId := createClipboardItem(t2, testServer, headers, "shared snippet")
+
+			response, responseBody := test.SendPostRequest(
+				t2,
+				testServer,
+				"/api/v1/share-links",
+				map[string]any{
+					"clipboardItemId":  clipboardItemId,
+					"expiresInSeconds": 3600,
+				},
+				headers,
+			)
+
+			require.Equal(t2, http.StatusCreated, response.StatusCode)
+			require.Subset(
+				t2,
+				responseBody["payload"],
+				map[string]any{
+					"clipboardItemId": clipboardItemId,
+					"hasPassword":     false,
+					"maxViewCount":    nil,
+					"viewCount":       float64(0),
+					"revokedAt":       nil,
+				},
+			)
+
+			shareLinkId := test.GetValueFromMap(responseBody, "payload", "shareLinkId").(string)
+			token := test.GetValueFromMap(responseBody, "payload", "token").(string)
+
+			response, responseBody = resolveShareLink(t2, testServer, token, "")
+
+			require.Equal(t2, http.StatusOK, response.StatusCode)
+			require.Subset(
+				t2,
+				responseBody["payload"],
+				map[string]any{
+					"type":        "TEXT",
+					"content":     "shared snippet",
+					"downloadUrl": nil,
+				},
+			)
+
+			_, responseBody = test.SendGetRequest(t2, testServer, "/api/v1/share-links", headers)
+			shareLinks := test.GetValueFromMap(responseBody, "payload").([]any)
+
+			require.Len(t2, shareLinks, 1)
+			require.EqualValues(t2, 1, test.GetValueFromMap(shareLinks[0], "viewCount"))
+			require.NotContains(t2, shareLinks[0], "token")
+
+			response, responseBody = test.SendGetRequest(
+				t2,
+				testServer,
+				"/api/v1/share-links/"+shareLinkId+"/views",
+				headers,
+			)
+
+			require.Equal(t2, http.StatusOK, response.StatusCode)
+
+			shareLinkViews := test.GetValueFromMap(responseBody, "payload").([]any)
+
+			require.Len(t2, shareLinkViews, 1)
+			require.Equal(t2, "Go-http-client/1.1", test.GetValueFromMap(shareLinkViews[0], "userAgent"))
+		})
+
+		t1.Run("2. stops resolving share link once its max view count is reached", func(t2 *testing.T) {
+			sessionCookie, _ := test.CreateAndLoginUser(t2, testServer)
+			headers := map[string]string{
+				"Cookie": sessionCookie,
+			}
+			clipboardItemId := createClipboardItem(t2, testServer, headers, "view me once")
+
+			_, responseBody := test.SendPostRequest(
+				t2,
+				testServer,
+				"/api/v1/share-links",
+				map[string]any{
+					"clipboardItemId":  clipboardItemId,
+					"expiresInSeconds": 3600,
+					"maxViewCount":     1,
+				},
+				headers,
+			)
+			token := test.GetValueFromMap(responseBody, "payload", "token").(string)
+
+			response, _ := resolveShareLink(t2, testServer, token, "")
+
+			require.Equal(t2, http.StatusOK, response.StatusCode)
+
+			response, _ = resolveShareLink(t2, testServer, token, "")
+
+			require.Equal(t2, http.StatusNotFound, response.StatusCode)
+		})
+
+		t1.Run("3. requires the password of password-protected share link", func(t2 *testing.T) {
+			sessionCookie, _ := test.CreateAndLoginUser(t2, testServer)
+			headers := map[string]string{
+				"Cookie": sessionCookie,
+			}
+			clipboardItemId := createClipboardItem(t2, testServer, headers, "secret")
+
+			_, responseBody := test.SendPostRequest(
+				t2,
+				testServer,
+				"/api/v1/share-links",
+				map[string]any{
+					"clipboardItemId":  clipboardItemId,
+					"expiresInSeconds": 3600,
+					"password":         "open sesame",
+				},
+				headers,
+			)
+
+			require.Equal(t2, true, test.GetValueFromMap(responseBody, "payload", "hasPassword"))
+
+			token := test.GetValueFromMap(responseBody, "payload", "token").(string)
+
+			response, responseBody := resolveShareLink(t2, testServer, token, "")
+
+			require.Equal(t2, http.StatusUnauthorized, response.StatusCode)
+			require.Subset(
+				t2,
+				responseBody["payload"],
+				debug.JsonParse(`
+					{
+						"extra": {
+							"isPasswordRequired": true
+						}
+					}
+				`),
+			)
+
+			response, _ = resolveShareLink(t2, testServer, token, "open sesame?")
+
+			require.Equal(t2, http.StatusUnauthorized, response.StatusCode)
+
+			response, responseBody = resolveShareLink(t2, testServer, token, "open sesame")
+
+			require.Equal(t2, http.StatusOK, response.StatusCode)
+			require.Equal(t2, "secret", test.GetValueFromMap(responseBody, "payload", "content"))
+
+			_, responseBody = test.SendGetRequest(t2, testServer, "/api/v1/share-links", headers)
+
+			require.EqualValues(t2, 1, test.GetValueFromMap(responseBody, "payload").([]any)[0].(map[string]any)["viewCount"])
+		})
+
+		t1.Run("4. stops resolving share link once it is revoked", func(t2 *testing.T) {
+			sessionCookie, _ := test.CreateAndLoginUser(t2, testServer)
+			headers := map[string]string{
+				"Cookie": sessionCookie,
+			}
+			clipboardItemId := createClipboardItem(t2, testServer, headers, "revoke me")
+
+			_, responseBody := test.SendPostRequest(
+				t2,
+				testServer,
+				"/api/v1/share-links",
+				map[string]any{
+					"clipboardItemId":  clipboardItemId,
+					"expiresInSeconds": 3600,
+				},
+				headers,
+			)
+			shareLinkId := test.GetValueFromMap(responseBody, "payload", "shareLinkId").(string)
+			token := test.GetValueFromMap(responseBody, "payload", "token").(string)
+
+			response, _ := test.SendDeleteRequest(t2, testServer, "/api/v1/share-links/"+shareLinkId, headers)
+
+			require.Equal(t2, http.StatusNoContent, response.StatusCode)
+
+			response, _ = resolveShareLink(t2, testServer, token, "")
+
+			require.Equal(t2, http.StatusNotFound, response.StatusCode)
+
+			_, responseBody = test.SendGetRequest(t2, testServer, "/api/v1/share-links", headers)
+
+			require.NotNil(t2, test.GetValueFromMap(responseBody, "payload").([]any)[0].(map[string]any)["revokedAt"])
+		})
+
+		t1.Run("5. returns 404 when clipboard item or share link belongs to another user", func(t2 *testing.T) {
+			ownerSessionCookie, _ := test.CreateAndLoginUser(t2, testServer)
+			ownerHeaders := map[string]string{
+				"Cookie": ownerSessionCookie,
+			}
+			otherSessionCookie, _ := test.CreateAndLoginUser(t2, testServer)
+			otherHeaders := map[string]string{
+				"Cookie": otherSessionCookie,
+			}
+			clipboardItemId := createClipboardItem(t2, testServer, ownerHeaders, "not yours")
+
+			response, _ := test.SendPostRequest(
+				t2,
+				testServer,
+				"/api/v1/share-links",
+				map[string]any{
+					"clipboardItemId":  clipboardItemId,
+					"expiresInSeconds": 3600,
+				},
+				otherHeaders,
+			)
+
+			require.Equal(t2, http.StatusNotFound, response.StatusCode)
+
+			_, responseBody := test.SendPostRequest(
+				t2,
+				testServer,
+				"/api/v1/share-links",
+				map[string]any{
+					"clipboardItemId":  clipboardItemId,
+					"expiresInSeconds": 3600,
+				},
+				ownerHeaders,
+			)
+			shareLinkId := test.GetValueFromMap(responseBody, "payload", "shareLinkId").(string)
+
+			response, _ = test.SendDeleteRequest(t2, testServer, "/api/v1/share-links/"+shareLinkId, otherHeaders)
+
+			require.Equal(t2, http.StatusNotFound, response.StatusCode)
+
+			response, _ = test.SendGetRequest(
+				t2,
+				testServer,
+				"/api/v1/share-links/"+shareLinkId+"/views",
+				otherHeaders,
+			)
+
+			require.Equal(t2, http.StatusNotFound, response.StatusCode)
+		})
+
+		t1.Run("6. returns 404 when token is unknown", func(t2 *testing.T) {
+			response, _ := resolveShareLink(t2, testServer, "unknown", "")
+
+			require.Equal(t2, http.StatusNotFound, response.StatusCode)
+		})
+
+		t1.Run("7. returns 401 when jwt is missing", func(t2 *testing.T) {
+			response, _ := test.SendGetRequest(t2, testServer, "/api/v1/share-links", nil)
+
+			require.Equal(t2, http.StatusUnauthorized, response.StatusCode)
+		})
+	})
+}
+
+func createClipboardItem(
+	t *testing.T,
+	testServer *httptest.Server,
+	headers map[string]string,
+	content string,
+) string {
+	response, responseBody := test.SendPostRequest(
+		t,
+		testServer,
+		"/api/v1/clipboard/items",
+		map[string]any{
+			"type":    "TEXT",
+			"content": content,
+		},
+		headers,
+	)
+
+	require.Equal(t, http.StatusCreated, response.StatusCode)
+
+	return test.GetValueFromMap(responseBody, "payload", "clipboardItemId").(string)
+}
+
+func resolveShareLink(
+	t *testing.T,
+	testServer *httptest.Server,
+	token string,
+	password string,
+) (*http.Response, map[string]any) {
+	return test.SendPostRequest(
+		t,
+		testServer,
+		"/api/v1/share-links/resolutions",
+		map[string]any{
+			"token":    token,
+			"password": password,
+		},
+		map[string]string{
+			turnstile.TurnstileTokenHeader: "turnstile-token",
+		},
+	)
+}
@@ -0,0 +1,89 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/cloudy-clip/api/internal/storage"
+	"github.com/stretchr/testify/require"
+)
+
+const signingSecret = "012387ef7806996d79651a6bafd14793dc29d0344812131033b170900ed4e9a8"
+
+func TestLocalObjectStorage(t1 *testing.T) {
+	objectStorage, err := storage.NewLocalObjectStorage(
+		t1.TempDir(),
+		"http://localhost:8282/api/v1/storage/objects",
+		signingSecret,
+	)
+	require.NoError(t1, err)
+
+	content := []byte("image bytes")
+	err = objectStorage.PutObject(
+		context.Background(),
+		"users/1/images/1",
+		bytes.NewReader(content),
+		int64(len(content)),
+		"image/png",
+	)
+	require.NoError(t1, err)
+
+	t1.Run("1. opens object through signed url", func(t2 *testing.T) {
+		downloadUrl, err := objectStorage.SignDownloadUrl("users/1/images/1", time.Minute)
+		require.NoError(t2, err)
+
+		parsedDownloadUrl, err := url.Parse(downloadUrl)
+		require.NoError(t2, err)
+		require.Equal(t2, "/api/v1/storage/objects/users/1/images/1", parsedDownloadUrl.Path)
+
+		objectFile, err := objectStorage.OpenSignedObject(
+			"users/1/images/1",
+			parsedDownloadUrl.Query().Get("expires"),
+			parsedDownloadUrl.Query().Get("signature"),
+		)
+		require.NoError(t2, err)
+		defer objectFile.Close()
+
+		storedObject, err := io.ReadAll(objectFile)
+		require.NoError(t2, err)
+		require.Equal(t2, content, storedObject)
+	})
+
+	t1.Run("2. rejects expired or tampered urls", func(t2 *testing.T) {
+		downloadUrl, err := objectStorage.SignDownloadUrl("users/1/images/1", -time.Minute)
+		require.NoError(t2, err)
+
+		parsedDownloadUrl, err := url.Parse(downloadUrl)
+		require.NoError(t2, err)
+
+		_, err = objectStorage.OpenSignedObject(
+			"users/1/images/1",
+			parsedDownloadUrl.Query().Get("expires"),
+			parsedDownloadUrl.Query().Get("signature"),
+		)
+		require.ErrorIs(t2, err, storage.ErrObjectNotFound)
+
+		downloadUrl, err = objectStorage.SignDownloadUrl("users/1/images/1", time.Minute)
+		require.NoError(t2, err)
+
+		parsedDownloadUrl, err = url.Parse(downloadUrl)
+		require.NoError(t2, err)
+
+		_, err = objectStorage.OpenSignedObject(
+			"users/2/images/1",
+			parsedDownloadUrl.Query().Get("expires"),
+			parsedDownloadUrl.Query().Get("signature"),
+		)
+		require.ErrorIs(t2, err, storage.ErrObjectNotFound)
+	})
+
+	t1.Run("3. rejects keys that escape the storage directory", func(t2 *testing.T) {
+		err := objectStorage.PutObject(context.Background(), "../escaped", bytes.NewReader(content), 11, "image/png")
+
+		require.Error(t2, err)
+	})
+}
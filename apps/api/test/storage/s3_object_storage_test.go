@@ -0,0 +1,90 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cloudy-clip/api/internal/storage"
+	test "github.com/cloudy-clip/api/test/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestS3ObjectStorage(t1 *testing.T) {
+	fakeS3Server := test.NewFakeS3Server()
+	defer fakeS3Server.Close()
+
+	objectStorage, err := storage.NewS3ObjectStorage(
+		fakeS3Server.URL,
+		"us-east-1",
+		"clipboard",
+		"access-key-id",
+		"secret-access-key",
+		true,
+	)
+	require.NoError(t1, err)
+
+	t1.Run("1. uploads object and downloads it through signed url", func(t2 *testing.T) {
+		content := []byte("image bytes")
+
+		err := objectStorage.PutObject(
+			context.Background(),
+			"users/1/images/1",
+			bytes.NewReader(content),
+			int64(len(content)),
+			"image/png",
+		)
+		require.NoError(t2, err)
+
+		storedObject, ok := fakeS3Server.GetObject("/clipboard/users/1/images/1")
+		require.True(t2, ok)
+		require.Equal(t2, content, storedObject)
+
+		downloadUrl, err := objectStorage.SignDownloadUrl("users/1/images/1", time.Minute)
+		require.NoError(t2, err)
+		require.True(t2, strings.HasPrefix(downloadUrl, fakeS3Server.URL+"/clipboard/users/1/images/1?"))
+
+		response, err := http.Get(downloadUrl)
+		require.NoError(t2, err)
+		defer response.Body.Close()
+
+		downloadedObject, err := io.ReadAll(response.Body)
+		require.NoError(t2, err)
+		require.Equal(t2, http.StatusOK, response.StatusCode)
+		require.Equal(t2, content, downloadedObject)
+	})
+
+	t1.Run("2. fails when body is shorter than its declared size", func(t2 *testing.T) {
+		err := objectStorage.PutObject(
+			context.Background(),
+			"users/1/images/2",
+			strings.NewReader("short"),
+			100,
+			"image/png",
+		)
+
+		require.Error(t2, err)
+	})
+
+	t1.Run("3. deletes object and ignores objects that do not exist", func(t2 *testing.T) {
+		content := []byte("image bytes")
+		err := objectStorage.PutObject(
+			context.Background(),
+			"users/1/images/3",
+			bytes.NewReader(content),
+			int64(len(content)),
+			"image/png",
+		)
+		require.NoError(t2, err)
+
+		require.NoError(t2, objectStorage.DeleteObject(context.Background(), "users/1/images/3"))
+		require.NoError(t2, objectStorage.DeleteObject(context.Background(), "users/1/images/3"))
+
+		_, ok := fakeS3Server.GetObject("/clipboard/users/1/images/3")
+		require.False(t2, ok)
+	})
+}
@@ -0,0 +1,77 @@
+package test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+)
+
+// FakeS3Server stands in for MinIO, it keeps objects in memory and expects path-style URLs.
+type FakeS3Server struct {
+	*httptest.Server
+	Objects map[string][]byte
+	mutex   sync.Mutex
+}
+
+func NewFakeS3Server() *FakeS3Server {
+	fakeS3Server := &FakeS3Server{
+		Objects: map[string][]byte{},
+	}
+	fakeS3Server.Server = httptest.NewServer(http.HandlerFunc(fakeS3Server.handleRequest))
+
+	return fakeS3Server
+}
+
+func (fakeS3Server *FakeS3Server) GetObject(path string) ([]byte, bool) {
+	fakeS3Server.mutex.Lock()
+	defer fakeS3Server.mutex.Unlock()
+
+	object, ok := fakeS3Server.Objects[path]
+
+	return object, ok
+}
+
+func (fakeS3Server *FakeS3Server) handleRequest(responseWriter http.ResponseWriter, request *http.Request) {
+	isSignedInHeader := strings.HasPrefix(request.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=")
+	isSignedInQuery := request.URL.Query().Get("X-Amz-Signature") != ""
+	if !isSignedInHeader && !isSignedInQuery {
+		responseWriter.WriteHeader(http.StatusForbidden)
+
+		return
+	}
+
+	fakeS3Server.mutex.Lock()
+	defer fakeS3Server.mutex.Unlock()
+
+	switch request.Method {
+	case http.MethodPut:
+		object, err := io.ReadAll(request.Body)
+		if err != nil || int64(len(object)) != request.ContentLength {
+			responseWriter.WriteHeader(http.StatusBadRequest)
+
+			return
+		}
+
+		fakeS3Server.Objects[request.URL.Path] = object
+		responseWriter.WriteHeader(http.StatusOK)
+
+	case http.MethodGet:
+		object, ok := fakeS3Server.Objects[request.URL.Path]
+		if !ok {
+			responseWriter.WriteHeader(http.StatusNotFound)
+
+			return
+		}
+
+		_, _ = responseWriter.Write(object)
+
+	case http.MethodDelete:
+		delete(fakeS3Server.Objects, request.URL.Path)
+		responseWriter.WriteHeader(http.StatusNoContent)
+
+	default:
+		responseWriter.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
@@ -0,0 +1,118 @@
+package test
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/h2non/gock"
+)
+
+type ServerSentEvent struct {
+	Id    string
+	Event string
+	Data  string
+}
+
+type EventStream struct {
+	Response *http.Response
+	events   chan ServerSentEvent
+	cancel   context.CancelFunc
+}
+
+func OpenEventStream(
+	t *testing.T,
+	testServer *httptest.Server,
+	endpoint string,
+	headers map[string]string,
+) *EventStream {
+	gock.New(testServer.URL).
+		EnableNetworking()
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, testServer.URL+endpoint, nil)
+	if err != nil {
+		cancel()
+		t.Fatalf("failed to create event stream request to '%s'; error was %v\n", endpoint, err)
+
+		panic(err)
+	}
+
+	request.Header.Set("Accept", "text/event-stream")
+
+	for name, value := range headers {
+		request.Header.Set(name, value)
+	}
+
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		cancel()
+		t.Fatalf("failed to open event stream to '%s'; error was %v\n", endpoint, err)
+
+		panic(err)
+	}
+
+	eventStream := &EventStream{
+		Response: response,
+		events:   make(chan ServerSentEvent),
+		cancel:   cancel,
+	}
+
+	go eventStream.readEvents()
+
+	return eventStream
+}
+
+func (eventStream *EventStream) readEvents() {
+	defer close(eventStream.events)
+
+	scanner := bufio.NewScanner(eventStream.Response.Body)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	var event ServerSentEvent
+
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		switch {
+		case line == "":
+			if event.Event != "" || event.Data != "" {
+				eventStream.events <- event
+			}
+
+			event = ServerSentEvent{}
+		case strings.HasPrefix(line, "id: "):
+			event.Id = strings.TrimPrefix(line, "id: ")
+		case strings.HasPrefix(line, "event: "):
+			event.Event = strings.TrimPrefix(line, "event: ")
+		case strings.HasPrefix(line, "data: "):
+			event.Data = strings.TrimPrefix(line, "data: ")
+		}
+	}
+}
+
+// NextEvent fails the test when no event arrives within `timeout`.
+func (eventStream *EventStream) NextEvent(t *testing.T, timeout time.Duration) ServerSentEvent {
+	select {
+	case event, ok := <-eventStream.events:
+		if !ok {
+			t.Fatalf("event stream was closed before receiving an event\n")
+		}
+
+		return event
+	case <-time.After(timeout):
+		t.Fatalf("no event was received within %v\n", timeout)
+	}
+
+	return ServerSentEvent{}
+}
+
+func (eventStream *EventStream) Close() {
+	eventStream.cancel()
+	_ = eventStream.Response.Body.Close()
+}
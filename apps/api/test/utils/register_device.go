@@ -0,0 +1,42 @@
+package test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cloudy-clip/api/internal/common/jwt"
+	"github.com/cloudy-clip/api/internal/device/dto"
+	"github.com/cloudy-clip/api/internal/device/model"
+	"github.com/cloudy-clip/api/internal/user"
+	"github.com/stretchr/testify/require"
+)
+
+// RegisterDevice returns the session cookie that is bound to the registered device along with its ID.
+func RegisterDevice(
+	t *testing.T,
+	testServer *httptest.Server,
+	sessionCookie string,
+	deviceName string,
+) (string, string) {
+	response, responseBody := SendPostRequest(
+		t,
+		testServer,
+		"/api/v1/users/me/devices",
+		dto.RegisterDeviceRequest{
+			Name:       deviceName,
+			Platform:   model.DevicePlatformMacos,
+			AppVersion: "1.0.0",
+		},
+		map[string]string{
+			"Cookie": sessionCookie,
+		},
+	)
+
+	require.Equal(t, http.StatusCreated, response.StatusCode)
+
+	return makeSessionCookie(
+		GetCookieValueFromResponse(t, response, user.SessionIdCookieName),
+		GetCookieValueFromResponse(t, response, jwt.JwtCookieName),
+	), GetValueFromMap(responseBody, "payload", "deviceId").(string)
+}
@@ -5,16 +5,32 @@ import (
 	"cloudy-clip/desktop/internal/clipboard/dto"
 	"cloudy-clip/desktop/internal/common/database"
 	"cloudy-clip/desktop/internal/common/environment"
+	"cloudy-clip/desktop/internal/common/exception"
+	"cloudy-clip/desktop/internal/ignorerule"
+	_ignoreRuleDto "cloudy-clip/desktop/internal/ignorerule/dto"
+	"cloudy-clip/desktop/internal/retention"
+	_retentionDto "cloudy-clip/desktop/internal/retention/dto"
+	"cloudy-clip/desktop/internal/sensitivecontent"
+	_sensitiveContentDto "cloudy-clip/desktop/internal/sensitivecontent/dto"
 	"context"
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/joho/godotenv"
+	"github.com/wailsapp/wails/v2/pkg/runtime"
 )
 
+var clipboardArchiveFileFilters = []runtime.FileFilter{
+	{DisplayName: "Clipboard history archives (*.zip)", Pattern: "*.zip"},
+}
+
 // App struct
 type App struct {
-	ctx context.Context
+	ctx                context.Context
+	clipboardWatcher   *clipboard.ClipboardWatcher
+	retentionSweeper   *retention.RetentionSweeper
+	imageBlobCollector *clipboard.ImageBlobCollector
 }
 
 // NewApp creates a new App application struct
@@ -39,14 +55,225 @@ func (a *App) startup(ctx context.Context) {
 
 	a.ctx = ctx
 	database.InitializeDatabaseClient()
+	// Fingerprinting needs the encryption key for items that are encrypted.
+	clipboard.InitializeEncryption(ctx)
+	clipboard.BackfillClipboardItemFingerprints(ctx)
+	clipboard.MigrateImageFilesToBlobs(ctx)
+	clipboard.BackfillImageMetadata(ctx)
+
+	a.clipboardWatcher = clipboard.NewClipboardWatcher(
+		environment.Config.ClipboardPollInterval,
+		environment.Config.ClipboardDebounceInterval,
+		func(item dto.ClipboardItem) {
+			runtime.EventsEmit(a.ctx, clipboard.ClipboardItemCapturedEventName, item)
+		},
+	)
+	a.clipboardWatcher.Start()
+
+	a.retentionSweeper = retention.NewRetentionSweeper(
+		environment.Config.RetentionSweepInterval,
+		environment.Config.ExpiredItemSweepInterval,
+		func(itemIds []string) {
+			runtime.EventsEmit(a.ctx, retention.ClipboardItemsExpiredEventName, itemIds)
+		},
+	)
+	a.retentionSweeper.Start()
+
+	a.imageBlobCollector = clipboard.NewImageBlobCollector(environment.Config.ImageBlobCollectionInterval)
+	a.imageBlobCollector.Start()
 }
 
 func (a *App) shutdown(_ context.Context) bool {
+	// The watcher, the sweeper and the collector have to stop before the database closes since they
+	// may be writing to it.
+	a.clipboardWatcher.Stop()
+	a.retentionSweeper.Stop()
+	a.imageBlobCollector.Stop()
 	database.Close()
 
 	return true
 }
 
-func (a *App) GetLatestClipboardItem() dto.ClipboardItem {
-	return clipboard.GetLatestClipboardItem()
+// PauseClipboardCapture stops capturing clipboard items until `ResumeClipboardCapture` is called.
+func (a *App) PauseClipboardCapture() {
+	a.clipboardWatcher.Pause()
+}
+
+func (a *App) ResumeClipboardCapture() {
+	a.clipboardWatcher.Resume()
+}
+
+func (a *App) IsClipboardCapturePaused() bool {
+	return a.clipboardWatcher.IsPaused()
+}
+
+// GetClipboardItems returns a page of the clipboard history, pass the `nextCursor` of a page
+// as the `cursor` of the query to get the following page.
+func (a *App) GetClipboardItems(query dto.GetClipboardItemsQuery) (dto.ClipboardItemPage, error) {
+	return clipboard.GetClipboardItems(a.ctx, query)
+}
+
+func (a *App) GetClipboardItem(itemId string) (dto.ClipboardItem, error) {
+	return clipboard.GetClipboardItem(a.ctx, itemId)
+}
+
+// SearchClipboardItems returns the best matches first, with the matched terms of every snippet
+// wrapped in <mark></mark>.
+func (a *App) SearchClipboardItems(query dto.SearchClipboardItemsQuery) (dto.ClipboardItemSearchResults, error) {
+	return clipboard.SearchClipboardItems(a.ctx, query)
+}
+
+// CopyToClipboard writes the item back onto the system clipboard without capturing it again,
+// and returns it after moving it to the top of the history.
+func (a *App) CopyToClipboard(itemId string) (dto.ClipboardItem, error) {
+	return clipboard.CopyToClipboard(a.ctx, itemId)
+}
+
+func (a *App) PinClipboardItem(itemId string) (dto.ClipboardItem, error) {
+	return clipboard.SetClipboardItemPinned(a.ctx, itemId, true)
+}
+
+func (a *App) UnpinClipboardItem(itemId string) (dto.ClipboardItem, error) {
+	return clipboard.SetClipboardItemPinned(a.ctx, itemId, false)
+}
+
+func (a *App) DeleteClipboardItem(itemId string) error {
+	return clipboard.DeleteClipboardItem(a.ctx, itemId)
+}
+
+// DeleteClipboardItems returns how many of the items were deleted.
+func (a *App) DeleteClipboardItems(itemIds []string) (int, error) {
+	return clipboard.DeleteClipboardItems(a.ctx, itemIds)
+}
+
+// ClearClipboardHistory deletes every item that is not pinned and returns how many were deleted.
+func (a *App) ClearClipboardHistory() (int, error) {
+	return clipboard.ClearClipboardHistory(a.ctx)
+}
+
+// ExportClipboardHistory asks where to save the archive, then exports the items that match the request
+// while emitting `clipboard.ClipboardHistoryExportProgressEventName`. It resolves with an empty file
+// path when the user cancels.
+func (a *App) ExportClipboardHistory(
+	request dto.ExportClipboardHistoryRequest,
+) (dto.ClipboardHistoryExportResult, error) {
+	filePath, err := runtime.SaveFileDialog(a.ctx, runtime.SaveDialogOptions{
+		DefaultFilename: fmt.Sprintf("cloudy-clip-history-%s.zip", time.Now().Format(time.DateOnly)),
+		Filters:         clipboardArchiveFileFilters,
+	})
+	if err != nil {
+		return dto.ClipboardHistoryExportResult{}, exception.NewUnknownException("failed to choose where to export to")
+	}
+
+	if filePath == "" {
+		return dto.ClipboardHistoryExportResult{}, nil
+	}
+
+	return clipboard.ExportClipboardHistory(
+		a.ctx,
+		filePath,
+		request,
+		func(progress dto.ClipboardArchiveProgress) {
+			runtime.EventsEmit(a.ctx, clipboard.ClipboardHistoryExportProgressEventName, progress)
+		},
+	)
+}
+
+// ImportClipboardHistory asks for the archive to import, then merges it into the history while emitting
+// `clipboard.ClipboardHistoryImportProgressEventName`. It resolves with an empty file path when the user
+// cancels, the history has to be loaded again to show the imported items.
+func (a *App) ImportClipboardHistory() (dto.ClipboardHistoryImportResult, error) {
+	filePath, err := runtime.OpenFileDialog(a.ctx, runtime.OpenDialogOptions{
+		Filters: clipboardArchiveFileFilters,
+	})
+	if err != nil {
+		return dto.ClipboardHistoryImportResult{}, exception.NewUnknownException("failed to choose what to import")
+	}
+
+	if filePath == "" {
+		return dto.ClipboardHistoryImportResult{}, nil
+	}
+
+	return clipboard.ImportClipboardHistory(
+		a.ctx,
+		filePath,
+		func(progress dto.ClipboardArchiveProgress) {
+			runtime.EventsEmit(a.ctx, clipboard.ClipboardHistoryImportProgressEventName, progress)
+		},
+	)
+}
+
+func (a *App) GetRetentionPolicy() (_retentionDto.RetentionPolicy, error) {
+	return retention.GetRetentionPolicy(a.ctx)
+}
+
+// UpdateRetentionPolicy replaces every limit of the policy, the new limits are enforced by the next sweep.
+func (a *App) UpdateRetentionPolicy(
+	request _retentionDto.UpdateRetentionPolicyRequest,
+) (_retentionDto.RetentionPolicy, error) {
+	return retention.UpdateRetentionPolicy(a.ctx, request)
+}
+
+func (a *App) GetEncryptionSettings() dto.EncryptionSettings {
+	return clipboard.GetEncryptionSettings()
+}
+
+// UpdateEncryptionSettings encrypts or decrypts the whole history before resolving.
+func (a *App) UpdateEncryptionSettings(request dto.UpdateEncryptionSettingsRequest) (dto.EncryptionSettings, error) {
+	return clipboard.UpdateEncryptionSettings(a.ctx, request)
+}
+
+// GetSensitiveContentRules returns every rule, including the ones that still use their defaults.
+func (a *App) GetSensitiveContentRules() ([]_sensitiveContentDto.SensitiveContentRule, error) {
+	return sensitivecontent.GetSensitiveContentRules(a.ctx)
+}
+
+// UpdateSensitiveContentRule applies to the content captured from then on.
+func (a *App) UpdateSensitiveContentRule(
+	request _sensitiveContentDto.UpdateSensitiveContentRuleRequest,
+) (_sensitiveContentDto.SensitiveContentRule, error) {
+	return sensitivecontent.UpdateSensitiveContentRule(a.ctx, request)
+}
+
+// GetIgnoreRules returns the oldest rules first.
+func (a *App) GetIgnoreRules() ([]_ignoreRuleDto.IgnoreRule, error) {
+	return ignorerule.GetIgnoreRules(a.ctx)
+}
+
+func (a *App) CreateIgnoreRule(request _ignoreRuleDto.SaveIgnoreRuleRequest) (_ignoreRuleDto.IgnoreRule, error) {
+	return ignorerule.CreateIgnoreRule(a.ctx, request)
+}
+
+func (a *App) UpdateIgnoreRule(
+	ruleId string,
+	request _ignoreRuleDto.SaveIgnoreRuleRequest,
+) (_ignoreRuleDto.IgnoreRule, error) {
+	return ignorerule.UpdateIgnoreRule(a.ctx, ruleId, request)
+}
+
+func (a *App) DeleteIgnoreRule(ruleId string) error {
+	return ignorerule.DeleteIgnoreRule(a.ctx, ruleId)
+}
+
+// TestIgnoreRules tells whether the content would be captured, it rejects a draft rule that is invalid
+// with the same error as saving it would, such as a pattern that does not compile.
+func (a *App) TestIgnoreRules(
+	request _ignoreRuleDto.TestIgnoreRulesRequest,
+) (_ignoreRuleDto.IgnoreRuleTestResult, error) {
+	return ignorerule.TestIgnoreRules(a.ctx, request)
+}
+
+func (a *App) GetIncognitoMode() (_ignoreRuleDto.IncognitoMode, error) {
+	return ignorerule.GetIncognitoMode(a.ctx)
+}
+
+// StartIncognitoMode keeps anything that is copied from being captured for the duration, even across restarts.
+func (a *App) StartIncognitoMode(
+	request _ignoreRuleDto.StartIncognitoModeRequest,
+) (_ignoreRuleDto.IncognitoMode, error) {
+	return ignorerule.StartIncognitoMode(a.ctx, request)
+}
+
+func (a *App) StopIncognitoMode() (_ignoreRuleDto.IncognitoMode, error) {
+	return ignorerule.StopIncognitoMode(a.ctx)
 }
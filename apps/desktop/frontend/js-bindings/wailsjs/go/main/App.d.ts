@@ -2,4 +2,58 @@
 // This file is automatically generated. DO NOT EDIT
 import { dto } from '../models';
 
-export function GetLatestClipboardItem(): Promise<dto.ClipboardItem>;
+export function ClearClipboardHistory(): Promise<number>;
+
+export function CopyToClipboard(arg1: string): Promise<dto.ClipboardItem>;
+
+export function CreateIgnoreRule(arg1: dto.SaveIgnoreRuleRequest): Promise<dto.IgnoreRule>;
+
+export function DeleteClipboardItem(arg1: string): Promise<void>;
+
+export function DeleteClipboardItems(arg1: Array<string>): Promise<number>;
+
+export function DeleteIgnoreRule(arg1: string): Promise<void>;
+
+export function ExportClipboardHistory(arg1: dto.ExportClipboardHistoryRequest): Promise<dto.ClipboardHistoryExportResult>;
+
+export function GetClipboardItem(arg1: string): Promise<dto.ClipboardItem>;
+
+export function GetClipboardItems(arg1: dto.GetClipboardItemsQuery): Promise<dto.ClipboardItemPage>;
+
+export function GetEncryptionSettings(): Promise<dto.EncryptionSettings>;
+
+export function GetIgnoreRules(): Promise<Array<dto.IgnoreRule>>;
+
+export function GetIncognitoMode(): Promise<dto.IncognitoMode>;
+
+export function GetRetentionPolicy(): Promise<dto.RetentionPolicy>;
+
+export function GetSensitiveContentRules(): Promise<Array<dto.SensitiveContentRule>>;
+
+export function ImportClipboardHistory(): Promise<dto.ClipboardHistoryImportResult>;
+
+export function IsClipboardCapturePaused(): Promise<boolean>;
+
+export function PauseClipboardCapture(): Promise<void>;
+
+export function PinClipboardItem(arg1: string): Promise<dto.ClipboardItem>;
+
+export function ResumeClipboardCapture(): Promise<void>;
+
+export function SearchClipboardItems(arg1: dto.SearchClipboardItemsQuery): Promise<dto.ClipboardItemSearchResults>;
+
+export function StartIncognitoMode(arg1: dto.StartIncognitoModeRequest): Promise<dto.IncognitoMode>;
+
+export function StopIncognitoMode(): Promise<dto.IncognitoMode>;
+
+export function TestIgnoreRules(arg1: dto.TestIgnoreRulesRequest): Promise<dto.IgnoreRuleTestResult>;
+
+export function UnpinClipboardItem(arg1: string): Promise<dto.ClipboardItem>;
+
+export function UpdateEncryptionSettings(arg1: dto.UpdateEncryptionSettingsRequest): Promise<dto.EncryptionSettings>;
+
+export function UpdateIgnoreRule(arg1: string, arg2: dto.SaveIgnoreRuleRequest): Promise<dto.IgnoreRule>;
+
+export function UpdateRetentionPolicy(arg1: dto.UpdateRetentionPolicyRequest): Promise<dto.RetentionPolicy>;
+
+export function UpdateSensitiveContentRule(arg1: dto.UpdateSensitiveContentRuleRequest): Promise<dto.SensitiveContentRule>;
@@ -2,6 +2,114 @@
 // Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
 // This file is automatically generated. DO NOT EDIT
 
-export function GetLatestClipboardItem() {
-  return window['go']['main']['App']['GetLatestClipboardItem']();
+export function ClearClipboardHistory() {
+  return window['go']['main']['App']['ClearClipboardHistory']();
+}
+
+export function CopyToClipboard(arg1) {
+  return window['go']['main']['App']['CopyToClipboard'](arg1);
+}
+
+export function CreateIgnoreRule(arg1) {
+  return window['go']['main']['App']['CreateIgnoreRule'](arg1);
+}
+
+export function DeleteClipboardItem(arg1) {
+  return window['go']['main']['App']['DeleteClipboardItem'](arg1);
+}
+
+export function DeleteClipboardItems(arg1) {
+  return window['go']['main']['App']['DeleteClipboardItems'](arg1);
+}
+
+export function DeleteIgnoreRule(arg1) {
+  return window['go']['main']['App']['DeleteIgnoreRule'](arg1);
+}
+
+export function ExportClipboardHistory(arg1) {
+  return window['go']['main']['App']['ExportClipboardHistory'](arg1);
+}
+
+export function GetClipboardItem(arg1) {
+  return window['go']['main']['App']['GetClipboardItem'](arg1);
+}
+
+export function GetClipboardItems(arg1) {
+  return window['go']['main']['App']['GetClipboardItems'](arg1);
+}
+
+export function GetEncryptionSettings() {
+  return window['go']['main']['App']['GetEncryptionSettings']();
+}
+
+export function GetIgnoreRules() {
+  return window['go']['main']['App']['GetIgnoreRules']();
+}
+
+export function GetIncognitoMode() {
+  return window['go']['main']['App']['GetIncognitoMode']();
+}
+
+export function GetRetentionPolicy() {
+  return window['go']['main']['App']['GetRetentionPolicy']();
+}
+
+export function GetSensitiveContentRules() {
+  return window['go']['main']['App']['GetSensitiveContentRules']();
+}
+
+export function ImportClipboardHistory() {
+  return window['go']['main']['App']['ImportClipboardHistory']();
+}
+
+export function IsClipboardCapturePaused() {
+  return window['go']['main']['App']['IsClipboardCapturePaused']();
+}
+
+export function PauseClipboardCapture() {
+  return window['go']['main']['App']['PauseClipboardCapture']();
+}
+
+export function PinClipboardItem(arg1) {
+  return window['go']['main']['App']['PinClipboardItem'](arg1);
+}
+
+export function ResumeClipboardCapture() {
+  return window['go']['main']['App']['ResumeClipboardCapture']();
+}
+
+export function SearchClipboardItems(arg1) {
+  return window['go']['main']['App']['SearchClipboardItems'](arg1);
+}
+
+export function StartIncognitoMode(arg1) {
+  return window['go']['main']['App']['StartIncognitoMode'](arg1);
+}
+
+export function StopIncognitoMode() {
+  return window['go']['main']['App']['StopIncognitoMode']();
+}
+
+export function TestIgnoreRules(arg1) {
+  return window['go']['main']['App']['TestIgnoreRules'](arg1);
+}
+
+export function UnpinClipboardItem(arg1) {
+  return window['go']['main']['App']['UnpinClipboardItem'](arg1);
+}
+
+export function UpdateEncryptionSettings(arg1) {
+  return window['go']['main']['App']['UpdateEncryptionSettings'](arg1);
+}
+
+export function UpdateIgnoreRule(arg1, arg2) {
+  return window['go']['main']['App']['UpdateIgnoreRule'](arg1, arg2);
+}
+
+export function UpdateRetentionPolicy(arg1) {
+  return window['go']['main']['App']['UpdateRetentionPolicy'](arg1);
+}
+
+export function UpdateSensitiveContentRule(arg1) {
+  return window['go']['main']['App']['UpdateSensitiveContentRule'](arg1);
 }
@@ -1,11 +1,49 @@
 export namespace dto {
+  export class ClipboardHistoryExportResult {
+    filePath: string;
+    exportedItemCount: number;
+    skippedItemCount: number;
+
+    static createFrom(source: any = {}) {
+      return new ClipboardHistoryExportResult(source);
+    }
+
+    constructor(source: any = {}) {
+      if ('string' === typeof source) source = JSON.parse(source);
+      this.filePath = source['filePath'];
+      this.exportedItemCount = source['exportedItemCount'];
+      this.skippedItemCount = source['skippedItemCount'];
+    }
+  }
+  export class ClipboardHistoryImportResult {
+    filePath: string;
+    importedItemCount: number;
+    duplicateItemCount: number;
+    skippedItemCount: number;
+
+    static createFrom(source: any = {}) {
+      return new ClipboardHistoryImportResult(source);
+    }
+
+    constructor(source: any = {}) {
+      if ('string' === typeof source) source = JSON.parse(source);
+      this.filePath = source['filePath'];
+      this.importedItemCount = source['importedItemCount'];
+      this.duplicateItemCount = source['duplicateItemCount'];
+      this.skippedItemCount = source['skippedItemCount'];
+    }
+  }
   export class ClipboardItem {
     id: string;
-    type: 'TEXT' | 'IMAGE' | 'URL';
+    type: 'TEXT' | 'IMAGE' | 'URL' | 'HTML' | 'RTF' | 'FILE' | 'COLOR';
     content: string;
+    richContent?: string;
     createdAt: number;
     isPinned: boolean;
     pinnedAt: number;
+    isSensitive: boolean;
+    expiresAt: number;
+    imageMetadata?: ImageMetadata;
 
     static createFrom(source: any = {}) {
       return new ClipboardItem(source);
@@ -16,9 +54,445 @@ export namespace dto {
       this.id = source['id'];
       this.type = source['type'];
       this.content = source['content'];
+      this.richContent = source['richContent'];
+      this.createdAt = source['createdAt'];
+      this.isPinned = source['isPinned'];
+      this.pinnedAt = source['pinnedAt'];
+      this.isSensitive = source['isSensitive'];
+      this.expiresAt = source['expiresAt'];
+      this.imageMetadata = this.convertValues(source['imageMetadata'], ImageMetadata);
+    }
+
+    convertValues(a: any, classs: any, asMap: boolean = false): any {
+      if (!a) {
+        return a;
+      }
+      if (a.slice && a.map) {
+        return (a as any[]).map(elem => this.convertValues(elem, classs));
+      } else if ('object' === typeof a) {
+        if (asMap) {
+          for (const key of Object.keys(a)) {
+            a[key] = new classs(a[key]);
+          }
+          return a;
+        }
+        return new classs(a);
+      }
+      return a;
+    }
+  }
+  export class ClipboardItemPage {
+    items: ClipboardItem[];
+    nextCursor: string;
+    hasMore: boolean;
+
+    static createFrom(source: any = {}) {
+      return new ClipboardItemPage(source);
+    }
+
+    constructor(source: any = {}) {
+      if ('string' === typeof source) source = JSON.parse(source);
+      this.items = this.convertValues(source['items'], ClipboardItem);
+      this.nextCursor = source['nextCursor'];
+      this.hasMore = source['hasMore'];
+    }
+
+    convertValues(a: any, classs: any, asMap: boolean = false): any {
+      if (!a) {
+        return a;
+      }
+      if (a.slice && a.map) {
+        return (a as any[]).map(elem => this.convertValues(elem, classs));
+      } else if ('object' === typeof a) {
+        if (asMap) {
+          for (const key of Object.keys(a)) {
+            a[key] = new classs(a[key]);
+          }
+          return a;
+        }
+        return new classs(a);
+      }
+      return a;
+    }
+  }
+  export class ClipboardItemSearchResult {
+    id: string;
+    type: 'TEXT' | 'IMAGE' | 'URL' | 'HTML' | 'RTF' | 'FILE' | 'COLOR';
+    content: string;
+    richContent?: string;
+    createdAt: number;
+    isPinned: boolean;
+    pinnedAt: number;
+    isSensitive: boolean;
+    expiresAt: number;
+    imageMetadata?: ImageMetadata;
+    snippet: string;
+
+    static createFrom(source: any = {}) {
+      return new ClipboardItemSearchResult(source);
+    }
+
+    constructor(source: any = {}) {
+      if ('string' === typeof source) source = JSON.parse(source);
+      this.id = source['id'];
+      this.type = source['type'];
+      this.content = source['content'];
+      this.richContent = source['richContent'];
       this.createdAt = source['createdAt'];
       this.isPinned = source['isPinned'];
       this.pinnedAt = source['pinnedAt'];
+      this.isSensitive = source['isSensitive'];
+      this.expiresAt = source['expiresAt'];
+      this.imageMetadata = this.convertValues(source['imageMetadata'], ImageMetadata);
+      this.snippet = source['snippet'];
+    }
+
+    convertValues(a: any, classs: any, asMap: boolean = false): any {
+      if (!a) {
+        return a;
+      }
+      if (a.slice && a.map) {
+        return (a as any[]).map(elem => this.convertValues(elem, classs));
+      } else if ('object' === typeof a) {
+        if (asMap) {
+          for (const key of Object.keys(a)) {
+            a[key] = new classs(a[key]);
+          }
+          return a;
+        }
+        return new classs(a);
+      }
+      return a;
+    }
+  }
+  export class ClipboardItemSearchResults {
+    items: ClipboardItemSearchResult[];
+    isFuzzy: boolean;
+
+    static createFrom(source: any = {}) {
+      return new ClipboardItemSearchResults(source);
+    }
+
+    constructor(source: any = {}) {
+      if ('string' === typeof source) source = JSON.parse(source);
+      this.items = this.convertValues(source['items'], ClipboardItemSearchResult);
+      this.isFuzzy = source['isFuzzy'];
+    }
+
+    convertValues(a: any, classs: any, asMap: boolean = false): any {
+      if (!a) {
+        return a;
+      }
+      if (a.slice && a.map) {
+        return (a as any[]).map(elem => this.convertValues(elem, classs));
+      } else if ('object' === typeof a) {
+        if (asMap) {
+          for (const key of Object.keys(a)) {
+            a[key] = new classs(a[key]);
+          }
+          return a;
+        }
+        return new classs(a);
+      }
+      return a;
+    }
+  }
+  export class EncryptionSettings {
+    isEnabled: boolean;
+    isKeyAvailable: boolean;
+
+    static createFrom(source: any = {}) {
+      return new EncryptionSettings(source);
+    }
+
+    constructor(source: any = {}) {
+      if ('string' === typeof source) source = JSON.parse(source);
+      this.isEnabled = source['isEnabled'];
+      this.isKeyAvailable = source['isKeyAvailable'];
+    }
+  }
+  export class ExportClipboardHistoryRequest {
+    types: ('TEXT' | 'IMAGE' | 'URL' | 'HTML' | 'RTF' | 'FILE' | 'COLOR')[];
+    createdFrom: number;
+    createdTo: number;
+    isPinned?: boolean;
+
+    static createFrom(source: any = {}) {
+      return new ExportClipboardHistoryRequest(source);
+    }
+
+    constructor(source: any = {}) {
+      if ('string' === typeof source) source = JSON.parse(source);
+      this.types = source['types'];
+      this.createdFrom = source['createdFrom'];
+      this.createdTo = source['createdTo'];
+      this.isPinned = source['isPinned'];
+    }
+  }
+  export class GetClipboardItemsQuery {
+    cursor: string;
+    limit: number;
+    types: ('TEXT' | 'IMAGE' | 'URL' | 'HTML' | 'RTF' | 'FILE' | 'COLOR')[];
+    createdFrom: number;
+    createdTo: number;
+    isPinned?: boolean;
+
+    static createFrom(source: any = {}) {
+      return new GetClipboardItemsQuery(source);
+    }
+
+    constructor(source: any = {}) {
+      if ('string' === typeof source) source = JSON.parse(source);
+      this.cursor = source['cursor'];
+      this.limit = source['limit'];
+      this.types = source['types'];
+      this.createdFrom = source['createdFrom'];
+      this.createdTo = source['createdTo'];
+      this.isPinned = source['isPinned'];
+    }
+  }
+  export class IgnoreRule {
+    id: string;
+    name: string;
+    pattern?: string;
+    minLength?: number;
+    maxLength?: number;
+    contentTypes: ('TEXT' | 'IMAGE' | 'URL' | 'HTML' | 'RTF' | 'FILE' | 'COLOR')[];
+    isEnabled: boolean;
+    createdAt: number;
+    updatedAt: number;
+
+    static createFrom(source: any = {}) {
+      return new IgnoreRule(source);
+    }
+
+    constructor(source: any = {}) {
+      if ('string' === typeof source) source = JSON.parse(source);
+      this.id = source['id'];
+      this.name = source['name'];
+      this.pattern = source['pattern'];
+      this.minLength = source['minLength'];
+      this.maxLength = source['maxLength'];
+      this.contentTypes = source['contentTypes'];
+      this.isEnabled = source['isEnabled'];
+      this.createdAt = source['createdAt'];
+      this.updatedAt = source['updatedAt'];
+    }
+  }
+  export class IgnoreRuleTestResult {
+    isIgnored: boolean;
+    matchedRuleIds: string[];
+    isDraftRuleMatched: boolean;
+
+    static createFrom(source: any = {}) {
+      return new IgnoreRuleTestResult(source);
+    }
+
+    constructor(source: any = {}) {
+      if ('string' === typeof source) source = JSON.parse(source);
+      this.isIgnored = source['isIgnored'];
+      this.matchedRuleIds = source['matchedRuleIds'];
+      this.isDraftRuleMatched = source['isDraftRuleMatched'];
+    }
+  }
+  export class ImageMetadata {
+    width: number;
+    height: number;
+    byteSize: number;
+    mimeType: string;
+    thumbnailUrl: string;
+
+    static createFrom(source: any = {}) {
+      return new ImageMetadata(source);
+    }
+
+    constructor(source: any = {}) {
+      if ('string' === typeof source) source = JSON.parse(source);
+      this.width = source['width'];
+      this.height = source['height'];
+      this.byteSize = source['byteSize'];
+      this.mimeType = source['mimeType'];
+      this.thumbnailUrl = source['thumbnailUrl'];
+    }
+  }
+  export class IncognitoMode {
+    isActive: boolean;
+    endsAt: number;
+
+    static createFrom(source: any = {}) {
+      return new IncognitoMode(source);
+    }
+
+    constructor(source: any = {}) {
+      if ('string' === typeof source) source = JSON.parse(source);
+      this.isActive = source['isActive'];
+      this.endsAt = source['endsAt'];
+    }
+  }
+  export class RetentionPolicy {
+    maxAgeDays?: number;
+    maxItemCount?: number;
+    maxTotalImageBytes?: number;
+    planRetentionPeriodDays?: number;
+
+    static createFrom(source: any = {}) {
+      return new RetentionPolicy(source);
+    }
+
+    constructor(source: any = {}) {
+      if ('string' === typeof source) source = JSON.parse(source);
+      this.maxAgeDays = source['maxAgeDays'];
+      this.maxItemCount = source['maxItemCount'];
+      this.maxTotalImageBytes = source['maxTotalImageBytes'];
+      this.planRetentionPeriodDays = source['planRetentionPeriodDays'];
+    }
+  }
+  export class SaveIgnoreRuleRequest {
+    name: string;
+    pattern?: string;
+    minLength?: number;
+    maxLength?: number;
+    contentTypes: ('TEXT' | 'IMAGE' | 'URL' | 'HTML' | 'RTF' | 'FILE' | 'COLOR')[];
+    isEnabled: boolean;
+
+    static createFrom(source: any = {}) {
+      return new SaveIgnoreRuleRequest(source);
+    }
+
+    constructor(source: any = {}) {
+      if ('string' === typeof source) source = JSON.parse(source);
+      this.name = source['name'];
+      this.pattern = source['pattern'];
+      this.minLength = source['minLength'];
+      this.maxLength = source['maxLength'];
+      this.contentTypes = source['contentTypes'];
+      this.isEnabled = source['isEnabled'];
+    }
+  }
+  export class SearchClipboardItemsQuery {
+    text: string;
+    types: ('TEXT' | 'IMAGE' | 'URL' | 'HTML' | 'RTF' | 'FILE' | 'COLOR')[];
+    limit: number;
+
+    static createFrom(source: any = {}) {
+      return new SearchClipboardItemsQuery(source);
+    }
+
+    constructor(source: any = {}) {
+      if ('string' === typeof source) source = JSON.parse(source);
+      this.text = source['text'];
+      this.types = source['types'];
+      this.limit = source['limit'];
+    }
+  }
+  export class SensitiveContentRule {
+    id: 'CREDIT_CARD_NUMBER' | 'PRIVATE_KEY' | 'JWT' | 'AWS_ACCESS_KEY' | 'GITHUB_TOKEN' | 'STRIPE_KEY' | 'HIGH_ENTROPY_STRING';
+    isEnabled: boolean;
+    policy: 'SKIP' | 'MASK' | 'EXPIRE';
+    expireAfterMinutes: number;
+
+    static createFrom(source: any = {}) {
+      return new SensitiveContentRule(source);
+    }
+
+    constructor(source: any = {}) {
+      if ('string' === typeof source) source = JSON.parse(source);
+      this.id = source['id'];
+      this.isEnabled = source['isEnabled'];
+      this.policy = source['policy'];
+      this.expireAfterMinutes = source['expireAfterMinutes'];
+    }
+  }
+  export class StartIncognitoModeRequest {
+    durationMinutes: number;
+
+    static createFrom(source: any = {}) {
+      return new StartIncognitoModeRequest(source);
+    }
+
+    constructor(source: any = {}) {
+      if ('string' === typeof source) source = JSON.parse(source);
+      this.durationMinutes = source['durationMinutes'];
+    }
+  }
+  export class TestIgnoreRulesRequest {
+    content: string;
+    contentType: 'UNKNOWN' | 'TEXT' | 'IMAGE' | 'URL' | 'HTML' | 'RTF' | 'FILE' | 'COLOR';
+    draftRule?: SaveIgnoreRuleRequest;
+
+    static createFrom(source: any = {}) {
+      return new TestIgnoreRulesRequest(source);
+    }
+
+    constructor(source: any = {}) {
+      if ('string' === typeof source) source = JSON.parse(source);
+      this.content = source['content'];
+      this.contentType = source['contentType'];
+      this.draftRule = this.convertValues(source['draftRule'], SaveIgnoreRuleRequest);
+    }
+
+    convertValues(a: any, classs: any, asMap: boolean = false): any {
+      if (!a) {
+        return a;
+      }
+      if (a.slice && a.map) {
+        return (a as any[]).map(elem => this.convertValues(elem, classs));
+      } else if ('object' === typeof a) {
+        if (asMap) {
+          for (const key of Object.keys(a)) {
+            a[key] = new classs(a[key]);
+          }
+          return a;
+        }
+        return new classs(a);
+      }
+      return a;
+    }
+  }
+  export class UpdateEncryptionSettingsRequest {
+    isEnabled: boolean;
+
+    static createFrom(source: any = {}) {
+      return new UpdateEncryptionSettingsRequest(source);
+    }
+
+    constructor(source: any = {}) {
+      if ('string' === typeof source) source = JSON.parse(source);
+      this.isEnabled = source['isEnabled'];
+    }
+  }
+  export class UpdateRetentionPolicyRequest {
+    maxAgeDays?: number;
+    maxItemCount?: number;
+    maxTotalImageBytes?: number;
+
+    static createFrom(source: any = {}) {
+      return new UpdateRetentionPolicyRequest(source);
+    }
+
+    constructor(source: any = {}) {
+      if ('string' === typeof source) source = JSON.parse(source);
+      this.maxAgeDays = source['maxAgeDays'];
+      this.maxItemCount = source['maxItemCount'];
+      this.maxTotalImageBytes = source['maxTotalImageBytes'];
+    }
+  }
+  export class UpdateSensitiveContentRuleRequest {
+    id: 'CREDIT_CARD_NUMBER' | 'PRIVATE_KEY' | 'JWT' | 'AWS_ACCESS_KEY' | 'GITHUB_TOKEN' | 'STRIPE_KEY' | 'HIGH_ENTROPY_STRING';
+    isEnabled: boolean;
+    policy: 'SKIP' | 'MASK' | 'EXPIRE';
+    expireAfterMinutes: number;
+
+    static createFrom(source: any = {}) {
+      return new UpdateSensitiveContentRuleRequest(source);
+    }
+
+    constructor(source: any = {}) {
+      if ('string' === typeof source) source = JSON.parse(source);
+      this.id = source['id'];
+      this.isEnabled = source['isEnabled'];
+      this.policy = source['policy'];
+      this.expireAfterMinutes = source['expireAfterMinutes'];
     }
   }
 }
@@ -1,5 +1,13 @@
-import { DatePipe } from '@angular/common';
-import { afterNextRender, ChangeDetectionStrategy, Component, inject, signal, ViewEncapsulation } from '@angular/core';
+import { DatePipe, DecimalPipe } from '@angular/common';
+import {
+  afterNextRender,
+  ChangeDetectionStrategy,
+  Component,
+  DestroyRef,
+  inject,
+  signal,
+  ViewEncapsulation
+} from '@angular/core';
 import { MatRipple } from '@angular/material/core';
 import { ConfirmationCaptureService } from '@lazycuh/angular-confirmation-capture';
 import { NotificationService } from '@lazycuh/angular-notification';
@@ -8,13 +16,25 @@ import { Logger } from '@lazycuh/logging';
 import { SearchBoxFormFieldComponent } from '@lazycuh/web-ui-common/form/search-box-form-field';
 import { IconComponent } from '@lazycuh/web-ui-common/icon';
 import { TruncatedTextComponent } from '@lazycuh/web-ui-common/truncated-text';
-import { GetLatestClipboardItem } from '@wails/bindings/App';
+import {
+  ClearClipboardHistory,
+  CopyToClipboard,
+  DeleteClipboardItem,
+  GetClipboardItems,
+  PinClipboardItem,
+  UnpinClipboardItem
+} from '@wails/bindings/App';
 import { dto } from '@wails/models';
-import { BrowserOpenURL, ClipboardSetText } from '@wails/runtime/runtime';
+import { BrowserOpenURL, EventsOn } from '@wails/runtime/runtime';
 
 import { EmptyStateComponent } from './empty-state';
 import { ClipboardItem } from './models';
 
+// Must match `clipboard.ClipboardItemCapturedEventName`.
+const CLIPBOARD_ITEM_CAPTURED_EVENT_NAME = 'clipboard:item-captured';
+// Must match `retention.ClipboardItemsExpiredEventName`.
+const CLIPBOARD_ITEMS_EXPIRED_EVENT_NAME = 'clipboard:items-expired';
+
 @Component({
   changeDetection: ChangeDetectionStrategy.OnPush,
   encapsulation: ViewEncapsulation.None,
@@ -23,6 +43,7 @@ import { ClipboardItem } from './models';
     IconComponent,
     MatRipple,
     DatePipe,
+    DecimalPipe,
     TooltipDirective,
     TruncatedTextComponent,
     EmptyStateComponent
@@ -36,6 +57,7 @@ export class ClipboardHistoryComponent {
 
   private readonly _notificationService = inject(NotificationService);
   private readonly _confirmationCaptureService = inject(ConfirmationCaptureService);
+  private readonly _destroyRef = inject(DestroyRef);
   private readonly _logger = new Logger('ClipboardHistoryComponent');
 
   constructor() {
@@ -47,23 +69,40 @@ export class ClipboardHistoryComponent {
   }
 
   private async _init() {
-    await this._refreshClipboardHistory();
+    const stopListening = EventsOn(CLIPBOARD_ITEM_CAPTURED_EVENT_NAME, (clipboardItem: dto.ClipboardItem) => {
+      this._storeClipboardItem(clipboardItem);
+    });
+    const stopListeningForExpiredItems = EventsOn(CLIPBOARD_ITEMS_EXPIRED_EVENT_NAME, (itemIds: string[]) => {
+      this._clipboardItems.set(this._clipboardItems().filter(item => !itemIds.includes(item.id)));
+    });
+
+    this._destroyRef.onDestroy(stopListening);
+    this._destroyRef.onDestroy(stopListeningForExpiredItems);
 
-    setInterval(() => {
-      void this._refreshClipboardHistory();
-    }, 1000);
+    await this._loadClipboardHistory();
   }
 
-  private async _refreshClipboardHistory() {
-    const clipboardItem = await GetLatestClipboardItem();
+  private async _loadClipboardHistory() {
+    try {
+      const clipboardItemPage = await GetClipboardItems(dto.GetClipboardItemsQuery.createFrom({ cursor: '', limit: 0 }));
 
-    if (clipboardItem.id !== '') {
-      this._storeClipboardItem(clipboardItem);
+      this._clipboardItems.set([
+        ...this._clipboardItems(),
+        ...clipboardItemPage.items.map(item => new ClipboardItem(item))
+      ]);
+
+      this._sortClipboardItems();
+    } catch (error) {
+      this._logger.error('failed to load clipboard history', error);
     }
   }
 
   private _storeClipboardItem(item: dto.ClipboardItem) {
-    this._clipboardItems.set([new ClipboardItem(item), ...this._clipboardItems()]);
+    // Copying something that is already in the history moves it to the top instead of adding it again.
+    this._clipboardItems.set([
+      new ClipboardItem(item),
+      ...this._clipboardItems().filter(existingItem => existingItem.id !== item.id)
+    ]);
 
     this._sortClipboardItems();
   }
@@ -81,15 +120,32 @@ export class ClipboardHistoryComponent {
       content: $localize`Are you sure you want to clear all unpinned items in your clipboard history?`
     });
 
-    if (confirmed) {
+    if (!confirmed) {
+      return;
+    }
+
+    try {
+      await ClearClipboardHistory();
+
       this._clipboardItems.set(this._getPinnedClipboardItems());
+    } catch (error) {
+      this._logger.error('failed to clear clipboard history', error);
+      this._notificationService.open({
+        content: $localize`Failed to clear clipboard history`
+      });
     }
   }
 
-  protected _onPinOrUnpinClipboardItem(item: ClipboardItem) {
-    item.togglePin();
+  protected async _onPinOrUnpinClipboardItem(item: ClipboardItem) {
+    try {
+      const updatedItem = item.isPinned() ? await UnpinClipboardItem(item.id) : await PinClipboardItem(item.id);
 
-    this._sortClipboardItems();
+      item.setPinned(updatedItem.isPinned, updatedItem.pinnedAt);
+
+      this._sortClipboardItems();
+    } catch (error) {
+      this._logger.error('failed to pin or unpin clipboard item', error);
+    }
   }
 
   private _sortClipboardItems() {
@@ -116,11 +172,17 @@ export class ClipboardHistoryComponent {
   }
 
   protected async _onCopyClipboardItemToClipboard(item: ClipboardItem) {
-    if (item.type !== 'IMAGE') {
-      const copied = await ClipboardSetText(item.content);
+    try {
+      const copiedItem = await CopyToClipboard(item.id);
 
+      this._storeClipboardItem(copiedItem);
+      this._notificationService.open({
+        content: $localize`Copied to clipboard`
+      });
+    } catch (error) {
+      this._logger.error('failed to copy clipboard item to clipboard', error);
       this._notificationService.open({
-        content: copied ? $localize`Copied to clipboard` : $localize`Failed to copy to clipboard`
+        content: $localize`Failed to copy to clipboard`
       });
     }
   }
@@ -130,8 +192,19 @@ export class ClipboardHistoryComponent {
       content: $localize`Are you sure you want to delete this clipboard item?`
     });
 
-    if (confirmed) {
+    if (!confirmed) {
+      return;
+    }
+
+    try {
+      await DeleteClipboardItem(item.id);
+
       this._clipboardItems.set(this._clipboardItems().filter(i => i.id !== item.id));
+    } catch (error) {
+      this._logger.error('failed to delete clipboard item', error);
+      this._notificationService.open({
+        content: $localize`Failed to delete clipboard item`
+      });
     }
   }
 
@@ -1,14 +1,20 @@
 import { signal } from '@angular/core';
 import { dto } from '@wails/models';
 
-export type ClipboardItemType = 'TEXT' | 'IMAGE' | 'URL';
+export type ClipboardItemType = 'TEXT' | 'IMAGE' | 'URL' | 'HTML' | 'RTF' | 'FILE' | 'COLOR';
 
 export class ClipboardItem {
   readonly id: string;
   readonly type: ClipboardItemType;
   readonly content: string;
+  readonly richContent?: string;
   readonly isPinned = signal(false);
   readonly createdAt: number;
+  readonly isSensitive: boolean;
+  readonly expiresAt: number;
+  readonly imageMetadata?: dto.ImageMetadata;
+  // The history list shows the thumbnail of images until they are expanded.
+  readonly isImageExpanded = signal(false);
 
   pinnedAt = 0;
 
@@ -16,17 +22,27 @@ export class ClipboardItem {
     this.id = source.id;
     this.type = source.type as unknown as ClipboardItemType;
     this.content = source.content;
+    this.richContent = source.richContent;
     this.isPinned.set(source.isPinned);
     this.createdAt = source.createdAt;
+    this.pinnedAt = source.pinnedAt;
+    this.isSensitive = source.isSensitive;
+    this.expiresAt = source.expiresAt;
+    this.imageMetadata = source.imageMetadata;
+  }
 
-    if (this.isPinned()) {
-      this.pinnedAt = Date.now();
+  getImageUrl() {
+    // Images captured before thumbnails existed have none until they have been backfilled.
+    if (this.isImageExpanded() || !this.imageMetadata) {
+      return this.content;
     }
+
+    return this.imageMetadata.thumbnailUrl;
   }
 
-  togglePin() {
-    this.isPinned.set(!this.isPinned());
+  setPinned(isPinned: boolean, pinnedAt: number) {
+    this.isPinned.set(isPinned);
 
-    this.pinnedAt = Date.now();
+    this.pinnedAt = pinnedAt;
   }
 }
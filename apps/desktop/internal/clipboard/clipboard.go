@@ -1,67 +1,5 @@
 package clipboard
 
-/*
-#cgo CFLAGS: -x objective-c -framework Cocoa
-#cgo LDFLAGS: -framework Cocoa
-#import <Cocoa/Cocoa.h>
-#include <stdlib.h>
-
-// Fetch the first (most recent) pasteboard item.
-// Always returns PNG bytes (malloc’d) or NULL.
-// outString: strdup’d UTF-8 string or NULL
-// outImageData: malloc’d PNG bytes or NULL, outImageLength the length
-// Returns 0 on success, -1 if empty.
-int getLatestPasteboardItem(char **outString,
-                            void **outImageData,
-                            int *outImageLength)
-{
-    NSPasteboard *pb = [NSPasteboard generalPasteboard];
-    NSArray<NSPasteboardItem*> *items = [pb pasteboardItems];
-    if (items.count == 0) {
-        *outString = NULL;
-        *outImageData = NULL;
-        *outImageLength = 0;
-        return -1;
-    }
-    NSPasteboardItem *item = items[0];
-
-    // Text
-    NSString *s = [item stringForType:NSPasteboardTypeString];
-    if (s) {
-        *outString = strdup([s UTF8String]);
-    } else {
-        *outString = NULL;
-    }
-
-    // Try raw PNG first
-    NSData *png = [item dataForType:NSPasteboardTypePNG];
-    if (!png) {
-        // Fallback: TIFF → NSImage → PNG
-        NSData *tiff = [item dataForType:NSPasteboardTypeTIFF];
-        if (tiff) {
-            NSImage *img = [[NSImage alloc] initWithData:tiff];
-            NSBitmapImageRep *rep =
-              [[NSBitmapImageRep alloc] initWithData:[img TIFFRepresentation]];
-            png = [rep representationUsingType:NSPNGFileType
-                                 properties:@{}];
-        }
-    }
-
-    if (png) {
-        size_t len = png.length;
-        void *buf = malloc(len);
-        memcpy(buf, png.bytes, len);
-        *outImageData   = buf;
-        *outImageLength = (int)len;
-    } else {
-        *outImageData   = NULL;
-        *outImageLength = 0;
-    }
-
-    return 0;
-}
-*/
-import "C"
 import (
 	"cloudy-clip/desktop/internal/clipboard/dto"
 	"cloudy-clip/desktop/internal/common/database"
@@ -69,146 +7,279 @@ import (
 	"cloudy-clip/desktop/internal/common/database/generated/table"
 	"cloudy-clip/desktop/internal/common/logging"
 	"cloudy-clip/desktop/internal/common/utils"
+	"cloudy-clip/desktop/internal/ignorerule"
+	"cloudy-clip/desktop/internal/sensitivecontent"
 	"context"
-	"encoding/base64"
 	"fmt"
 	"log/slog"
-	"os"
 	"path/filepath"
-	"strings"
 	"time"
-	"unsafe"
-
-	"github.com/cespare/xxhash/v2"
-	"github.com/pkg/errors"
 )
 
 var (
-	lastSeenFingerprint uint64
-	logger              = logging.NewLogger("clipboard", slog.LevelInfo)
+	logger = logging.NewLogger("clipboard", slog.LevelInfo)
 )
 
-// GetLatestClipboardItem retrieves the most recent clipboard item,
-// always converting any image into PNG and returning a data URL.
+// GetLatestClipboardItem captures the most recent clipboard item, always converting any image
+// into PNG, which is stored along with a thumbnail and served through `ImageRequestPath`. HTML and
+// RTF are captured along with their plain-text rendering, which is what is searched, and what is
+// stored on its own when part of it has to be masked. Content that is already in the history is
+// moved to the top instead of being stored again, and nothing is returned when it already is the
+// newest item.
+// Nothing is captured in incognito mode or when an ignore rule matches, and text goes through
+// the sensitive content rules, nothing is returned either when it is skipped.
 func GetLatestClipboardItem() dto.ClipboardItem {
-	var cStr *C.char
-	var cImgPtr unsafe.Pointer
-	var cImgLen C.int
+	ctx := context.WithValue(
+		context.Background(),
+		logging.LoggerContextCallSiteKey, "GetLatestClipboardItem",
+	)
+
+	isIncognitoModeActive, err := ignorerule.IsIncognitoModeActive(time.Now())
+	if err != nil {
+		logger.ErrorAttrs(ctx, err, "failed to check incognito mode")
 
-	if ret := C.getLatestPasteboardItem(&cStr, &cImgPtr, &cImgLen); ret != 0 {
 		return dto.ClipboardItem{}
 	}
-	defer func() {
-		if cStr != nil {
-			C.free(unsafe.Pointer(cStr))
-		}
-		if cImgPtr != nil {
-			C.free(cImgPtr)
+
+	if isIncognitoModeActive {
+		return dto.ClipboardItem{}
+	}
+
+	content, err := backend.ReadLatestItem()
+	if err != nil {
+		logger.ErrorAttrs(ctx, err, "failed to read clipboard")
+
+		return dto.ClipboardItem{}
+	}
+
+	itemType, text, richContent := classifyClipboardContent(content)
+	if itemType == dto.ClipboardItemTypeUnknown {
+		return dto.ClipboardItem{}
+	}
+
+	item := dto.ClipboardItem{
+		Type:        itemType,
+		RichContent: richContent,
+	}
+
+	matchedIgnoreRuleIds, err := ignorerule.MatchIgnoreRules(ctx, item.Type, text)
+	if err != nil {
+		logger.ErrorAttrs(ctx, err, "failed to match ignore rules")
+
+		return dto.ClipboardItem{}
+	}
+
+	if len(matchedIgnoreRuleIds) > 0 {
+		logger.DebugAttrs(ctx, "ignored clipboard content", slog.Any("ruleIds", matchedIgnoreRuleIds))
+
+		return dto.ClipboardItem{}
+	}
+
+	var contentBytes []byte
+	var image *capturedImage
+
+	if item.Type == dto.ClipboardItemTypeImage {
+		newImage, err := newCapturedImage(content.Image)
+		if err != nil {
+			logger.ErrorAttrs(ctx, err, "failed to create thumbnail of clipboard image")
+
+			return dto.ClipboardItem{}
 		}
-	}()
 
-	item := dto.ClipboardItem{}
-	if cStr != nil {
-		if isClipboardItemContentNew([]byte(C.GoString(cStr))) {
-			item.Type = dto.ClipboardItemTypeText
-			item.Id = utils.Generate()
-			item.Content = strings.TrimSpace(C.GoString(cStr))
-			item.CreatedAt = uint64(time.Now().UnixMilli())
+		contentBytes = content.Image
+		image = &newImage
+	} else {
+		inspection, err := sensitivecontent.InspectContent(ctx, text)
+		if err != nil {
+			logger.ErrorAttrs(ctx, err, "failed to inspect clipboard content")
+
+			return dto.ClipboardItem{}
+		}
 
-			if utils.IsValidUrl(item.Content) {
-				item.Type = dto.ClipboardItemTypeUrl
-			}
+		if inspection.ShouldSkip {
+			logger.InfoAttrs(ctx, "skipped sensitive clipboard content", slog.Any("ruleIds", inspection.RuleIds))
 
-			err := persistClipboardItem(&item, nil)
-			if err != nil {
-				ctx := context.WithValue(
-					context.TODO(),
-					logging.LoggerContextCallSiteKey, "GetLatestClipboardItem",
-				)
+			return dto.ClipboardItem{}
+		}
 
-				logger.ErrorAttrs(ctx, err, "failed to persist clipboard item", slog.Any("item", item))
+		item.Content = inspection.Content
+		item.IsSensitive = inspection.IsSensitive
+		contentBytes = []byte(item.Content)
 
-				return dto.ClipboardItem{}
-			}
+		// The markup, paths or color would give away what was masked, or no longer match it.
+		if item.Content != text && item.Type != dto.ClipboardItemTypeText && item.Type != dto.ClipboardItemTypeUrl {
+			item.Type = dto.ClipboardItemTypeText
+			item.RichContent = nil
 		}
-	} else if cImgPtr != nil && cImgLen > 0 {
-		imageByteBuffer := C.GoBytes(cImgPtr, cImgLen)
-		if isClipboardItemContentNew(imageByteBuffer) {
-			item.Id = utils.Generate()
-			item.Type = dto.ClipboardItemTypeImage
-			item.Content = "data:image/png;base64," + base64.StdEncoding.EncodeToString(imageByteBuffer)
-			item.CreatedAt = uint64(time.Now().UnixMilli())
-
-			err := persistClipboardItem(&item, &imageByteBuffer)
-			if err != nil {
-				ctx := context.WithValue(
-					context.Background(),
-					logging.LoggerContextCallSiteKey, "GetLatestClipboardItem",
-				)
-
-				item.Content = ""
-
-				logger.ErrorAttrs(ctx, err, "failed to persist image clipboard item", slog.Any("item", item))
-
-				return dto.ClipboardItem{}
-			}
+
+		if inspection.ExpiresAfter > 0 {
+			item.ExpiresAt = uint64(time.Now().Add(inspection.ExpiresAfter).UnixMilli())
 		}
 	}
 
+	contentFingerprint := fingerprintClipboardItem(contentBytes, item.RichContent)
+	createdAt := uint64(time.Now().UnixMilli())
+
+	latestItemModel, err := findLatestClipboardItem()
+	if err != nil && !database.IsEmptyResultError(err) {
+		logger.ErrorAttrs(ctx, err, "failed to find latest clipboard item")
+
+		return dto.ClipboardItem{}
+	}
+
+	if err == nil && latestItemModel.Fingerprint != nil && *latestItemModel.Fingerprint == contentFingerprint {
+		return dto.ClipboardItem{}
+	}
+
+	duplicateItemModel, err := findClipboardItemByFingerprint(contentFingerprint)
+	if err == nil {
+		return moveClipboardItemToTop(ctx, *duplicateItemModel, createdAt, item.IsSensitive, item.ExpiresAt)
+	}
+
+	if !database.IsEmptyResultError(err) {
+		logger.ErrorAttrs(ctx, err, "failed to find clipboard item by fingerprint")
+
+		return dto.ClipboardItem{}
+	}
+
+	item.Id = utils.Generate()
+	item.CreatedAt = createdAt
+
+	err = persistClipboardItem(&item, contentFingerprint, image)
+	if err != nil {
+		logger.ErrorAttrs(ctx, err, "failed to persist clipboard item", slog.Any("item", item))
+
+		return dto.ClipboardItem{}
+	}
+
 	return item
 }
 
-// isClipboardItemContentNew returns true exactly when the supplied raw bytes
-// produce a different fingerprint than we saw last time.
-func isClipboardItemContentNew(contentBytes []byte) bool {
-	contentFingerprint := fingerprint(contentBytes)
+// moveClipboardItemToTop makes a copied item the newest one again.
+func moveClipboardItemToTop(
+	ctx context.Context,
+	clipboardItemModel model.ClipboardItem,
+	createdAt uint64,
+	isSensitive bool,
+	expiresAt uint64,
+) dto.ClipboardItem {
+	err := utils.Retry(func() error {
+		return updateRecapturedClipboardItem(clipboardItemModel.ID, createdAt, isSensitive, expiresAt)
+	})
+	if err != nil {
+		logger.ErrorAttrs(
+			ctx,
+			err,
+			"failed to move clipboard item to top",
+			slog.String("itemId", clipboardItemModel.ID),
+		)
+
+		return dto.ClipboardItem{}
+	}
+
+	clipboardItemModel.CreatedAt = createdAt
+	clipboardItemModel.IsSensitive = isSensitive
+	clipboardItemModel.ExpiresAt = expiresAt
 
-	if contentFingerprint != lastSeenFingerprint {
-		lastSeenFingerprint = contentFingerprint
+	item, err := newClipboardItem(clipboardItemModel)
+	if err != nil {
+		logger.ErrorAttrs(
+			ctx,
+			err,
+			"failed to read clipboard item moved to top",
+			slog.String("itemId", clipboardItemModel.ID),
+		)
 
-		return true
+		return dto.ClipboardItem{}
 	}
 
-	return false
+	return item
 }
 
-// fingerprint computes a super-fast 64-bit hash of the raw bytes.
-func fingerprint(raw []byte) uint64 {
-	return xxhash.Sum64(raw)
-}
+// persistClipboardItem encrypts the content, rich content or image of the item when encryption is
+// turned on, and points image items at `ImageRequestPath`.
+func persistClipboardItem(item *dto.ClipboardItem, contentFingerprint int64, image *capturedImage) error {
+	isEncrypted, _ := getEncryptionState()
+	clipboardItemModel := model.ClipboardItem{
+		ID:          item.Id,
+		Type:        item.Type,
+		CreatedAt:   item.CreatedAt,
+		IsPinned:    item.IsPinned,
+		PinnedAt:    item.PinnedAt,
+		Fingerprint: &contentFingerprint,
+		IsEncrypted: isEncrypted,
+		IsSensitive: item.IsSensitive,
+		ExpiresAt:   item.ExpiresAt,
+	}
+
+	if image != nil {
+		clipboardItemModel.ImageWidth = &image.width
+		clipboardItemModel.ImageHeight = &image.height
+		clipboardItemModel.ImageByteSize = &image.byteSize
+		clipboardItemModel.ImageMimeType = &image.mimeType
+	}
+
+	err := utils.Retry(func() error {
+		var err error
+		if image == nil {
+			clipboardItemModel.Content, err = sealClipboardItemContent(clipboardItemModel, []byte(item.Content))
+		} else {
+			imageBlobMutex.Lock()
+			defer imageBlobMutex.Unlock()
+
+			var imageBlobHash string
+			imageBlobHash, err = storeImageBlob(isEncrypted, image.imageBytes, image.thumbnail)
+			clipboardItemModel.ImageBlobHash = &imageBlobHash
+		}
 
-func persistClipboardItem(item *dto.ClipboardItem, imageByteBuffer *[]byte) error {
-	return utils.Retry(func() error {
-		if item.Type != dto.ClipboardItemTypeImage {
-			return database.Exec(table.ClipboardItemTable.INSERT().MODEL(model.ClipboardItem{
-				ID:        item.Id,
-				Content:   item.Content,
-				Type:      item.Type,
-				CreatedAt: item.CreatedAt,
-				IsPinned:  item.IsPinned,
-				PinnedAt:  item.PinnedAt,
-			}))
+		if err != nil {
+			return err
 		}
 
-		if err := storeImageClipboardItem(item, imageByteBuffer); err != nil {
+		clipboardItemModel.RichContent, err = sealClipboardItemRichContent(clipboardItemModel, item.RichContent)
+		if err != nil {
 			return err
 		}
 
-		return database.Exec(table.ClipboardItemTable.INSERT().MODEL(model.ClipboardItem{
-			ID:        item.Id,
-			Content:   "",
-			Type:      item.Type,
-			CreatedAt: item.CreatedAt,
-			IsPinned:  item.IsPinned,
-			PinnedAt:  item.PinnedAt,
-		}))
+		return database.Exec(table.ClipboardItemTable.INSERT().MODEL(clipboardItemModel))
 	})
+	if err != nil || image == nil {
+		return err
+	}
+
+	item.Content = ImageRequestPath + item.Id
+	item.ImageMetadata = newImageMetadata(clipboardItemModel)
+
+	return nil
 }
 
-func storeImageClipboardItem(clipboardItem *dto.ClipboardItem, imageByteBuffer *[]byte) error {
-	return errors.WithStack(os.WriteFile(resolveImageFilePathForClipboardItem(clipboardItem), *imageByteBuffer, 0644))
+// ResolveImageFilePath returns where the image of the item is stored, which is the file of its blob,
+// or a file named after the item for images that predate blobs.
+func ResolveImageFilePath(clipboardItemModel model.ClipboardItem) string {
+	if clipboardItemModel.ImageBlobHash != nil {
+		return resolveImageBlobFilePath(newImageBlobOfClipboardItem(clipboardItemModel))
+	}
+
+	fileName := fmt.Sprintf("%v.png", clipboardItemModel.ID)
+	if clipboardItemModel.IsEncrypted {
+		fileName += ".enc"
+	}
+
+	return filepath.Join(utils.GetOrCreateDirectory("images"), fileName)
 }
 
-func resolveImageFilePathForClipboardItem(clipboardItem *dto.ClipboardItem) string {
-	return filepath.Join(utils.GetOrCreateDirectory("images"), fmt.Sprintf("%v.png", clipboardItem.Id))
+// resolveThumbnailFilePath returns where the thumbnail of the image is stored, next to the images.
+func resolveThumbnailFilePath(clipboardItemModel model.ClipboardItem) string {
+	if clipboardItemModel.ImageBlobHash != nil {
+		return resolveImageBlobThumbnailFilePath(newImageBlobOfClipboardItem(clipboardItemModel))
+	}
+
+	fileName := fmt.Sprintf("%v.png", clipboardItemModel.ID)
+	if clipboardItemModel.IsEncrypted {
+		fileName += ".enc"
+	}
+
+	utils.GetOrCreateDirectory("images")
+
+	return filepath.Join(utils.GetOrCreateDirectory(filepath.Join("images", "thumbnails")), fileName)
 }
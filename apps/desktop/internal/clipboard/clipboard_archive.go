@@ -0,0 +1,98 @@
+package clipboard
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+
+	"cloudy-clip/desktop/internal/clipboard/dto"
+)
+
+const (
+	// The names of the Wails events that carry the `dto.ClipboardArchiveProgress` of an export or an
+	// import to the UI.
+	ClipboardHistoryExportProgressEventName = "clipboard:export-progress"
+	ClipboardHistoryImportProgressEventName = "clipboard:import-progress"
+	// Bumped whenever the manifest changes in a way that older versions cannot read.
+	clipboardArchiveVersion       = 1
+	clipboardArchiveManifestName  = "manifest.json"
+	clipboardArchiveBlobDirectory = "blobs/"
+	// Larger images are skipped on import rather than read into memory.
+	maxClipboardArchiveBlobSize = 256 << 20
+)
+
+// clipboardArchiveManifest lists the items of a zip archive, whose images are stored next to it
+// under `clipboardArchiveBlobDirectory`. Everything in the archive is in plaintext, even when the
+// history is encrypted.
+type clipboardArchiveManifest struct {
+	Version    int                    `json:"version"`
+	ExportedAt uint64                 `json:"exportedAt"`
+	Items      []clipboardArchiveItem `json:"items"`
+}
+
+type clipboardArchiveItem struct {
+	Type clipboardArchiveItemType `json:"type"`
+	// Empty for images.
+	Content     string  `json:"content"`
+	RichContent *string `json:"richContent,omitempty"`
+	CreatedAt   uint64  `json:"createdAt"`
+	IsPinned    bool    `json:"isPinned"`
+	PinnedAt    uint64  `json:"pinnedAt"`
+	// The path of the PNG in the archive, only set for images.
+	ImageBlobPath string `json:"imageBlobPath,omitempty"`
+}
+
+// clipboardArchiveItemType reads the types that this version does not know, e.g. ones added by a
+// later version, as `dto.ClipboardItemTypeUnknown`, so that only their items are skipped rather
+// than the whole archive.
+type clipboardArchiveItemType dto.ClipboardItemType
+
+func (itemType clipboardArchiveItemType) MarshalJSON() ([]byte, error) {
+	return dto.ClipboardItemType(itemType).MarshalJSON()
+}
+
+func (itemType *clipboardArchiveItemType) UnmarshalJSON(buf []byte) error {
+	var knownItemType dto.ClipboardItemType
+
+	err := knownItemType.UnmarshalJSON(buf)
+	if err != nil {
+		knownItemType = dto.ClipboardItemTypeUnknown
+	}
+
+	*itemType = clipboardArchiveItemType(knownItemType)
+
+	return nil
+}
+
+// resolveClipboardArchiveBlobPath names the image after its SHA-256, so that images shared by
+// several items are only stored once, and so that a corrupted image can be told apart on import.
+func resolveClipboardArchiveBlobPath(imageBytes []byte) string {
+	hash := sha256.Sum256(imageBytes)
+
+	return clipboardArchiveBlobDirectory + hex.EncodeToString(hash[:]) + ".png"
+}
+
+// newClipboardArchiveProgressReporter reports the progress at most once per percent, so that large
+// archives do not flood the UI with events.
+func newClipboardArchiveProgressReporter(
+	totalItemCount int,
+	onProgress func(progress dto.ClipboardArchiveProgress),
+) func(processedItemCount int) {
+	reportedPercent := -1
+
+	return func(processedItemCount int) {
+		percent := 100
+		if totalItemCount > 0 {
+			percent = processedItemCount * 100 / totalItemCount
+		}
+
+		if percent == reportedPercent {
+			return
+		}
+
+		reportedPercent = percent
+		onProgress(dto.ClipboardArchiveProgress{
+			ProcessedItemCount: processedItemCount,
+			TotalItemCount:     totalItemCount,
+		})
+	}
+}
@@ -0,0 +1,184 @@
+package clipboard
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"time"
+
+	"cloudy-clip/desktop/internal/clipboard/dto"
+	"cloudy-clip/desktop/internal/common/database/generated/model"
+	"cloudy-clip/desktop/internal/common/exception"
+	"cloudy-clip/desktop/internal/common/logging"
+
+	"github.com/pkg/errors"
+)
+
+// ExportClipboardHistory writes the items that match the request to a zip archive at `filePath`,
+// oldest first and decrypted, along with their pinned state and timestamps. Sensitive items are
+// never exported. The archive is written next to `filePath` and only moved there once complete, so
+// that a failed export does not leave a partial archive behind.
+func ExportClipboardHistory(
+	ctx context.Context,
+	filePath string,
+	request dto.ExportClipboardHistoryRequest,
+	onProgress func(progress dto.ClipboardArchiveProgress),
+) (dto.ClipboardHistoryExportResult, error) {
+	ctx = context.WithValue(ctx, logging.LoggerContextCallSiteKey, "ExportClipboardHistory")
+
+	result, err := exportClipboardHistory(ctx, filePath, request, onProgress)
+	if err == nil {
+		logger.InfoAttrs(
+			ctx,
+			"exported clipboard history",
+			slog.Int("exportedItemCount", result.ExportedItemCount),
+			slog.Int("skippedItemCount", result.SkippedItemCount),
+		)
+
+		return result, nil
+	}
+
+	logger.ErrorAttrs(
+		ctx,
+		err,
+		"failed to export clipboard history",
+		slog.String("filePath", filePath),
+		slog.Any("request", request),
+	)
+
+	return dto.ClipboardHistoryExportResult{}, exception.NewUnknownException("failed to export clipboard history")
+}
+
+func exportClipboardHistory(
+	ctx context.Context,
+	filePath string,
+	request dto.ExportClipboardHistoryRequest,
+	onProgress func(progress dto.ClipboardArchiveProgress),
+) (dto.ClipboardHistoryExportResult, error) {
+	clipboardItemModels, err := findClipboardItemsToExport(ctx, request)
+	if err != nil {
+		return dto.ClipboardHistoryExportResult{}, err
+	}
+
+	archiveFile, err := os.CreateTemp(filepath.Dir(filePath), ".cloudy-clip-export-*.zip")
+	if err != nil {
+		return dto.ClipboardHistoryExportResult{}, errors.WithStack(err)
+	}
+
+	// Both fail harmlessly once the archive has been moved to `filePath`.
+	defer os.Remove(archiveFile.Name())
+	defer archiveFile.Close()
+
+	archiveWriter := zip.NewWriter(archiveFile)
+	manifest := clipboardArchiveManifest{
+		Version:    clipboardArchiveVersion,
+		ExportedAt: uint64(time.Now().UnixMilli()),
+		Items:      make([]clipboardArchiveItem, 0, len(clipboardItemModels)),
+	}
+	result := dto.ClipboardHistoryExportResult{FilePath: filePath}
+	writtenBlobPaths := make(map[string]bool)
+	reportProgress := newClipboardArchiveProgressReporter(len(clipboardItemModels), onProgress)
+
+	reportProgress(0)
+
+	for index, clipboardItemModel := range clipboardItemModels {
+		archiveItem, err := exportClipboardItem(archiveWriter, clipboardItemModel, writtenBlobPaths)
+		if errors.Is(err, os.ErrNotExist) {
+			logger.WarnAttrs(
+				ctx,
+				"skipped clipboard image whose file is missing",
+				slog.String("itemId", clipboardItemModel.ID),
+			)
+
+			result.SkippedItemCount++
+		} else if err != nil {
+			return dto.ClipboardHistoryExportResult{}, err
+		} else {
+			manifest.Items = append(manifest.Items, archiveItem)
+			result.ExportedItemCount++
+		}
+
+		reportProgress(index + 1)
+	}
+
+	manifestWriter, err := archiveWriter.Create(clipboardArchiveManifestName)
+	if err != nil {
+		return dto.ClipboardHistoryExportResult{}, errors.WithStack(err)
+	}
+
+	err = json.NewEncoder(manifestWriter).Encode(manifest)
+	if err != nil {
+		return dto.ClipboardHistoryExportResult{}, errors.WithStack(err)
+	}
+
+	err = archiveWriter.Close()
+	if err != nil {
+		return dto.ClipboardHistoryExportResult{}, errors.WithStack(err)
+	}
+
+	err = archiveFile.Close()
+	if err != nil {
+		return dto.ClipboardHistoryExportResult{}, errors.WithStack(err)
+	}
+
+	return result, errors.WithStack(os.Rename(archiveFile.Name(), filePath))
+}
+
+// exportClipboardItem writes the image of the item to the archive unless another item already
+// wrote the same one.
+func exportClipboardItem(
+	archiveWriter *zip.Writer,
+	clipboardItemModel model.ClipboardItem,
+	writtenBlobPaths map[string]bool,
+) (clipboardArchiveItem, error) {
+	archiveItem := clipboardArchiveItem{
+		Type:      clipboardArchiveItemType(clipboardItemModel.Type),
+		CreatedAt: clipboardItemModel.CreatedAt,
+		IsPinned:  clipboardItemModel.IsPinned,
+		PinnedAt:  clipboardItemModel.PinnedAt,
+	}
+
+	if clipboardItemModel.Type != dto.ClipboardItemTypeImage {
+		var err error
+		archiveItem.Content, err = openClipboardItemContent(clipboardItemModel)
+		if err != nil {
+			return clipboardArchiveItem{}, err
+		}
+
+		archiveItem.RichContent, err = openClipboardItemRichContent(clipboardItemModel)
+
+		return archiveItem, err
+	}
+
+	imageBytes, err := readImageFile(clipboardItemModel)
+	if err != nil {
+		return clipboardArchiveItem{}, err
+	}
+
+	archiveItem.ImageBlobPath = resolveClipboardArchiveBlobPath(imageBytes)
+	if writtenBlobPaths[archiveItem.ImageBlobPath] {
+		return archiveItem, nil
+	}
+
+	// PNGs are already compressed.
+	blobWriter, err := archiveWriter.CreateHeader(&zip.FileHeader{
+		Name:     archiveItem.ImageBlobPath,
+		Method:   zip.Store,
+		Modified: time.UnixMilli(int64(clipboardItemModel.CreatedAt)),
+	})
+	if err != nil {
+		return clipboardArchiveItem{}, errors.WithStack(err)
+	}
+
+	_, err = blobWriter.Write(imageBytes)
+	if err != nil {
+		return clipboardArchiveItem{}, errors.WithStack(err)
+	}
+
+	writtenBlobPaths[archiveItem.ImageBlobPath] = true
+
+	return archiveItem, nil
+}
@@ -0,0 +1,229 @@
+package clipboard
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+
+	"cloudy-clip/desktop/internal/clipboard/dto"
+	"cloudy-clip/desktop/internal/common/database"
+	"cloudy-clip/desktop/internal/common/exception"
+	"cloudy-clip/desktop/internal/common/logging"
+	"cloudy-clip/desktop/internal/common/utils"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	errClipboardArchiveItemIsDuplicate = errors.New("clipboard archive item is already in the history")
+	errClipboardArchiveItemIsInvalid   = errors.New("clipboard archive item is invalid")
+)
+
+// ImportClipboardHistory merges the items of an archive written by `ExportClipboardHistory` into the
+// history, keeping their pinned state and timestamps. Items that are already in the history, as
+// told by their fingerprint, are left out, so that importing the same archive twice is harmless.
+// The items imported before an error are kept.
+func ImportClipboardHistory(
+	ctx context.Context,
+	filePath string,
+	onProgress func(progress dto.ClipboardArchiveProgress),
+) (dto.ClipboardHistoryImportResult, error) {
+	ctx = context.WithValue(ctx, logging.LoggerContextCallSiteKey, "ImportClipboardHistory")
+
+	result, err := importClipboardHistory(ctx, filePath, onProgress)
+	if err == nil {
+		logger.InfoAttrs(
+			ctx,
+			"imported clipboard history",
+			slog.Int("importedItemCount", result.ImportedItemCount),
+			slog.Int("duplicateItemCount", result.DuplicateItemCount),
+			slog.Int("skippedItemCount", result.SkippedItemCount),
+		)
+
+		return result, nil
+	}
+
+	logger.ErrorAttrs(ctx, err, "failed to import clipboard history", slog.String("filePath", filePath))
+
+	return dto.ClipboardHistoryImportResult{}, exception.GetAsApplicationException(
+		err,
+		"failed to import clipboard history",
+	)
+}
+
+func importClipboardHistory(
+	ctx context.Context,
+	filePath string,
+	onProgress func(progress dto.ClipboardArchiveProgress),
+) (dto.ClipboardHistoryImportResult, error) {
+	archiveReader, err := zip.OpenReader(filePath)
+	if err != nil {
+		return dto.ClipboardHistoryImportResult{}, exception.NewValidationException(
+			"the file is not a clipboard history archive",
+		)
+	}
+
+	defer archiveReader.Close()
+
+	manifest, err := readClipboardArchiveManifest(&archiveReader.Reader)
+	if err != nil {
+		return dto.ClipboardHistoryImportResult{}, err
+	}
+
+	result := dto.ClipboardHistoryImportResult{FilePath: filePath}
+	reportProgress := newClipboardArchiveProgressReporter(len(manifest.Items), onProgress)
+
+	reportProgress(0)
+
+	for index, archiveItem := range manifest.Items {
+		err = importClipboardArchiveItem(&archiveReader.Reader, archiveItem)
+
+		switch {
+		case err == nil:
+			result.ImportedItemCount++
+
+		case errors.Is(err, errClipboardArchiveItemIsDuplicate):
+			result.DuplicateItemCount++
+
+		case errors.Is(err, errClipboardArchiveItemIsInvalid):
+			logger.WarnAttrs(
+				ctx,
+				"skipped invalid clipboard archive item",
+				slog.Int("itemIndex", index),
+				slog.String("error", err.Error()),
+			)
+
+			result.SkippedItemCount++
+
+		default:
+			return dto.ClipboardHistoryImportResult{}, err
+		}
+
+		reportProgress(index + 1)
+	}
+
+	return result, nil
+}
+
+func readClipboardArchiveManifest(archiveReader *zip.Reader) (clipboardArchiveManifest, error) {
+	manifestFile, err := archiveReader.Open(clipboardArchiveManifestName)
+	if err != nil {
+		return clipboardArchiveManifest{}, exception.NewValidationException("the archive has no manifest")
+	}
+
+	defer manifestFile.Close()
+
+	var manifest clipboardArchiveManifest
+
+	err = json.NewDecoder(manifestFile).Decode(&manifest)
+	if err != nil {
+		return clipboardArchiveManifest{}, exception.NewValidationException("the manifest of the archive is invalid")
+	}
+
+	if manifest.Version < 1 || manifest.Version > clipboardArchiveVersion {
+		return clipboardArchiveManifest{}, exception.NewValidationException(
+			fmt.Sprintf("archives of version %d are not supported", manifest.Version),
+		)
+	}
+
+	return manifest, nil
+}
+
+// importClipboardArchiveItem stores the item the way it would have been captured, encrypted when
+// encryption is turned on, but without going through the ignore and sensitive content rules again.
+func importClipboardArchiveItem(archiveReader *zip.Reader, archiveItem clipboardArchiveItem) error {
+	item := dto.ClipboardItem{
+		Type:      dto.ClipboardItemType(archiveItem.Type),
+		Content:   archiveItem.Content,
+		CreatedAt: archiveItem.CreatedAt,
+		IsPinned:  archiveItem.IsPinned,
+	}
+
+	if item.IsPinned {
+		item.PinnedAt = archiveItem.PinnedAt
+	}
+
+	if item.Type == dto.ClipboardItemTypeHtml || item.Type == dto.ClipboardItemTypeRtf {
+		item.RichContent = archiveItem.RichContent
+	}
+
+	var contentBytes []byte
+	var image *capturedImage
+
+	switch item.Type {
+	case dto.ClipboardItemTypeImage:
+		imageBytes, err := readClipboardArchiveBlob(archiveReader, archiveItem.ImageBlobPath)
+		if err != nil {
+			return err
+		}
+
+		newImage, err := newCapturedImage(imageBytes)
+		if err != nil {
+			return errors.WithMessage(errClipboardArchiveItemIsInvalid, "the image is not a PNG")
+		}
+
+		item.Content = ""
+		contentBytes = imageBytes
+		image = &newImage
+
+	case dto.ClipboardItemTypeText,
+		dto.ClipboardItemTypeUrl,
+		dto.ClipboardItemTypeHtml,
+		dto.ClipboardItemTypeRtf,
+		dto.ClipboardItemTypeFile,
+		dto.ClipboardItemTypeColor:
+		if item.Content == "" {
+			return errors.WithMessage(errClipboardArchiveItemIsInvalid, "the content is empty")
+		}
+
+		contentBytes = []byte(item.Content)
+
+	default:
+		return errors.WithMessage(errClipboardArchiveItemIsInvalid, "the type is unknown")
+	}
+
+	contentFingerprint := fingerprintClipboardItem(contentBytes, item.RichContent)
+
+	_, err := findClipboardItemByFingerprint(contentFingerprint)
+	if err == nil {
+		return errClipboardArchiveItemIsDuplicate
+	}
+
+	if !database.IsEmptyResultError(err) {
+		return err
+	}
+
+	item.Id = utils.Generate()
+
+	return persistClipboardItem(&item, contentFingerprint, image)
+}
+
+// readClipboardArchiveBlob checks the image against the hash it is named after.
+func readClipboardArchiveBlob(archiveReader *zip.Reader, blobPath string) ([]byte, error) {
+	blobFile, err := archiveReader.Open(blobPath)
+	if err != nil {
+		return nil, errors.WithMessage(errClipboardArchiveItemIsInvalid, "the image is missing")
+	}
+
+	defer blobFile.Close()
+
+	blobFileInfo, err := blobFile.Stat()
+	if err != nil || blobFileInfo.Size() > maxClipboardArchiveBlobSize {
+		return nil, errors.WithMessage(errClipboardArchiveItemIsInvalid, "the image is too large")
+	}
+
+	// The size recorded in the archive is not trusted, reading stops right past the limit.
+	imageBytes, err := io.ReadAll(io.LimitReader(blobFile, maxClipboardArchiveBlobSize+1))
+	if len(imageBytes) > maxClipboardArchiveBlobSize {
+		return nil, errors.WithMessage(errClipboardArchiveItemIsInvalid, "the image is too large")
+	}
+
+	if err != nil || resolveClipboardArchiveBlobPath(imageBytes) != blobPath {
+		return nil, errors.WithMessage(errClipboardArchiveItemIsInvalid, "the image is corrupted")
+	}
+
+	return imageBytes, nil
+}
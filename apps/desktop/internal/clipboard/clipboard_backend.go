@@ -0,0 +1,69 @@
+package clipboard
+
+import (
+	"github.com/pkg/errors"
+)
+
+var (
+	ErrClipboardBackendUnsupported = errors.New("clipboard is not supported on this platform")
+)
+
+// ClipboardContent is a snapshot of the system clipboard, images are always PNG encoded.
+// Text is nil when the clipboard holds no text, and everything is empty when the clipboard is empty.
+// Most applications offer a plain-text rendering of HTML, RTF, files and colors in Text as well.
+type ClipboardContent struct {
+	Text *string
+	Html *string
+	Rtf  *string
+	// The absolute paths of the copied files.
+	FilePaths []string
+	// Formatted like `#rrggbb`, or like `#rrggbbaa` when the color is not opaque.
+	Color *string
+	Image []byte
+}
+
+func NewTextClipboardContent(text string) ClipboardContent {
+	return ClipboardContent{
+		Text: &text,
+	}
+}
+
+func NewImageClipboardContent(image []byte) ClipboardContent {
+	return ClipboardContent{
+		Image: image,
+	}
+}
+
+func (content ClipboardContent) IsEmpty() bool {
+	return content.Text == nil &&
+		content.Html == nil &&
+		content.Rtf == nil &&
+		len(content.FilePaths) == 0 &&
+		content.Color == nil &&
+		len(content.Image) == 0
+}
+
+// ClipboardBackend reads from and writes to the system clipboard of the current platform.
+type ClipboardBackend interface {
+	// ReadLatestItem returns the most recent item on the clipboard.
+	ReadLatestItem() (ClipboardContent, error)
+	// WriteItem replaces the contents of the clipboard. Files take precedence over the color, which
+	// takes precedence over text along with its HTML and RTF, which take precedence over the image.
+	WriteItem(content ClipboardContent) error
+	// ChangeCount increases every time the contents of the clipboard change, so that callers can
+	// tell whether there is anything new without reading the clipboard.
+	ChangeCount() (int64, error)
+}
+
+var (
+	backend ClipboardBackend = newPlatformClipboardBackend()
+)
+
+func GetClipboardBackend() ClipboardBackend {
+	return backend
+}
+
+// SetClipboardBackend swaps the backend of the current platform, e.g. for `InMemoryClipboardBackend` in tests.
+func SetClipboardBackend(clipboardBackend ClipboardBackend) {
+	backend = clipboardBackend
+}
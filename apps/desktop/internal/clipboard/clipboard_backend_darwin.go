@@ -0,0 +1,289 @@
+package clipboard
+
+/*
+#cgo CFLAGS: -x objective-c -framework Cocoa
+#cgo LDFLAGS: -framework Cocoa
+#import <Cocoa/Cocoa.h>
+#include <stdlib.h>
+
+// Every string is a malloc'd UTF-8 string or NULL, and filePaths holds one absolute path per line.
+// The color components are sRGB values between 0 and 1, only set when hasColor is 1.
+typedef struct {
+    char *text;
+    char *html;
+    char *rtf;
+    char *filePaths;
+    int hasColor;
+    double red;
+    double green;
+    double blue;
+    double alpha;
+    void *imageData;
+    int imageLength;
+} PasteboardContent;
+
+static char *copyString(NSString *s) {
+    return s ? strdup([s UTF8String]) : NULL;
+}
+
+// RTF is 7-bit ASCII, anything else in it is escaped.
+static char *copyData(NSData *data) {
+    if (!data) {
+        return NULL;
+    }
+    char *buf = malloc(data.length + 1);
+    memcpy(buf, data.bytes, data.length);
+    buf[data.length] = '\0';
+    return buf;
+}
+
+void freePasteboardContent(PasteboardContent *content) {
+    free(content->text);
+    free(content->html);
+    free(content->rtf);
+    free(content->filePaths);
+    free(content->imageData);
+}
+
+// Fetch the first (most recent) pasteboard item, along with every file on the pasteboard.
+// Images are always returned as PNG bytes.
+// Returns 0 on success, -1 if empty.
+int getLatestPasteboardItem(PasteboardContent *content)
+{
+    memset(content, 0, sizeof(PasteboardContent));
+
+    NSPasteboard *pb = [NSPasteboard generalPasteboard];
+    NSArray<NSPasteboardItem*> *items = [pb pasteboardItems];
+    if (items.count == 0) {
+        return -1;
+    }
+    NSPasteboardItem *item = items[0];
+
+    // Text, HTML and RTF
+    content->text = copyString([item stringForType:NSPasteboardTypeString]);
+    content->html = copyString([item stringForType:NSPasteboardTypeHTML]);
+    content->rtf = copyData([item dataForType:NSPasteboardTypeRTF]);
+
+    // Files
+    NSArray<NSURL*> *urls = [pb readObjectsForClasses:@[[NSURL class]]
+                                              options:@{NSPasteboardURLReadingFileURLsOnlyKey: @YES}];
+    if (urls.count > 0) {
+        NSMutableArray<NSString*> *paths = [NSMutableArray arrayWithCapacity:urls.count];
+        for (NSURL *url in urls) {
+            [paths addObject:url.path];
+        }
+        content->filePaths = copyString([paths componentsJoinedByString:@"\n"]);
+    }
+
+    // Color
+    if ([[pb types] containsObject:NSPasteboardTypeColor]) {
+        NSColor *color = [[NSColor colorFromPasteboard:pb]
+                           colorUsingColorSpace:[NSColorSpace sRGBColorSpace]];
+        if (color) {
+            content->hasColor = 1;
+            content->red = color.redComponent;
+            content->green = color.greenComponent;
+            content->blue = color.blueComponent;
+            content->alpha = color.alphaComponent;
+        }
+    }
+
+    // Try raw PNG first
+    NSData *png = [item dataForType:NSPasteboardTypePNG];
+    if (!png) {
+        // Fallback: TIFF → NSImage → PNG
+        NSData *tiff = [item dataForType:NSPasteboardTypeTIFF];
+        if (tiff) {
+            NSImage *img = [[NSImage alloc] initWithData:tiff];
+            NSBitmapImageRep *rep =
+              [[NSBitmapImageRep alloc] initWithData:[img TIFFRepresentation]];
+            png = [rep representationUsingType:NSPNGFileType
+                                 properties:@{}];
+        }
+    }
+
+    if (png) {
+        size_t len = png.length;
+        void *buf = malloc(len);
+        memcpy(buf, png.bytes, len);
+        content->imageData   = buf;
+        content->imageLength = (int)len;
+    }
+
+    return 0;
+}
+
+// Replace the pasteboard contents with the files, the color, the string along with its HTML
+// and RTF, or the PNG bytes, in that order of precedence.
+// Returns 0 on success, -1 if the pasteboard rejected the data.
+int writePasteboardItem(const PasteboardContent *content)
+{
+    NSPasteboard *pb = [NSPasteboard generalPasteboard];
+    [pb clearContents];
+
+    BOOL ok = NO;
+    if (content->filePaths) {
+        NSString *filePaths = [NSString stringWithUTF8String:content->filePaths];
+        NSMutableArray<NSURL*> *urls = [NSMutableArray array];
+        for (NSString *path in [filePaths componentsSeparatedByString:@"\n"]) {
+            if (path.length > 0) {
+                [urls addObject:[NSURL fileURLWithPath:path]];
+            }
+        }
+        ok = [pb writeObjects:urls];
+    } else if (content->hasColor) {
+        NSColor *color = [NSColor colorWithSRGBRed:content->red
+                                             green:content->green
+                                              blue:content->blue
+                                             alpha:content->alpha];
+        ok = [pb writeObjects:@[color]];
+        if (ok && content->text) {
+            [pb setString:[NSString stringWithUTF8String:content->text]
+                  forType:NSPasteboardTypeString];
+        }
+    } else if (content->text || content->html || content->rtf) {
+        NSPasteboardItem *item = [[NSPasteboardItem alloc] init];
+        if (content->text) {
+            [item setString:[NSString stringWithUTF8String:content->text]
+                    forType:NSPasteboardTypeString];
+        }
+        if (content->html) {
+            [item setString:[NSString stringWithUTF8String:content->html]
+                    forType:NSPasteboardTypeHTML];
+        }
+        if (content->rtf) {
+            [item setData:[NSData dataWithBytes:content->rtf length:strlen(content->rtf)]
+                  forType:NSPasteboardTypeRTF];
+        }
+        ok = [pb writeObjects:@[item]];
+        [item release];
+    } else if (content->imageData && content->imageLength > 0) {
+        ok = [pb setData:[NSData dataWithBytes:content->imageData length:content->imageLength]
+                 forType:NSPasteboardTypePNG];
+    }
+
+    return ok ? 0 : -1;
+}
+
+long getPasteboardChangeCount() {
+    return (long)[[NSPasteboard generalPasteboard] changeCount];
+}
+*/
+import "C"
+import (
+	"math"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+type darwinClipboardBackend struct {
+}
+
+func newPlatformClipboardBackend() ClipboardBackend {
+	return darwinClipboardBackend{}
+}
+
+func (darwinBackend darwinClipboardBackend) ReadLatestItem() (ClipboardContent, error) {
+	var cContent C.PasteboardContent
+
+	if ret := C.getLatestPasteboardItem(&cContent); ret != 0 {
+		return ClipboardContent{}, nil
+	}
+	defer C.freePasteboardContent(&cContent)
+
+	content := ClipboardContent{
+		Text: goStringOrNil(cContent.text),
+		Html: goStringOrNil(cContent.html),
+		Rtf:  goStringOrNil(cContent.rtf),
+	}
+
+	if cContent.filePaths != nil {
+		content.FilePaths = strings.Split(C.GoString(cContent.filePaths), "\n")
+	}
+
+	if cContent.hasColor != 0 {
+		color := formatColor(
+			toColorComponent(cContent.red),
+			toColorComponent(cContent.green),
+			toColorComponent(cContent.blue),
+			toColorComponent(cContent.alpha),
+		)
+		content.Color = &color
+	}
+
+	if cContent.imageData != nil && cContent.imageLength > 0 {
+		content.Image = C.GoBytes(cContent.imageData, cContent.imageLength)
+	}
+
+	return content, nil
+}
+
+func (darwinBackend darwinClipboardBackend) WriteItem(content ClipboardContent) error {
+	var cContent C.PasteboardContent
+
+	cContent.text = cStringOrNil(content.Text)
+	cContent.html = cStringOrNil(content.Html)
+	cContent.rtf = cStringOrNil(content.Rtf)
+
+	if len(content.FilePaths) > 0 {
+		cContent.filePaths = C.CString(strings.Join(content.FilePaths, "\n"))
+	}
+
+	if content.Color != nil {
+		red, green, blue, alpha, err := parseColor(*content.Color)
+		if err != nil {
+			return err
+		}
+
+		cContent.hasColor = 1
+		cContent.red = fromColorComponent(red)
+		cContent.green = fromColorComponent(green)
+		cContent.blue = fromColorComponent(blue)
+		cContent.alpha = fromColorComponent(alpha)
+	}
+
+	if len(content.Image) > 0 {
+		cContent.imageData = C.CBytes(content.Image)
+		cContent.imageLength = C.int(len(content.Image))
+	}
+
+	// Every pointer in the struct was allocated with malloc.
+	defer C.freePasteboardContent(&cContent)
+
+	if ret := C.writePasteboardItem(&cContent); ret != 0 {
+		return errors.New("failed to write to the pasteboard")
+	}
+
+	return nil
+}
+
+func (darwinBackend darwinClipboardBackend) ChangeCount() (int64, error) {
+	return int64(C.getPasteboardChangeCount()), nil
+}
+
+func goStringOrNil(cString *C.char) *string {
+	if cString == nil {
+		return nil
+	}
+
+	value := C.GoString(cString)
+
+	return &value
+}
+
+func cStringOrNil(value *string) *C.char {
+	if value == nil {
+		return nil
+	}
+
+	return C.CString(*value)
+}
+
+func toColorComponent(value C.double) uint8 {
+	return uint8(math.Round(math.Max(0, math.Min(1, float64(value))) * opaqueAlpha))
+}
+
+func fromColorComponent(value uint8) C.double {
+	return C.double(float64(value) / opaqueAlpha)
+}
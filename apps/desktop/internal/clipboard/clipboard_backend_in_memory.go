@@ -0,0 +1,67 @@
+package clipboard
+
+import (
+	"bytes"
+	"slices"
+	"sync"
+)
+
+// InMemoryClipboardBackend stands in for the system clipboard where there is none, e.g. in tests.
+type InMemoryClipboardBackend struct {
+	mutex       sync.Mutex
+	content     ClipboardContent
+	changeCount int64
+}
+
+func NewInMemoryClipboardBackend() *InMemoryClipboardBackend {
+	return &InMemoryClipboardBackend{}
+}
+
+func (inMemoryBackend *InMemoryClipboardBackend) ReadLatestItem() (ClipboardContent, error) {
+	inMemoryBackend.mutex.Lock()
+	defer inMemoryBackend.mutex.Unlock()
+
+	return copyClipboardContent(inMemoryBackend.content), nil
+}
+
+func (inMemoryBackend *InMemoryClipboardBackend) WriteItem(content ClipboardContent) error {
+	inMemoryBackend.mutex.Lock()
+	defer inMemoryBackend.mutex.Unlock()
+
+	if content.Text != nil || len(content.FilePaths) > 0 || content.Color != nil {
+		content.Image = nil
+	}
+
+	inMemoryBackend.content = copyClipboardContent(content)
+	inMemoryBackend.changeCount++
+
+	return nil
+}
+
+func (inMemoryBackend *InMemoryClipboardBackend) ChangeCount() (int64, error) {
+	inMemoryBackend.mutex.Lock()
+	defer inMemoryBackend.mutex.Unlock()
+
+	return inMemoryBackend.changeCount, nil
+}
+
+func copyClipboardContent(content ClipboardContent) ClipboardContent {
+	return ClipboardContent{
+		Text:      copyString(content.Text),
+		Html:      copyString(content.Html),
+		Rtf:       copyString(content.Rtf),
+		FilePaths: slices.Clone(content.FilePaths),
+		Color:     copyString(content.Color),
+		Image:     bytes.Clone(content.Image),
+	}
+}
+
+func copyString(value *string) *string {
+	if value == nil {
+		return nil
+	}
+
+	valueCopy := *value
+
+	return &valueCopy
+}
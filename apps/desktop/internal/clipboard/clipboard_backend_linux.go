@@ -0,0 +1,383 @@
+package clipboard
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"io"
+	"net/url"
+	"os"
+	"os/exec"
+	"slices"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/cespare/xxhash/v2"
+	"github.com/pkg/errors"
+)
+
+const (
+	pngMimeType     = "image/png"
+	htmlMimeType    = "text/html"
+	uriListMimeType = "text/uri-list"
+	// Offered by GTK applications, as four native-endian 16-bit values for red, green, blue and alpha.
+	colorMimeType = "application/x-color"
+	// Offered by X11 selections, the time at which the current owner took the selection.
+	timestampTarget = "TIMESTAMP"
+	// How long writing waits for `wl-paste --watch` to report the write.
+	watchedWriteTimeout = time.Second
+)
+
+var (
+	// In order of preference, X11 selections advertise the legacy atoms and Wayland ones the MIME types.
+	textTargets = []string{"text/plain;charset=utf-8", "UTF8_STRING", "text/plain", "STRING", "TEXT"}
+	rtfTargets  = []string{"text/rtf", "application/rtf", "text/richtext"}
+)
+
+// linuxClipboardBackend talks to `wl-clipboard` under Wayland and to `xclip` under X11, one of which
+// has to be installed. Neither exposes a change counter, so under Wayland the counter increases
+// whenever `wl-paste --watch` reports a new selection. Under X11, and under compositors that cannot
+// be watched, it increases whenever the fingerprint of the selection differs from the one seen by
+// the previous call. Both tools only offer a single target when writing, so files are written as a
+// URI list and everything else as plain text.
+type linuxClipboardBackend struct {
+	mutex           sync.Mutex
+	isWayland       bool
+	changeCount     int64
+	lastFingerprint uint64
+	isWatching      bool
+	hasWatchStopped bool
+	// Closed, then replaced, whenever the watcher reports a change.
+	watchedChange chan struct{}
+}
+
+func newPlatformClipboardBackend() ClipboardBackend {
+	return &linuxClipboardBackend{
+		isWayland: os.Getenv("WAYLAND_DISPLAY") != "",
+	}
+}
+
+func (linuxBackend *linuxClipboardBackend) ReadLatestItem() (ClipboardContent, error) {
+	targets, err := linuxBackend.listTargets()
+	if err != nil || len(targets) == 0 {
+		return ClipboardContent{}, err
+	}
+
+	content := ClipboardContent{}
+
+	content.Text, err = linuxBackend.readFirstTarget(targets, textTargets)
+	if err != nil {
+		return ClipboardContent{}, err
+	}
+
+	content.Html, err = linuxBackend.readFirstTarget(targets, []string{htmlMimeType})
+	if err != nil {
+		return ClipboardContent{}, err
+	}
+
+	content.Rtf, err = linuxBackend.readFirstTarget(targets, rtfTargets)
+	if err != nil {
+		return ClipboardContent{}, err
+	}
+
+	if slices.Contains(targets, uriListMimeType) {
+		uriList, err := linuxBackend.readTarget(uriListMimeType)
+		if err != nil {
+			return ClipboardContent{}, err
+		}
+
+		content.FilePaths = parseFileUriList(string(uriList))
+	}
+
+	if slices.Contains(targets, colorMimeType) {
+		color, err := linuxBackend.readTarget(colorMimeType)
+		if err != nil {
+			return ClipboardContent{}, err
+		}
+
+		content.Color = parseColorTarget(color)
+	}
+
+	if slices.Contains(targets, pngMimeType) {
+		content.Image, err = linuxBackend.readTarget(pngMimeType)
+		if err != nil {
+			return ClipboardContent{}, err
+		}
+	}
+
+	return content, nil
+}
+
+func (linuxBackend *linuxClipboardBackend) WriteItem(content ClipboardContent) error {
+	var input []byte
+	target := textTargets[0]
+
+	if len(content.FilePaths) > 0 {
+		input = []byte(formatFileUriList(content.FilePaths))
+		target = uriListMimeType
+	} else if content.Text != nil {
+		input = []byte(*content.Text)
+	} else if len(content.Image) > 0 {
+		input = content.Image
+		target = pngMimeType
+	} else {
+		return nil
+	}
+
+	command := exec.Command("xclip", "-selection", "clipboard", "-in", "-target", target)
+	if linuxBackend.isWayland {
+		command = exec.Command("wl-copy", "--type", target)
+	}
+
+	command.Stdin = bytes.NewReader(input)
+
+	linuxBackend.mutex.Lock()
+	watchedChange := linuxBackend.watchedChange
+	linuxBackend.mutex.Unlock()
+
+	err := command.Run()
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	// The watcher reports the write asynchronously, the change count read right after writing has to
+	// include it nonetheless.
+	if watchedChange != nil {
+		select {
+		case <-watchedChange:
+		case <-time.After(watchedWriteTimeout):
+		}
+	}
+
+	return nil
+}
+
+func (linuxBackend *linuxClipboardBackend) ChangeCount() (int64, error) {
+	if linuxBackend.isWayland && linuxBackend.watchChanges() {
+		linuxBackend.mutex.Lock()
+		defer linuxBackend.mutex.Unlock()
+
+		return linuxBackend.changeCount, nil
+	}
+
+	selectionFingerprint, err := linuxBackend.fingerprintSelection()
+	if err != nil {
+		return 0, err
+	}
+
+	linuxBackend.mutex.Lock()
+	defer linuxBackend.mutex.Unlock()
+
+	if selectionFingerprint != linuxBackend.lastFingerprint {
+		linuxBackend.lastFingerprint = selectionFingerprint
+		linuxBackend.changeCount++
+	}
+
+	return linuxBackend.changeCount, nil
+}
+
+// watchChanges starts `wl-paste --watch` unless it already runs, and tells whether it keeps the
+// change counter up to date. It only runs under compositors that support the data control
+// protocol, once it stops the changes are polled instead.
+func (linuxBackend *linuxClipboardBackend) watchChanges() bool {
+	linuxBackend.mutex.Lock()
+	defer linuxBackend.mutex.Unlock()
+
+	if linuxBackend.isWatching || linuxBackend.hasWatchStopped {
+		return linuxBackend.isWatching
+	}
+
+	// `echo` prints a line for every new selection.
+	command := exec.Command("wl-paste", "--watch", "echo")
+	// Otherwise the watcher would outlive the application.
+	command.SysProcAttr = &syscall.SysProcAttr{Pdeathsig: syscall.SIGTERM}
+
+	output, err := command.StdoutPipe()
+	if err == nil {
+		err = command.Start()
+	}
+
+	if err != nil {
+		linuxBackend.hasWatchStopped = true
+
+		return false
+	}
+
+	linuxBackend.isWatching = true
+	linuxBackend.watchedChange = make(chan struct{})
+
+	go linuxBackend.countWatchedChanges(command, output)
+
+	return true
+}
+
+func (linuxBackend *linuxClipboardBackend) countWatchedChanges(command *exec.Cmd, output io.Reader) {
+	scanner := bufio.NewScanner(output)
+
+	for scanner.Scan() {
+		linuxBackend.mutex.Lock()
+		linuxBackend.changeCount++
+		close(linuxBackend.watchedChange)
+		linuxBackend.watchedChange = make(chan struct{})
+		linuxBackend.mutex.Unlock()
+	}
+
+	_ = command.Wait()
+
+	linuxBackend.mutex.Lock()
+	defer linuxBackend.mutex.Unlock()
+
+	linuxBackend.isWatching = false
+	linuxBackend.hasWatchStopped = true
+	linuxBackend.watchedChange = nil
+}
+
+// listTargets returns nil when the clipboard is empty.
+func (linuxBackend *linuxClipboardBackend) listTargets() ([]string, error) {
+	command := exec.Command("xclip", "-selection", "clipboard", "-out", "-target", "TARGETS")
+	if linuxBackend.isWayland {
+		command = exec.Command("wl-paste", "--list-types")
+	}
+
+	output, err := command.Output()
+	if err != nil {
+		var exitError *exec.ExitError
+		// Both tools exit with a non-zero status when nothing owns the clipboard.
+		if errors.As(err, &exitError) {
+			return nil, nil
+		}
+
+		return nil, errors.WithStack(err)
+	}
+
+	return strings.Fields(string(output)), nil
+}
+
+// readFirstTarget reads the first of the preferred targets that the clipboard offers, if any.
+func (linuxBackend *linuxClipboardBackend) readFirstTarget(targets []string, preferredTargets []string) (*string, error) {
+	for _, preferredTarget := range preferredTargets {
+		if !slices.Contains(targets, preferredTarget) {
+			continue
+		}
+
+		output, err := linuxBackend.readTarget(preferredTarget)
+		if err != nil {
+			return nil, err
+		}
+
+		value := string(output)
+
+		return &value, nil
+	}
+
+	return nil, nil
+}
+
+func (linuxBackend *linuxClipboardBackend) readTarget(target string) ([]byte, error) {
+	command := exec.Command("xclip", "-selection", "clipboard", "-out", "-target", target)
+	if linuxBackend.isWayland {
+		command = exec.Command("wl-paste", "--no-newline", "--type", target)
+	}
+
+	output, err := command.Output()
+
+	return output, errors.WithStack(err)
+}
+
+// fingerprintSelection hashes the offered targets and the text rather than the whole content, which
+// would take reading every target, images included, on every poll. Under X11, the time at which the
+// selection was taken tells apart two images copied one after the other. Without it, the image itself
+// has to be hashed, as two images offer the same targets and no text.
+func (linuxBackend *linuxClipboardBackend) fingerprintSelection() (uint64, error) {
+	targets, err := linuxBackend.listTargets()
+	if err != nil {
+		return 0, err
+	}
+
+	digest := xxhash.New()
+	_, _ = digest.WriteString(strings.Join(targets, "\n"))
+	_, _ = digest.Write([]byte{0})
+
+	isTimestamped := false
+
+	if !linuxBackend.isWayland && slices.Contains(targets, timestampTarget) {
+		// Not every application answers it.
+		timestamp, err := linuxBackend.readTarget(timestampTarget)
+		if err == nil && len(timestamp) > 0 {
+			_, _ = digest.Write(timestamp)
+			isTimestamped = true
+		}
+	}
+
+	if !isTimestamped && slices.Contains(targets, pngMimeType) {
+		imageBytes, err := linuxBackend.readTarget(pngMimeType)
+		if err != nil {
+			return 0, err
+		}
+
+		_, _ = digest.Write(imageBytes)
+	}
+
+	_, _ = digest.Write([]byte{0})
+
+	text, err := linuxBackend.readFirstTarget(targets, textTargets)
+	if err != nil {
+		return 0, err
+	}
+
+	if text != nil {
+		_, _ = digest.WriteString(*text)
+	}
+
+	return digest.Sum64(), nil
+}
+
+// parseFileUriList returns the local paths of the `file://` URIs, lines starting with `#` are comments.
+func parseFileUriList(uriList string) []string {
+	var filePaths []string
+
+	for _, line := range strings.Split(uriList, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		uri, err := url.Parse(line)
+		if err != nil || uri.Scheme != "file" || uri.Path == "" {
+			continue
+		}
+
+		filePaths = append(filePaths, uri.Path)
+	}
+
+	return filePaths
+}
+
+func formatFileUriList(filePaths []string) string {
+	uris := make([]string, 0, len(filePaths))
+
+	for _, filePath := range filePaths {
+		uris = append(uris, (&url.URL{Scheme: "file", Path: filePath}).String())
+	}
+
+	// Lines are separated by CRLF, as per RFC 2483.
+	return strings.Join(uris, "\r\n") + "\r\n"
+}
+
+// parseColorTarget returns nil unless the target holds the four 16-bit values, of which only the
+// most significant byte is kept.
+func parseColorTarget(target []byte) *string {
+	if len(target) != 8 {
+		return nil
+	}
+
+	component := func(index int) uint8 {
+		return uint8(binary.NativeEndian.Uint16(target[index*2:]) >> 8)
+	}
+
+	color := formatColor(component(0), component(1), component(2), component(3))
+
+	return &color
+}
@@ -0,0 +1,22 @@
+//go:build !darwin && !linux
+
+package clipboard
+
+type unsupportedClipboardBackend struct {
+}
+
+func newPlatformClipboardBackend() ClipboardBackend {
+	return unsupportedClipboardBackend{}
+}
+
+func (unsupportedBackend unsupportedClipboardBackend) ReadLatestItem() (ClipboardContent, error) {
+	return ClipboardContent{}, ErrClipboardBackendUnsupported
+}
+
+func (unsupportedBackend unsupportedClipboardBackend) WriteItem(_ ClipboardContent) error {
+	return ErrClipboardBackendUnsupported
+}
+
+func (unsupportedBackend unsupportedClipboardBackend) ChangeCount() (int64, error) {
+	return 0, ErrClipboardBackendUnsupported
+}
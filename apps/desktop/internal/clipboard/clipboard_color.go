@@ -0,0 +1,41 @@
+package clipboard
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	opaqueAlpha = 0xff
+)
+
+// formatColor returns `#rrggbb`, or `#rrggbbaa` when the color is not opaque.
+func formatColor(red uint8, green uint8, blue uint8, alpha uint8) string {
+	if alpha == opaqueAlpha {
+		return fmt.Sprintf("#%02x%02x%02x", red, green, blue)
+	}
+
+	return fmt.Sprintf("#%02x%02x%02x%02x", red, green, blue, alpha)
+}
+
+// parseColor reads back a color returned by `formatColor`.
+func parseColor(color string) (red uint8, green uint8, blue uint8, alpha uint8, err error) {
+	hexDigits, hasPrefix := strings.CutPrefix(color, "#")
+	if !hasPrefix || (len(hexDigits) != 6 && len(hexDigits) != 8) {
+		return 0, 0, 0, 0, errors.Errorf("invalid color '%s'", color)
+	}
+
+	if len(hexDigits) == 6 {
+		hexDigits += "ff"
+	}
+
+	value, err := strconv.ParseUint(hexDigits, 16, 32)
+	if err != nil {
+		return 0, 0, 0, 0, errors.WithStack(err)
+	}
+
+	return uint8(value >> 24), uint8(value >> 16), uint8(value >> 8), uint8(value), nil
+}
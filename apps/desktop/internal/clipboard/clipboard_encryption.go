@@ -0,0 +1,456 @@
+package clipboard
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+
+	"cloudy-clip/desktop/internal/clipboard/dto"
+	"cloudy-clip/desktop/internal/common/database"
+	"cloudy-clip/desktop/internal/common/database/generated/model"
+	"cloudy-clip/desktop/internal/common/encryption"
+	"cloudy-clip/desktop/internal/common/exception"
+	"cloudy-clip/desktop/internal/common/logging"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	contentKeyPurpose     = "clipboard-item-content"
+	fingerprintKeyPurpose = "clipboard-item-fingerprint"
+	imageBlobKeyPurpose   = "clipboard-image-blob"
+	// Appended to the item id that the rich content is bound to, so that it cannot be swapped with the content,
+	// and to the blob hash that the thumbnail is bound to.
+	richContentAssociatedDataSuffix = ":rich-content"
+	thumbnailAssociatedDataSuffix   = ":thumbnail"
+)
+
+var (
+	errEncryptionKeyUnavailable = errors.New("encryption key is not available")
+	encryptionKeyStore          encryption.KeyStore
+	// Held while encryption is turned on or off, so that it cannot be done twice at the same time.
+	encryptionUpdateMutex sync.Mutex
+	encryptionMutex       sync.RWMutex
+	isEncryptionEnabled   bool
+	// Nil until the key has been loaded, items that are already encrypted need it even when
+	// encryption has since been turned off.
+	encryptionKeys *clipboardEncryptionKeys
+)
+
+type clipboardEncryptionKeys struct {
+	content     []byte
+	fingerprint []byte
+	imageBlob   []byte
+}
+
+func newClipboardEncryptionKeys(key []byte) *clipboardEncryptionKeys {
+	return &clipboardEncryptionKeys{
+		content:     encryption.DeriveKey(key, contentKeyPurpose),
+		fingerprint: encryption.DeriveKey(key, fingerprintKeyPurpose),
+		imageBlob:   encryption.DeriveKey(key, imageBlobKeyPurpose),
+	}
+}
+
+func setEncryptionState(isEnabled bool, keys *clipboardEncryptionKeys) {
+	encryptionMutex.Lock()
+	defer encryptionMutex.Unlock()
+
+	isEncryptionEnabled = isEnabled
+	encryptionKeys = keys
+}
+
+func getEncryptionState() (bool, *clipboardEncryptionKeys) {
+	encryptionMutex.RLock()
+	defer encryptionMutex.RUnlock()
+
+	return isEncryptionEnabled, encryptionKeys
+}
+
+// InitializeEncryption loads the key when the history is encrypted, then finishes encrypting or
+// decrypting the items in place in case the app quit before that was done.
+func InitializeEncryption(ctx context.Context) {
+	ctx = context.WithValue(ctx, logging.LoggerContextCallSiteKey, "InitializeEncryption")
+	if encryptionKeyStore == nil {
+		encryptionKeyStore = encryption.NewKeyStore()
+	}
+
+	err := initializeEncryption(ctx)
+	if err != nil {
+		logger.ErrorAttrs(ctx, err, "failed to initialize encryption")
+	}
+}
+
+// SetEncryptionKeyStore swaps the key store of the current platform, e.g. for `encryption.InMemoryKeyStore`
+// in tests, and must be called before `InitializeEncryption`.
+func SetEncryptionKeyStore(keyStore encryption.KeyStore) {
+	encryptionKeyStore = keyStore
+}
+
+func initializeEncryption(ctx context.Context) error {
+	isEnabled := false
+
+	encryptionSettingsModel, err := findEncryptionSettings()
+	if err == nil {
+		isEnabled = encryptionSettingsModel.IsEnabled
+	} else if !database.IsEmptyResultError(err) {
+		return err
+	}
+
+	// Capturing fails rather than storing items in plaintext until the key can be loaded.
+	setEncryptionState(isEnabled, nil)
+
+	encryptedItemCount, err := countEncryptedClipboardItems()
+	if err != nil || (!isEnabled && encryptedItemCount == 0) {
+		return err
+	}
+
+	key, err := encryptionKeyStore.LoadKey()
+	if err != nil {
+		return err
+	}
+
+	setEncryptionState(isEnabled, newClipboardEncryptionKeys(key))
+
+	return convertClipboardItemsInPlace(ctx, isEnabled)
+}
+
+func GetEncryptionSettings() dto.EncryptionSettings {
+	isEnabled, keys := getEncryptionState()
+
+	return dto.EncryptionSettings{
+		IsEnabled:      isEnabled,
+		IsKeyAvailable: keys != nil,
+	}
+}
+
+// UpdateEncryptionSettings turns encryption on or off, and encrypts or decrypts the whole history in
+// place before returning, which may take a while when there are a lot of images.
+func UpdateEncryptionSettings(
+	ctx context.Context,
+	request dto.UpdateEncryptionSettingsRequest,
+) (dto.EncryptionSettings, error) {
+	ctx = context.WithValue(ctx, logging.LoggerContextCallSiteKey, "UpdateEncryptionSettings")
+
+	encryptionUpdateMutex.Lock()
+	defer encryptionUpdateMutex.Unlock()
+
+	err := updateEncryptionSettings(ctx, request.IsEnabled)
+	if err == nil {
+		return GetEncryptionSettings(), nil
+	}
+
+	logger.ErrorAttrs(ctx, err, "failed to update encryption settings", slog.Any("request", request))
+
+	return dto.EncryptionSettings{}, exception.GetAsApplicationException(err, "failed to update encryption settings")
+}
+
+func updateEncryptionSettings(ctx context.Context, isEnabled bool) error {
+	// The key is needed to turn encryption off as well, to decrypt the items.
+	key, err := encryption.GetOrCreateKey(encryptionKeyStore)
+	if err != nil {
+		return err
+	}
+
+	err = upsertEncryptionSettings(model.EncryptionSettings{
+		IsEnabled: isEnabled,
+		UpdatedAt: uint64(time.Now().UnixMilli()),
+	})
+	if err != nil {
+		return err
+	}
+
+	setEncryptionState(isEnabled, newClipboardEncryptionKeys(key))
+
+	return convertClipboardItemsInPlace(ctx, isEnabled)
+}
+
+// convertClipboardItemsInPlace encrypts or decrypts every item that is not in the desired state yet,
+// the blobs of the images that were converted are left for `ImageBlobCollector` to remove.
+func convertClipboardItemsInPlace(ctx context.Context, shouldEncrypt bool) error {
+	clipboardItemModels, err := findClipboardItemsByEncryptionState(ctx, !shouldEncrypt)
+	if err != nil {
+		return err
+	}
+
+	for _, clipboardItemModel := range clipboardItemModels {
+		err = convertClipboardItemInPlace(clipboardItemModel, shouldEncrypt)
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(clipboardItemModels) > 0 {
+		logger.InfoAttrs(
+			ctx,
+			"converted clipboard items in place",
+			slog.Bool("isEncrypted", shouldEncrypt),
+			slog.Int("itemCount", len(clipboardItemModels)),
+		)
+
+		requestImageBlobCollection()
+	}
+
+	return nil
+}
+
+// convertClipboardItemInPlace stores the image in a blob of the new form before updating the row,
+// and only removes the image of an item that predates blobs once the row points at the blob.
+func convertClipboardItemInPlace(clipboardItemModel model.ClipboardItem, shouldEncrypt bool) error {
+	contentBytes, err := readClipboardItemContentBytes(clipboardItemModel)
+	if err != nil {
+		return err
+	}
+
+	richContent, err := openClipboardItemRichContent(clipboardItemModel)
+	if err != nil {
+		return err
+	}
+
+	convertedClipboardItemModel := clipboardItemModel
+	convertedClipboardItemModel.IsEncrypted = shouldEncrypt
+
+	if clipboardItemModel.Type == dto.ClipboardItemTypeImage {
+		imageBlobMutex.Lock()
+		defer imageBlobMutex.Unlock()
+
+		convertedClipboardItemModel.ImageBlobHash, err = convertImageBlob(
+			clipboardItemModel,
+			shouldEncrypt,
+			contentBytes,
+		)
+	} else {
+		convertedClipboardItemModel.Content, err = sealClipboardItemContent(convertedClipboardItemModel, contentBytes)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	convertedClipboardItemModel.RichContent, err = sealClipboardItemRichContent(convertedClipboardItemModel, richContent)
+	if err != nil {
+		return err
+	}
+
+	err = updateClipboardItemEncryptionState(
+		convertedClipboardItemModel.ID,
+		convertedClipboardItemModel.Content,
+		convertedClipboardItemModel.RichContent,
+		convertedClipboardItemModel.ImageBlobHash,
+		fingerprintClipboardItem(contentBytes, richContent),
+		convertedClipboardItemModel.IsEncrypted,
+	)
+	if err != nil || !isLegacyImageClipboardItem(clipboardItemModel) {
+		return err
+	}
+
+	return removeLegacyImageFiles(clipboardItemModel)
+}
+
+// convertImageBlob stores the image and its thumbnail in a blob of the new form and returns its
+// hash, images that have not been given a thumbnail yet are left for `BackfillImageMetadata`.
+func convertImageBlob(clipboardItemModel model.ClipboardItem, shouldEncrypt bool, imageBytes []byte) (*string, error) {
+	thumbnailBytes, err := readThumbnailFileIfExists(clipboardItemModel)
+	if err != nil {
+		return nil, err
+	}
+
+	hash, err := storeImageBlob(shouldEncrypt, imageBytes, thumbnailBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	return &hash, nil
+}
+
+// sealClipboardItemContent returns the content to store in the row, which is base64 encoded
+// when the item is encrypted.
+func sealClipboardItemContent(clipboardItemModel model.ClipboardItem, content []byte) (string, error) {
+	if !clipboardItemModel.IsEncrypted {
+		return string(content), nil
+	}
+
+	encryptedContent, err := encryptClipboardItemBytes(clipboardItemModel.ID, content)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.StdEncoding.EncodeToString(encryptedContent), nil
+}
+
+// openClipboardItemContent decrypts the content of the row when the item is encrypted.
+func openClipboardItemContent(clipboardItemModel model.ClipboardItem) (string, error) {
+	if !clipboardItemModel.IsEncrypted || clipboardItemModel.Type == dto.ClipboardItemTypeImage {
+		return clipboardItemModel.Content, nil
+	}
+
+	encryptedContent, err := base64.StdEncoding.DecodeString(clipboardItemModel.Content)
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+
+	content, err := decryptClipboardItemBytes(clipboardItemModel.ID, encryptedContent)
+
+	return string(content), err
+}
+
+// sealClipboardItemRichContent is `sealClipboardItemContent` for the HTML or RTF of the item.
+func sealClipboardItemRichContent(clipboardItemModel model.ClipboardItem, richContent *string) (*string, error) {
+	if !clipboardItemModel.IsEncrypted || richContent == nil {
+		return richContent, nil
+	}
+
+	encryptedRichContent, err := encryptClipboardItemBytes(
+		clipboardItemModel.ID+richContentAssociatedDataSuffix,
+		[]byte(*richContent),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	sealedRichContent := base64.StdEncoding.EncodeToString(encryptedRichContent)
+
+	return &sealedRichContent, nil
+}
+
+// openClipboardItemRichContent is `openClipboardItemContent` for the HTML or RTF of the item.
+func openClipboardItemRichContent(clipboardItemModel model.ClipboardItem) (*string, error) {
+	if !clipboardItemModel.IsEncrypted || clipboardItemModel.RichContent == nil {
+		return clipboardItemModel.RichContent, nil
+	}
+
+	encryptedRichContent, err := base64.StdEncoding.DecodeString(*clipboardItemModel.RichContent)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	richContent, err := decryptClipboardItemBytes(
+		clipboardItemModel.ID+richContentAssociatedDataSuffix,
+		encryptedRichContent,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	richContentString := string(richContent)
+
+	return &richContentString, nil
+}
+
+// readClipboardItemContentBytes returns the decrypted text or image of the item.
+func readClipboardItemContentBytes(clipboardItemModel model.ClipboardItem) ([]byte, error) {
+	if clipboardItemModel.Type == dto.ClipboardItemTypeImage {
+		return readImageFile(clipboardItemModel)
+	}
+
+	content, err := openClipboardItemContent(clipboardItemModel)
+
+	return []byte(content), err
+}
+
+func readImageFile(clipboardItemModel model.ClipboardItem) ([]byte, error) {
+	return readClipboardItemFile(
+		ResolveImageFilePath(clipboardItemModel),
+		clipboardItemModel.IsEncrypted,
+		resolveImageAssociatedData(clipboardItemModel),
+	)
+}
+
+func readThumbnailFile(clipboardItemModel model.ClipboardItem) ([]byte, error) {
+	return readClipboardItemFile(
+		resolveThumbnailFilePath(clipboardItemModel),
+		clipboardItemModel.IsEncrypted,
+		resolveImageAssociatedData(clipboardItemModel)+thumbnailAssociatedDataSuffix,
+	)
+}
+
+// readThumbnailFileIfExists returns nil for images that have not been given a thumbnail yet.
+func readThumbnailFileIfExists(clipboardItemModel model.ClipboardItem) ([]byte, error) {
+	thumbnailBytes, err := readThumbnailFile(clipboardItemModel)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+
+	return thumbnailBytes, err
+}
+
+func writeThumbnailFile(clipboardItemModel model.ClipboardItem, thumbnailBytes []byte) error {
+	return writeClipboardItemFile(
+		resolveThumbnailFilePath(clipboardItemModel),
+		clipboardItemModel.IsEncrypted,
+		resolveImageAssociatedData(clipboardItemModel)+thumbnailAssociatedDataSuffix,
+		thumbnailBytes,
+	)
+}
+
+// removeLegacyImageFiles removes the image and the thumbnail of an item that predates blobs, the
+// files of blobs are only ever removed by `CollectImageBlobGarbage`.
+func removeLegacyImageFiles(clipboardItemModel model.ClipboardItem) error {
+	for _, filePath := range []string{
+		ResolveImageFilePath(clipboardItemModel),
+		resolveThumbnailFilePath(clipboardItemModel),
+	} {
+		err := os.Remove(filePath)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return errors.WithStack(err)
+		}
+	}
+
+	return nil
+}
+
+// readClipboardItemFile decrypts the file when it is encrypted, the associated data is what the
+// file is bound to.
+func readClipboardItemFile(filePath string, isEncrypted bool, associatedData string) ([]byte, error) {
+	fileBytes, err := os.ReadFile(filePath)
+	if err != nil || !isEncrypted {
+		return fileBytes, errors.WithStack(err)
+	}
+
+	return decryptClipboardItemBytes(associatedData, fileBytes)
+}
+
+func writeClipboardItemFile(filePath string, isEncrypted bool, associatedData string, fileBytes []byte) error {
+	if isEncrypted {
+		var err error
+		fileBytes, err = encryptClipboardItemBytes(associatedData, fileBytes)
+		if err != nil {
+			return err
+		}
+	}
+
+	return errors.WithStack(os.WriteFile(filePath, fileBytes, 0644))
+}
+
+// encryptClipboardItemBytes binds the ciphertext to the item or the blob, so that it cannot be moved to another one.
+func encryptClipboardItemBytes(associatedData string, plaintext []byte) ([]byte, error) {
+	_, keys := getEncryptionState()
+	if keys == nil {
+		return nil, errEncryptionKeyUnavailable
+	}
+
+	return encryption.Encrypt(keys.content, plaintext, []byte(associatedData))
+}
+
+func decryptClipboardItemBytes(associatedData string, ciphertext []byte) ([]byte, error) {
+	_, keys := getEncryptionState()
+	if keys == nil {
+		return nil, errEncryptionKeyUnavailable
+	}
+
+	return encryption.Decrypt(keys.content, ciphertext, []byte(associatedData))
+}
+
+// keyedFingerprint keeps the fingerprints of encrypted items from revealing short content,
+// which could otherwise be guessed by hashing every candidate.
+func keyedFingerprint(keys *clipboardEncryptionKeys, raw []byte) int64 {
+	mac := hmac.New(sha256.New, keys.fingerprint)
+	mac.Write(raw)
+
+	return int64(binary.BigEndian.Uint64(mac.Sum(nil)))
+}
@@ -0,0 +1,81 @@
+package clipboard
+
+import (
+	"context"
+	"log/slog"
+
+	"cloudy-clip/desktop/internal/common/logging"
+
+	"github.com/cespare/xxhash/v2"
+)
+
+// fingerprint computes a super-fast 64-bit hash of the raw bytes, it is stored as a signed
+// integer since that is what SQLite supports. The hash is keyed while encryption is turned on.
+func fingerprint(raw []byte) int64 {
+	if isEnabled, keys := getEncryptionState(); isEnabled && keys != nil {
+		return keyedFingerprint(keys, raw)
+	}
+
+	return int64(xxhash.Sum64(raw))
+}
+
+// fingerprintClipboardItem fingerprints the plain-text content or the image along with the HTML
+// or RTF of the item, so that the same text copied with different formatting is kept apart.
+func fingerprintClipboardItem(content []byte, richContent *string) int64 {
+	if richContent == nil {
+		return fingerprint(content)
+	}
+
+	raw := make([]byte, 0, len(content)+1+len(*richContent))
+	raw = append(raw, content...)
+	raw = append(raw, 0)
+	raw = append(raw, *richContent...)
+
+	return fingerprint(raw)
+}
+
+// BackfillClipboardItemFingerprints fingerprints the items that were captured before items
+// had fingerprints, so that copying their content again does not duplicate them.
+func BackfillClipboardItemFingerprints(ctx context.Context) {
+	ctx = context.WithValue(ctx, logging.LoggerContextCallSiteKey, "BackfillClipboardItemFingerprints")
+	clipboardItemModels, err := findClipboardItemsWithoutFingerprint(ctx)
+	if err != nil {
+		logger.ErrorAttrs(ctx, err, "failed to find clipboard items without fingerprint")
+
+		return
+	}
+
+	fingerprintedItemCount := 0
+
+	for _, clipboardItemModel := range clipboardItemModels {
+		contentBytes, err := readClipboardItemContentBytes(clipboardItemModel)
+		if err != nil {
+			logger.WarnAttrs(
+				ctx,
+				"failed to read content of clipboard item to fingerprint",
+				slog.String("itemId", clipboardItemModel.ID),
+				slog.String("error", err.Error()),
+			)
+
+			continue
+		}
+
+		err = updateClipboardItemFingerprint(clipboardItemModel.ID, fingerprint(contentBytes))
+		if err != nil {
+			logger.ErrorAttrs(
+				ctx,
+				err,
+				"failed to fingerprint clipboard item",
+				slog.String("itemId", clipboardItemModel.ID),
+			)
+
+			continue
+		}
+
+		fingerprintedItemCount++
+	}
+
+	if fingerprintedItemCount > 0 {
+		logger.InfoAttrs(ctx, "fingerprinted clipboard items", slog.Int("itemCount", fingerprintedItemCount))
+	}
+}
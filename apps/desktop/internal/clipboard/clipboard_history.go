@@ -0,0 +1,207 @@
+package clipboard
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+
+	"cloudy-clip/desktop/internal/clipboard/dto"
+	"cloudy-clip/desktop/internal/common/database"
+	"cloudy-clip/desktop/internal/common/database/generated/model"
+	"cloudy-clip/desktop/internal/common/exception"
+	"cloudy-clip/desktop/internal/common/logging"
+
+	"github.com/oklog/ulid/v2"
+	"github.com/pkg/errors"
+)
+
+const (
+	defaultClipboardItemPageSize = 50
+	maxClipboardItemPageSize     = 200
+)
+
+// clipboardItemCursor points at the last item of a page, the ID breaks ties between items
+// that were created in the same millisecond.
+type clipboardItemCursor struct {
+	createdAt uint64
+	id        string
+}
+
+func (cursor clipboardItemCursor) String() string {
+	return fmt.Sprintf("%d_%s", cursor.createdAt, cursor.id)
+}
+
+func parseClipboardItemCursor(cursor string) (*clipboardItemCursor, error) {
+	if cursor == "" {
+		return nil, nil
+	}
+
+	createdAt, id, found := strings.Cut(cursor, "_")
+	parsedCreatedAt, err := strconv.ParseUint(createdAt, 10, 64)
+	if found && err == nil {
+		if _, err = ulid.ParseStrict(id); err == nil {
+			return &clipboardItemCursor{
+				createdAt: parsedCreatedAt,
+				id:        id,
+			}, nil
+		}
+	}
+
+	return nil, errors.WithStack(exception.NewValidationExceptionWithExtra(
+		"cursor is not valid",
+		map[string]any{
+			"cursor": cursor,
+		},
+	))
+}
+
+// GetClipboardItems returns a page of the history, newest items first.
+func GetClipboardItems(ctx context.Context, query dto.GetClipboardItemsQuery) (dto.ClipboardItemPage, error) {
+	ctx = context.WithValue(ctx, logging.LoggerContextCallSiteKey, "GetClipboardItems")
+	clipboardItemPage, err := getClipboardItems(ctx, query)
+	if err == nil {
+		return clipboardItemPage, nil
+	}
+
+	logger.ErrorAttrs(ctx, err, "failed to get clipboard items", slog.Any("query", query))
+
+	return dto.ClipboardItemPage{}, exception.GetAsApplicationException(err, "failed to get clipboard items")
+}
+
+func getClipboardItems(ctx context.Context, query dto.GetClipboardItemsQuery) (dto.ClipboardItemPage, error) {
+	cursor, err := parseClipboardItemCursor(query.Cursor)
+	if err != nil {
+		return dto.ClipboardItemPage{}, err
+	}
+
+	limit := query.Limit
+	if limit == 0 {
+		limit = defaultClipboardItemPageSize
+	}
+
+	if limit < 1 || limit > maxClipboardItemPageSize {
+		return dto.ClipboardItemPage{}, errors.WithStack(exception.NewValidationExceptionWithExtra(
+			fmt.Sprintf("limit must be between 1 and %d", maxClipboardItemPageSize),
+			map[string]any{
+				"limit": limit,
+			},
+		))
+	}
+
+	clipboardItemModels, err := findClipboardItems(ctx, query, cursor, int64(limit)+1)
+	if err != nil {
+		return dto.ClipboardItemPage{}, err
+	}
+
+	clipboardItemPage := dto.ClipboardItemPage{
+		Items:   make([]dto.ClipboardItem, 0, limit),
+		HasMore: len(clipboardItemModels) > limit,
+	}
+
+	if clipboardItemPage.HasMore {
+		clipboardItemModels = clipboardItemModels[:limit]
+	}
+
+	for _, clipboardItemModel := range clipboardItemModels {
+		clipboardItem, err := newClipboardItem(clipboardItemModel)
+		if err != nil {
+			return dto.ClipboardItemPage{}, err
+		}
+
+		clipboardItemPage.Items = append(clipboardItemPage.Items, clipboardItem)
+	}
+
+	if clipboardItemPage.HasMore {
+		lastClipboardItem := clipboardItemModels[len(clipboardItemModels)-1]
+		clipboardItemPage.NextCursor = clipboardItemCursor{
+			createdAt: lastClipboardItem.CreatedAt,
+			id:        lastClipboardItem.ID,
+		}.String()
+	}
+
+	return clipboardItemPage, nil
+}
+
+func GetClipboardItem(ctx context.Context, itemId string) (dto.ClipboardItem, error) {
+	ctx = context.WithValue(ctx, logging.LoggerContextCallSiteKey, "GetClipboardItem")
+	clipboardItem, err := getClipboardItem(itemId)
+	if err == nil {
+		return clipboardItem, nil
+	}
+
+	if database.IsEmptyResultError(err) {
+		return dto.ClipboardItem{}, exception.NewNotFoundException("clipboard item was not found")
+	}
+
+	logger.ErrorAttrs(ctx, err, "failed to get clipboard item", slog.String("itemId", itemId))
+
+	return dto.ClipboardItem{}, exception.NewUnknownException("failed to get clipboard item")
+}
+
+func getClipboardItem(itemId string) (dto.ClipboardItem, error) {
+	clipboardItemModel, err := findClipboardItemById(itemId)
+	if err != nil {
+		return dto.ClipboardItem{}, err
+	}
+
+	return newClipboardItem(*clipboardItemModel)
+}
+
+// newClipboardItem decrypts the content of encrypted items, and points image items at
+// `ImageRequestPath` and their thumbnails at `ThumbnailRequestPath` instead of inlining the image.
+func newClipboardItem(clipboardItemModel model.ClipboardItem) (dto.ClipboardItem, error) {
+	content, err := openClipboardItemContent(clipboardItemModel)
+	if err != nil {
+		return dto.ClipboardItem{}, err
+	}
+
+	if clipboardItemModel.Type == dto.ClipboardItemTypeImage {
+		content = ImageRequestPath + clipboardItemModel.ID
+	}
+
+	richContent, err := openClipboardItemRichContent(clipboardItemModel)
+	if err != nil {
+		return dto.ClipboardItem{}, err
+	}
+
+	return dto.ClipboardItem{
+		Id:            clipboardItemModel.ID,
+		Type:          clipboardItemModel.Type,
+		Content:       content,
+		RichContent:   richContent,
+		CreatedAt:     clipboardItemModel.CreatedAt,
+		IsPinned:      clipboardItemModel.IsPinned,
+		PinnedAt:      clipboardItemModel.PinnedAt,
+		IsSensitive:   clipboardItemModel.IsSensitive,
+		ExpiresAt:     clipboardItemModel.ExpiresAt,
+		ImageMetadata: newImageMetadata(clipboardItemModel),
+	}, nil
+}
+
+// newImageMetadata returns nil for every type but images, and for images that have not been measured yet.
+func newImageMetadata(clipboardItemModel model.ClipboardItem) *dto.ImageMetadata {
+	if clipboardItemModel.Type != dto.ClipboardItemTypeImage || clipboardItemModel.ImageWidth == nil {
+		return nil
+	}
+
+	imageMetadata := dto.ImageMetadata{
+		Width:        *clipboardItemModel.ImageWidth,
+		ThumbnailUrl: ThumbnailRequestPath + clipboardItemModel.ID,
+	}
+
+	if clipboardItemModel.ImageHeight != nil {
+		imageMetadata.Height = *clipboardItemModel.ImageHeight
+	}
+
+	if clipboardItemModel.ImageByteSize != nil {
+		imageMetadata.ByteSize = *clipboardItemModel.ImageByteSize
+	}
+
+	if clipboardItemModel.ImageMimeType != nil {
+		imageMetadata.MimeType = *clipboardItemModel.ImageMimeType
+	}
+
+	return &imageMetadata
+}
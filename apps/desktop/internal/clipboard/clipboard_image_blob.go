@@ -0,0 +1,407 @@
+package clipboard
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"cloudy-clip/desktop/internal/clipboard/dto"
+	"cloudy-clip/desktop/internal/common/database"
+	"cloudy-clip/desktop/internal/common/database/generated/model"
+	"cloudy-clip/desktop/internal/common/logging"
+	"cloudy-clip/desktop/internal/common/utils"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	// Held while a blob is stored until the item that references it has been written, and while
+	// garbage is collected, so that a blob cannot be collected in between.
+	imageBlobMutex sync.Mutex
+	// Holds at most one pending request, requests made while one is pending are merged into it.
+	imageBlobCollectionRequests = make(chan struct{}, 1)
+)
+
+// ImageBlobCollector removes the image blobs that are no longer referenced by any item right away,
+// then whenever items have been deleted and once every collection interval, which also catches
+// the blobs that were left behind when the app quit in the middle of storing them.
+type ImageBlobCollector struct {
+	collectionInterval time.Duration
+	stopSignal         chan struct{}
+	stopped            chan struct{}
+	startOnce          sync.Once
+	stopOnce           sync.Once
+}
+
+func NewImageBlobCollector(collectionInterval time.Duration) *ImageBlobCollector {
+	return &ImageBlobCollector{
+		collectionInterval: collectionInterval,
+		stopSignal:         make(chan struct{}),
+		stopped:            make(chan struct{}),
+	}
+}
+
+func (collector *ImageBlobCollector) Start() {
+	collector.startOnce.Do(func() {
+		go collector.run()
+	})
+}
+
+// Stop blocks until the ongoing collection, if any, has finished.
+func (collector *ImageBlobCollector) Stop() {
+	collector.stopOnce.Do(func() {
+		close(collector.stopSignal)
+	})
+
+	collector.startOnce.Do(func() {
+		close(collector.stopped)
+	})
+
+	<-collector.stopped
+}
+
+func (collector *ImageBlobCollector) run() {
+	defer close(collector.stopped)
+
+	ctx := context.WithValue(context.Background(), logging.LoggerContextCallSiteKey, "ImageBlobCollector")
+
+	ticker := time.NewTicker(collector.collectionInterval)
+	defer ticker.Stop()
+
+	collectImageBlobGarbage(ctx)
+
+	for {
+		select {
+		case <-collector.stopSignal:
+			return
+
+		case <-ticker.C:
+			collectImageBlobGarbage(ctx)
+
+		case <-imageBlobCollectionRequests:
+			collectImageBlobGarbage(ctx)
+		}
+	}
+}
+
+// requestImageBlobCollection never blocks, the request is only picked up once `ImageBlobCollector`
+// is running.
+func requestImageBlobCollection() {
+	select {
+	case imageBlobCollectionRequests <- struct{}{}:
+	default:
+	}
+}
+
+func collectImageBlobGarbage(ctx context.Context) {
+	removedBlobCount, err := CollectImageBlobGarbage(ctx)
+	if err != nil {
+		logger.ErrorAttrs(ctx, err, "failed to collect image blob garbage")
+
+		return
+	}
+
+	if removedBlobCount > 0 {
+		logger.InfoAttrs(ctx, "collected image blob garbage", slog.Int("removedBlobCount", removedBlobCount))
+	}
+}
+
+// CollectImageBlobGarbage removes the files and rows of the blobs that no item references, along
+// with the blob files that have no row, and returns how many blobs were removed.
+func CollectImageBlobGarbage(ctx context.Context) (int, error) {
+	imageBlobMutex.Lock()
+	defer imageBlobMutex.Unlock()
+
+	unreferencedImageBlobModels, err := findUnreferencedImageBlobs(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	hashes := make([]string, 0, len(unreferencedImageBlobModels))
+
+	for _, imageBlobModel := range unreferencedImageBlobModels {
+		err = removeImageBlobFiles(imageBlobModel)
+		if err != nil {
+			return 0, err
+		}
+
+		hashes = append(hashes, imageBlobModel.Hash)
+	}
+
+	err = deleteImageBlobs(hashes)
+	if err != nil {
+		return 0, err
+	}
+
+	removedStrayFileCount, err := removeStrayImageBlobFiles(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	return len(hashes) + removedStrayFileCount, nil
+}
+
+// removeStrayImageBlobFiles removes the files that were written for a blob whose row never was,
+// and returns how many blobs they made up.
+func removeStrayImageBlobFiles(ctx context.Context) (int, error) {
+	imageBlobModels, err := findImageBlobs(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	imageBlobFileNames := make(map[string]bool, len(imageBlobModels))
+	for _, imageBlobModel := range imageBlobModels {
+		imageBlobFileNames[resolveImageBlobFileName(imageBlobModel.Hash, imageBlobModel.IsEncrypted)] = true
+	}
+
+	strayFileNames := make(map[string]bool)
+
+	for _, directoryPath := range []string{resolveImageBlobDirectory(), resolveImageBlobThumbnailDirectory()} {
+		directoryEntries, err := os.ReadDir(directoryPath)
+		if err != nil {
+			return 0, errors.WithStack(err)
+		}
+
+		for _, directoryEntry := range directoryEntries {
+			if directoryEntry.IsDir() || imageBlobFileNames[directoryEntry.Name()] {
+				continue
+			}
+
+			err = os.Remove(filepath.Join(directoryPath, directoryEntry.Name()))
+			if err != nil && !errors.Is(err, os.ErrNotExist) {
+				return 0, errors.WithStack(err)
+			}
+
+			strayFileNames[directoryEntry.Name()] = true
+		}
+	}
+
+	return len(strayFileNames), nil
+}
+
+// storeImageBlob writes the image and its thumbnail unless a blob with the same content already
+// exists, and returns the hash of the blob. `imageBlobMutex` must be held until the item that
+// references the blob has been written.
+func storeImageBlob(isEncrypted bool, imageBytes []byte, thumbnailBytes []byte) (string, error) {
+	hash, err := hashImageBlob(isEncrypted, imageBytes)
+	if err != nil {
+		return "", err
+	}
+
+	imageBlobModel := model.ImageBlob{
+		Hash:        hash,
+		IsEncrypted: isEncrypted,
+		CreatedAt:   uint64(time.Now().UnixMilli()),
+	}
+
+	_, err = findImageBlob(hash)
+	if err == nil {
+		return hash, writeMissingImageBlobThumbnail(imageBlobModel, thumbnailBytes)
+	}
+
+	if !database.IsEmptyResultError(err) {
+		return "", err
+	}
+
+	err = writeClipboardItemFile(resolveImageBlobFilePath(imageBlobModel), isEncrypted, hash, imageBytes)
+	if err != nil {
+		return "", err
+	}
+
+	if thumbnailBytes != nil {
+		err = writeClipboardItemFile(
+			resolveImageBlobThumbnailFilePath(imageBlobModel),
+			isEncrypted,
+			hash+thumbnailAssociatedDataSuffix,
+			thumbnailBytes,
+		)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return hash, insertImageBlob(imageBlobModel)
+}
+
+// writeMissingImageBlobThumbnail gives a blob that was stored without a thumbnail the one of the
+// same image captured again.
+func writeMissingImageBlobThumbnail(imageBlobModel model.ImageBlob, thumbnailBytes []byte) error {
+	if thumbnailBytes == nil {
+		return nil
+	}
+
+	thumbnailFilePath := resolveImageBlobThumbnailFilePath(imageBlobModel)
+
+	_, err := os.Stat(thumbnailFilePath)
+	if !errors.Is(err, os.ErrNotExist) {
+		return errors.WithStack(err)
+	}
+
+	return writeClipboardItemFile(
+		thumbnailFilePath,
+		imageBlobModel.IsEncrypted,
+		imageBlobModel.Hash+thumbnailAssociatedDataSuffix,
+		thumbnailBytes,
+	)
+}
+
+// hashImageBlob returns the SHA-256 of the image, which is keyed when the blob is encrypted so that
+// the name of the file does not reveal which image it holds.
+func hashImageBlob(isEncrypted bool, imageBytes []byte) (string, error) {
+	if !isEncrypted {
+		hash := sha256.Sum256(imageBytes)
+
+		return hex.EncodeToString(hash[:]), nil
+	}
+
+	_, keys := getEncryptionState()
+	if keys == nil {
+		return "", errEncryptionKeyUnavailable
+	}
+
+	mac := hmac.New(sha256.New, keys.imageBlob)
+	mac.Write(imageBytes)
+
+	return hex.EncodeToString(mac.Sum(nil)), nil
+}
+
+func removeImageBlobFiles(imageBlobModel model.ImageBlob) error {
+	for _, filePath := range []string{
+		resolveImageBlobFilePath(imageBlobModel),
+		resolveImageBlobThumbnailFilePath(imageBlobModel),
+	} {
+		err := os.Remove(filePath)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return errors.WithStack(err)
+		}
+	}
+
+	return nil
+}
+
+func resolveImageBlobFilePath(imageBlobModel model.ImageBlob) string {
+	return filepath.Join(
+		resolveImageBlobDirectory(),
+		resolveImageBlobFileName(imageBlobModel.Hash, imageBlobModel.IsEncrypted),
+	)
+}
+
+func resolveImageBlobThumbnailFilePath(imageBlobModel model.ImageBlob) string {
+	return filepath.Join(
+		resolveImageBlobThumbnailDirectory(),
+		resolveImageBlobFileName(imageBlobModel.Hash, imageBlobModel.IsEncrypted),
+	)
+}
+
+func resolveImageBlobFileName(hash string, isEncrypted bool) string {
+	fileName := hash + ".png"
+	if isEncrypted {
+		fileName += ".enc"
+	}
+
+	return fileName
+}
+
+func resolveImageBlobDirectory() string {
+	utils.GetOrCreateDirectory("images")
+
+	return utils.GetOrCreateDirectory(filepath.Join("images", "blobs"))
+}
+
+// The thumbnail of a blob has the same name as the blob.
+func resolveImageBlobThumbnailDirectory() string {
+	resolveImageBlobDirectory()
+
+	return utils.GetOrCreateDirectory(filepath.Join("images", "blobs", "thumbnails"))
+}
+
+// MigrateImageFilesToBlobs moves the images that were stored in a file named after their item
+// into blobs, the items whose image cannot be read are left as they are and are tried again on the
+// next start.
+func MigrateImageFilesToBlobs(ctx context.Context) {
+	ctx = context.WithValue(ctx, logging.LoggerContextCallSiteKey, "MigrateImageFilesToBlobs")
+	clipboardItemModels, err := findImageClipboardItemsWithoutBlob(ctx)
+	if err != nil {
+		logger.ErrorAttrs(ctx, err, "failed to find image clipboard items without blob")
+
+		return
+	}
+
+	migratedItemCount := 0
+
+	for _, clipboardItemModel := range clipboardItemModels {
+		err = migrateImageFileToBlob(clipboardItemModel)
+		if err != nil {
+			logger.WarnAttrs(
+				ctx,
+				"failed to migrate clipboard image to blob",
+				slog.String("itemId", clipboardItemModel.ID),
+				slog.String("error", err.Error()),
+			)
+
+			continue
+		}
+
+		migratedItemCount++
+	}
+
+	if migratedItemCount > 0 {
+		logger.InfoAttrs(ctx, "migrated clipboard images to blobs", slog.Int("itemCount", migratedItemCount))
+	}
+}
+
+func migrateImageFileToBlob(clipboardItemModel model.ClipboardItem) error {
+	imageBytes, err := readImageFile(clipboardItemModel)
+	if err != nil {
+		return err
+	}
+
+	thumbnailBytes, err := readThumbnailFileIfExists(clipboardItemModel)
+	if err != nil {
+		return err
+	}
+
+	imageBlobMutex.Lock()
+	defer imageBlobMutex.Unlock()
+
+	hash, err := storeImageBlob(clipboardItemModel.IsEncrypted, imageBytes, thumbnailBytes)
+	if err != nil {
+		return err
+	}
+
+	err = updateClipboardItemImageBlobHash(clipboardItemModel.ID, hash)
+	if err != nil {
+		return err
+	}
+
+	return removeLegacyImageFiles(clipboardItemModel)
+}
+
+// resolveImageAssociatedData returns what the image file of the item is bound to, which is the
+// hash of its blob, or the item id for images that are still stored in a file named after the item.
+func resolveImageAssociatedData(clipboardItemModel model.ClipboardItem) string {
+	if clipboardItemModel.ImageBlobHash != nil {
+		return *clipboardItemModel.ImageBlobHash
+	}
+
+	return clipboardItemModel.ID
+}
+
+// newImageBlobOfClipboardItem returns the blob that the item references, a blob always has the
+// encryption state of the items that reference it.
+func newImageBlobOfClipboardItem(clipboardItemModel model.ClipboardItem) model.ImageBlob {
+	return model.ImageBlob{
+		Hash:        *clipboardItemModel.ImageBlobHash,
+		IsEncrypted: clipboardItemModel.IsEncrypted,
+	}
+}
+
+func isLegacyImageClipboardItem(clipboardItemModel model.ClipboardItem) bool {
+	return clipboardItemModel.Type == dto.ClipboardItemTypeImage && clipboardItemModel.ImageBlobHash == nil
+}
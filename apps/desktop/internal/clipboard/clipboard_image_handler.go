@@ -0,0 +1,75 @@
+package clipboard
+
+import (
+	"bytes"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"cloudy-clip/desktop/internal/clipboard/dto"
+
+	"github.com/oklog/ulid/v2"
+	"github.com/pkg/errors"
+)
+
+const (
+	// Served by `ImageHandler` through the Wails asset server, so that the UI loads images lazily.
+	ImageRequestPath     = "/clipboard/images/"
+	ThumbnailRequestPath = "/clipboard/thumbnails/"
+)
+
+// ImageHandler serves the PNG of the image item whose ID follows `ImageRequestPath`, or its
+// thumbnail when the ID follows `ThumbnailRequestPath`, decrypted when the item is encrypted.
+func ImageHandler() http.Handler {
+	return http.HandlerFunc(func(responseWriter http.ResponseWriter, request *http.Request) {
+		readFile := readImageFile
+
+		itemId, found := strings.CutPrefix(request.URL.Path, ImageRequestPath)
+		if !found {
+			itemId, found = strings.CutPrefix(request.URL.Path, ThumbnailRequestPath)
+			readFile = readThumbnailFile
+		}
+
+		if !found || request.Method != http.MethodGet {
+			http.NotFound(responseWriter, request)
+
+			return
+		}
+
+		// Only ULIDs are accepted so that the path cannot escape the images directory.
+		if _, err := ulid.ParseStrict(itemId); err != nil {
+			http.NotFound(responseWriter, request)
+
+			return
+		}
+
+		clipboardItemModel, err := findClipboardItemById(itemId)
+		if err != nil || clipboardItemModel.Type != dto.ClipboardItemTypeImage {
+			http.NotFound(responseWriter, request)
+
+			return
+		}
+
+		imageBytes, err := readFile(*clipboardItemModel)
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				http.NotFound(responseWriter, request)
+			} else {
+				http.Error(responseWriter, err.Error(), http.StatusInternalServerError)
+			}
+
+			return
+		}
+
+		contentType := "image/png"
+		if clipboardItemModel.ImageMimeType != nil {
+			contentType = *clipboardItemModel.ImageMimeType
+		}
+
+		responseWriter.Header().Set("Content-Type", contentType)
+		// Items never change their image.
+		responseWriter.Header().Set("Cache-Control", "private, max-age=31536000, immutable")
+		http.ServeContent(responseWriter, request, "", time.Time{}, bytes.NewReader(imageBytes))
+	})
+}
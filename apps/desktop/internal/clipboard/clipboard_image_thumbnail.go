@@ -0,0 +1,185 @@
+package clipboard
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/draw"
+	"image/png"
+	"log/slog"
+	"math"
+	"net/http"
+
+	"cloudy-clip/desktop/internal/common/database/generated/model"
+	"cloudy-clip/desktop/internal/common/logging"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// Thumbnails fit in a square of this many pixels, which is about twice what the history list
+	// shows so that they stay sharp on high density displays.
+	thumbnailMaxDimension = 320
+)
+
+// capturedImage holds what is recorded about an image when it is captured.
+type capturedImage struct {
+	imageBytes []byte
+	width      int32
+	height     int32
+	byteSize   int64
+	mimeType   string
+	thumbnail  []byte
+}
+
+// newCapturedImage measures the PNG and downscales it, images that already fit are used as their
+// own thumbnail.
+func newCapturedImage(imageBytes []byte) (capturedImage, error) {
+	imageConfig, err := png.DecodeConfig(bytes.NewReader(imageBytes))
+	if err != nil {
+		return capturedImage{}, errors.WithStack(err)
+	}
+
+	captured := capturedImage{
+		imageBytes: imageBytes,
+		width:      int32(imageConfig.Width),
+		height:     int32(imageConfig.Height),
+		byteSize:   int64(len(imageBytes)),
+		mimeType:   http.DetectContentType(imageBytes),
+		thumbnail:  imageBytes,
+	}
+
+	if imageConfig.Width <= thumbnailMaxDimension && imageConfig.Height <= thumbnailMaxDimension {
+		return captured, nil
+	}
+
+	sourceImage, err := png.Decode(bytes.NewReader(imageBytes))
+	if err != nil {
+		return capturedImage{}, errors.WithStack(err)
+	}
+
+	thumbnailWidth, thumbnailHeight := fitThumbnail(imageConfig.Width, imageConfig.Height)
+
+	var thumbnailBuffer bytes.Buffer
+
+	err = png.Encode(&thumbnailBuffer, downscaleImage(sourceImage, thumbnailWidth, thumbnailHeight))
+	if err != nil {
+		return capturedImage{}, errors.WithStack(err)
+	}
+
+	captured.thumbnail = thumbnailBuffer.Bytes()
+
+	return captured, nil
+}
+
+// BackfillImageMetadata measures the images that were captured before images had metadata, and
+// creates their thumbnails.
+func BackfillImageMetadata(ctx context.Context) {
+	ctx = context.WithValue(ctx, logging.LoggerContextCallSiteKey, "BackfillImageMetadata")
+	clipboardItemModels, err := findImageClipboardItemsWithoutMetadata(ctx)
+	if err != nil {
+		logger.ErrorAttrs(ctx, err, "failed to find image clipboard items without metadata")
+
+		return
+	}
+
+	measuredItemCount := 0
+
+	for _, clipboardItemModel := range clipboardItemModels {
+		err = backfillImageMetadata(clipboardItemModel)
+		if err != nil {
+			logger.WarnAttrs(
+				ctx,
+				"failed to backfill metadata of clipboard image",
+				slog.String("itemId", clipboardItemModel.ID),
+				slog.String("error", err.Error()),
+			)
+
+			continue
+		}
+
+		measuredItemCount++
+	}
+
+	if measuredItemCount > 0 {
+		logger.InfoAttrs(ctx, "backfilled metadata of clipboard images", slog.Int("itemCount", measuredItemCount))
+	}
+}
+
+func backfillImageMetadata(clipboardItemModel model.ClipboardItem) error {
+	imageBytes, err := readImageFile(clipboardItemModel)
+	if err != nil {
+		return err
+	}
+
+	measuredImage, err := newCapturedImage(imageBytes)
+	if err != nil {
+		return err
+	}
+
+	err = writeThumbnailFile(clipboardItemModel, measuredImage.thumbnail)
+	if err != nil {
+		return err
+	}
+
+	return updateClipboardItemImageMetadata(clipboardItemModel.ID, measuredImage)
+}
+
+// fitThumbnail keeps the aspect ratio, and never lets either side drop to 0 for very long images.
+func fitThumbnail(width int, height int) (int, int) {
+	scale := math.Min(
+		float64(thumbnailMaxDimension)/float64(width),
+		float64(thumbnailMaxDimension)/float64(height),
+	)
+
+	return max(1, int(math.Round(float64(width)*scale))), max(1, int(math.Round(float64(height)*scale)))
+}
+
+// downscaleImage averages every block of source pixels that makes up a pixel of the thumbnail.
+// The averaging is done on premultiplied colors, so that transparent pixels do not darken the edges.
+func downscaleImage(sourceImage image.Image, width int, height int) *image.RGBA {
+	sourceBounds := sourceImage.Bounds()
+
+	rgbaSourceImage, isRgba := sourceImage.(*image.RGBA)
+	if !isRgba {
+		rgbaSourceImage = image.NewRGBA(sourceBounds)
+		draw.Draw(rgbaSourceImage, sourceBounds, sourceImage, sourceBounds.Min, draw.Src)
+	}
+
+	thumbnail := image.NewRGBA(image.Rect(0, 0, width, height))
+	sourceWidth := sourceBounds.Dx()
+	sourceHeight := sourceBounds.Dy()
+
+	for y := range height {
+		sourceTop := sourceBounds.Min.Y + y*sourceHeight/height
+		sourceBottom := max(sourceTop+1, sourceBounds.Min.Y+(y+1)*sourceHeight/height)
+
+		for x := range width {
+			sourceLeft := sourceBounds.Min.X + x*sourceWidth/width
+			sourceRight := max(sourceLeft+1, sourceBounds.Min.X+(x+1)*sourceWidth/width)
+
+			var sums [4]uint64
+
+			for sourceY := sourceTop; sourceY < sourceBottom; sourceY++ {
+				offset := rgbaSourceImage.PixOffset(sourceLeft, sourceY)
+
+				for sourceX := sourceLeft; sourceX < sourceRight; sourceX++ {
+					for channel := range sums {
+						sums[channel] += uint64(rgbaSourceImage.Pix[offset+channel])
+					}
+
+					offset += 4
+				}
+			}
+
+			pixelCount := uint64((sourceBottom - sourceTop) * (sourceRight - sourceLeft))
+			offset := thumbnail.PixOffset(x, y)
+
+			for channel, sum := range sums {
+				thumbnail.Pix[offset+channel] = uint8(sum / pixelCount)
+			}
+		}
+	}
+
+	return thumbnail
+}
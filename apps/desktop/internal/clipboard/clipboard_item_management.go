@@ -0,0 +1,201 @@
+package clipboard
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"cloudy-clip/desktop/internal/clipboard/dto"
+	"cloudy-clip/desktop/internal/common/database"
+	"cloudy-clip/desktop/internal/common/database/generated/model"
+	"cloudy-clip/desktop/internal/common/exception"
+	"cloudy-clip/desktop/internal/common/logging"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// SetClipboardItemPinned pins or unpins the item, pinned items are never cleared from the history.
+func SetClipboardItemPinned(ctx context.Context, itemId string, isPinned bool) (dto.ClipboardItem, error) {
+	ctx = context.WithValue(ctx, logging.LoggerContextCallSiteKey, "SetClipboardItemPinned")
+
+	var clipboardItemModel *model.ClipboardItem
+
+	err := database.UseTransaction(ctx, func(transaction *sqlx.Tx) error {
+		var err error
+		clipboardItemModel, err = findClipboardItemByIdTx(transaction, itemId)
+		if err != nil {
+			return err
+		}
+
+		if clipboardItemModel.IsPinned == isPinned {
+			return nil
+		}
+
+		clipboardItemModel.IsPinned = isPinned
+		clipboardItemModel.PinnedAt = 0
+		if isPinned {
+			clipboardItemModel.PinnedAt = uint64(time.Now().UnixMilli())
+		}
+
+		return updateClipboardItemPinnedStateTx(
+			ctx,
+			transaction,
+			itemId,
+			clipboardItemModel.IsPinned,
+			clipboardItemModel.PinnedAt,
+		)
+	})
+	if err == nil {
+		var clipboardItem dto.ClipboardItem
+		clipboardItem, err = newClipboardItem(*clipboardItemModel)
+		if err == nil {
+			return clipboardItem, nil
+		}
+	}
+
+	if database.IsEmptyResultError(err) {
+		return dto.ClipboardItem{}, exception.NewNotFoundException("clipboard item was not found")
+	}
+
+	logger.ErrorAttrs(
+		ctx,
+		err,
+		"failed to update pinned state of clipboard item",
+		slog.String("itemId", itemId),
+		slog.Bool("isPinned", isPinned),
+	)
+
+	return dto.ClipboardItem{}, exception.NewUnknownException("failed to update clipboard item")
+}
+
+func DeleteClipboardItem(ctx context.Context, itemId string) error {
+	deletedItemCount, err := DeleteClipboardItems(ctx, []string{itemId})
+	if err == nil && deletedItemCount == 0 {
+		return exception.NewNotFoundException("clipboard item was not found")
+	}
+
+	return err
+}
+
+// DeleteClipboardItems deletes the items along with their images and returns how many were deleted,
+// IDs of items that do not exist are ignored.
+func DeleteClipboardItems(ctx context.Context, itemIds []string) (int, error) {
+	ctx = context.WithValue(ctx, logging.LoggerContextCallSiteKey, "DeleteClipboardItems")
+
+	if len(itemIds) == 0 {
+		return 0, nil
+	}
+
+	deletedItemCount := 0
+
+	err := database.UseTransaction(ctx, func(transaction *sqlx.Tx) error {
+		clipboardItemModels, err := findClipboardItemsByIdsTx(transaction, itemIds)
+		if err != nil {
+			return err
+		}
+
+		deletedItemCount = len(clipboardItemModels)
+
+		return deleteClipboardItemModelsTx(ctx, transaction, clipboardItemModels)
+	})
+	if err == nil {
+		requestImageBlobCollection()
+
+		return deletedItemCount, nil
+	}
+
+	logger.ErrorAttrs(ctx, err, "failed to delete clipboard items", slog.Any("itemIds", itemIds))
+
+	return 0, exception.NewUnknownException("failed to delete clipboard items")
+}
+
+// ClearClipboardHistory deletes every item that is not pinned and returns how many were deleted.
+func ClearClipboardHistory(ctx context.Context) (int, error) {
+	ctx = context.WithValue(ctx, logging.LoggerContextCallSiteKey, "ClearClipboardHistory")
+	deletedItemCount := 0
+
+	err := database.UseTransaction(ctx, func(transaction *sqlx.Tx) error {
+		clipboardItemModels, err := findUnpinnedClipboardItemsTx(transaction)
+		if err != nil {
+			return err
+		}
+
+		deletedItemCount = len(clipboardItemModels)
+
+		return deleteClipboardItemModelsTx(ctx, transaction, clipboardItemModels)
+	})
+	if err == nil {
+		requestImageBlobCollection()
+
+		return deletedItemCount, nil
+	}
+
+	logger.ErrorAttrs(ctx, err, "failed to clear clipboard history")
+
+	return 0, exception.NewUnknownException("failed to clear clipboard history")
+}
+
+// DeleteExpiredClipboardItems deletes the unpinned items that expired as of `now` and returns their IDs.
+func DeleteExpiredClipboardItems(ctx context.Context, now time.Time) ([]string, error) {
+	ctx = context.WithValue(ctx, logging.LoggerContextCallSiteKey, "DeleteExpiredClipboardItems")
+	var itemIds []string
+
+	err := database.UseTransaction(ctx, func(transaction *sqlx.Tx) error {
+		clipboardItemModels, err := findExpiredClipboardItemsTx(transaction, uint64(now.UnixMilli()))
+		if err != nil {
+			return err
+		}
+
+		itemIds = make([]string, 0, len(clipboardItemModels))
+		for _, clipboardItemModel := range clipboardItemModels {
+			itemIds = append(itemIds, clipboardItemModel.ID)
+		}
+
+		return deleteClipboardItemModelsTx(ctx, transaction, clipboardItemModels)
+	})
+	if err == nil {
+		requestImageBlobCollection()
+
+		return itemIds, nil
+	}
+
+	logger.ErrorAttrs(ctx, err, "failed to delete expired clipboard items")
+
+	return nil, exception.NewUnknownException("failed to delete expired clipboard items")
+}
+
+// deleteClipboardItemModelsTx removes the images that predate blobs before `transaction` commits,
+// so that the rows are kept around when an image cannot be removed. The blobs of the other images
+// are left for `ImageBlobCollector` to remove once nothing references them anymore.
+func deleteClipboardItemModelsTx(
+	ctx context.Context,
+	transaction *sqlx.Tx,
+	clipboardItemModels []model.ClipboardItem,
+) error {
+	if len(clipboardItemModels) == 0 {
+		return nil
+	}
+
+	itemIds := make([]string, 0, len(clipboardItemModels))
+	for _, clipboardItemModel := range clipboardItemModels {
+		itemIds = append(itemIds, clipboardItemModel.ID)
+	}
+
+	err := deleteClipboardItemsByIdsTx(ctx, transaction, itemIds)
+	if err != nil {
+		return err
+	}
+
+	for _, clipboardItemModel := range clipboardItemModels {
+		if !isLegacyImageClipboardItem(clipboardItemModel) {
+			continue
+		}
+
+		err = removeLegacyImageFiles(clipboardItemModel)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
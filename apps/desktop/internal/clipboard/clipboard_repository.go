@@ -0,0 +1,594 @@
+package clipboard
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"cloudy-clip/desktop/internal/clipboard/dto"
+	"cloudy-clip/desktop/internal/common/database"
+	"cloudy-clip/desktop/internal/common/database/generated/model"
+	"cloudy-clip/desktop/internal/common/database/generated/table"
+
+	jet "github.com/go-jet/jet/v2/sqlite"
+	"github.com/jmoiron/sqlx"
+)
+
+const (
+	encryptionSettingsId = 1
+)
+
+// findClipboardItems returns the newest items first, starting right after the cursor if there is one.
+func findClipboardItems(
+	ctx context.Context,
+	query dto.GetClipboardItemsQuery,
+	cursor *clipboardItemCursor,
+	limit int64,
+) ([]model.ClipboardItem, error) {
+	clipboardItemTable := table.ClipboardItemTable
+	condition := newClipboardItemFilterCondition(query.Types, query.CreatedFrom, query.CreatedTo, query.IsPinned)
+
+	if cursor != nil {
+		condition = condition.AND(
+			clipboardItemTable.CreatedAt.LT(jet.Int(int64(cursor.createdAt))).
+				OR(
+					clipboardItemTable.CreatedAt.EQ(jet.Int(int64(cursor.createdAt))).
+						AND(clipboardItemTable.ID.LT(jet.String(cursor.id))),
+				),
+		)
+	}
+
+	queryBuilder := clipboardItemTable.
+		SELECT(clipboardItemTable.AllColumns.As("")).
+		WHERE(condition).
+		ORDER_BY(clipboardItemTable.CreatedAt.DESC(), clipboardItemTable.ID.DESC()).
+		LIMIT(limit)
+
+	clipboardItems, err := database.SelectMany[model.ClipboardItem](ctx, queryBuilder)
+	if err != nil {
+		return nil, err
+	}
+
+	return *clipboardItems, nil
+}
+
+// findClipboardItemsToExport returns the oldest items first, sensitive items are never exported.
+func findClipboardItemsToExport(
+	ctx context.Context,
+	request dto.ExportClipboardHistoryRequest,
+) ([]model.ClipboardItem, error) {
+	clipboardItemTable := table.ClipboardItemTable
+	condition := newClipboardItemFilterCondition(
+		request.Types,
+		request.CreatedFrom,
+		request.CreatedTo,
+		request.IsPinned,
+	).AND(clipboardItemTable.IsSensitive.IS_FALSE())
+
+	queryBuilder := clipboardItemTable.
+		SELECT(clipboardItemTable.AllColumns.As("")).
+		WHERE(condition).
+		ORDER_BY(clipboardItemTable.CreatedAt.ASC(), clipboardItemTable.ID.ASC())
+
+	clipboardItems, err := database.SelectMany[model.ClipboardItem](ctx, queryBuilder)
+	if err != nil {
+		return nil, err
+	}
+
+	return *clipboardItems, nil
+}
+
+// newClipboardItemFilterCondition leaves out every filter that has its zero value.
+func newClipboardItemFilterCondition(
+	types []dto.ClipboardItemType,
+	createdFrom uint64,
+	createdTo uint64,
+	isPinned *bool,
+) jet.BoolExpression {
+	clipboardItemTable := table.ClipboardItemTable
+	condition := jet.Bool(true)
+
+	if len(types) > 0 {
+		// Types are stored as their numeric value.
+		typeExpressions := make([]jet.Expression, 0, len(types))
+		for _, clipboardItemType := range types {
+			typeExpressions = append(typeExpressions, jet.String(strconv.Itoa(int(clipboardItemType))))
+		}
+
+		condition = condition.AND(clipboardItemTable.Type.IN(typeExpressions...))
+	}
+
+	if createdFrom > 0 {
+		condition = condition.AND(clipboardItemTable.CreatedAt.GT_EQ(jet.Int(int64(createdFrom))))
+	}
+
+	if createdTo > 0 {
+		condition = condition.AND(clipboardItemTable.CreatedAt.LT(jet.Int(int64(createdTo))))
+	}
+
+	if isPinned != nil {
+		condition = condition.AND(clipboardItemTable.IsPinned.EQ(jet.Bool(*isPinned)))
+	}
+
+	return condition
+}
+
+func findClipboardItemById(itemId string) (*model.ClipboardItem, error) {
+	clipboardItemTable := table.ClipboardItemTable
+	queryBuilder := clipboardItemTable.
+		SELECT(clipboardItemTable.AllColumns.As("")).
+		WHERE(clipboardItemTable.ID.EQ(jet.String(itemId)))
+
+	return database.SelectOne[model.ClipboardItem](queryBuilder)
+}
+
+func findClipboardItemByIdTx(transaction *sqlx.Tx, itemId string) (*model.ClipboardItem, error) {
+	clipboardItemTable := table.ClipboardItemTable
+	queryBuilder := clipboardItemTable.
+		SELECT(clipboardItemTable.AllColumns.As("")).
+		WHERE(clipboardItemTable.ID.EQ(jet.String(itemId)))
+
+	return database.SelectOneTx[model.ClipboardItem](transaction, queryBuilder)
+}
+
+func findLatestClipboardItem() (*model.ClipboardItem, error) {
+	clipboardItemTable := table.ClipboardItemTable
+	queryBuilder := clipboardItemTable.
+		SELECT(clipboardItemTable.AllColumns.As("")).
+		ORDER_BY(clipboardItemTable.CreatedAt.DESC(), clipboardItemTable.ID.DESC()).
+		LIMIT(1)
+
+	return database.SelectOne[model.ClipboardItem](queryBuilder)
+}
+
+// findClipboardItemByFingerprint returns the newest item in case the history
+// already had duplicates before items were fingerprinted.
+func findClipboardItemByFingerprint(fingerprint int64) (*model.ClipboardItem, error) {
+	clipboardItemTable := table.ClipboardItemTable
+	queryBuilder := clipboardItemTable.
+		SELECT(clipboardItemTable.AllColumns.As("")).
+		WHERE(clipboardItemTable.Fingerprint.EQ(jet.Int(fingerprint))).
+		ORDER_BY(clipboardItemTable.CreatedAt.DESC(), clipboardItemTable.ID.DESC()).
+		LIMIT(1)
+
+	return database.SelectOne[model.ClipboardItem](queryBuilder)
+}
+
+func findClipboardItemsWithoutFingerprint(ctx context.Context) ([]model.ClipboardItem, error) {
+	clipboardItemTable := table.ClipboardItemTable
+	queryBuilder := clipboardItemTable.
+		SELECT(clipboardItemTable.AllColumns.As("")).
+		WHERE(clipboardItemTable.Fingerprint.IS_NULL())
+
+	clipboardItems, err := database.SelectMany[model.ClipboardItem](ctx, queryBuilder)
+	if err != nil {
+		return nil, err
+	}
+
+	return *clipboardItems, nil
+}
+
+func findImageClipboardItemsWithoutMetadata(ctx context.Context) ([]model.ClipboardItem, error) {
+	clipboardItemTable := table.ClipboardItemTable
+	queryBuilder := clipboardItemTable.
+		SELECT(clipboardItemTable.AllColumns.As("")).
+		WHERE(
+			clipboardItemTable.Type.EQ(jet.String(strconv.Itoa(int(dto.ClipboardItemTypeImage)))).
+				AND(clipboardItemTable.ImageWidth.IS_NULL()),
+		)
+
+	clipboardItems, err := database.SelectMany[model.ClipboardItem](ctx, queryBuilder)
+	if err != nil {
+		return nil, err
+	}
+
+	return *clipboardItems, nil
+}
+
+func updateClipboardItemImageMetadata(itemId string, image capturedImage) error {
+	clipboardItemTable := table.ClipboardItemTable
+	queryBuilder := clipboardItemTable.
+		UPDATE(
+			clipboardItemTable.ImageWidth,
+			clipboardItemTable.ImageHeight,
+			clipboardItemTable.ImageByteSize,
+			clipboardItemTable.ImageMimeType,
+		).
+		SET(
+			jet.Int32(image.width),
+			jet.Int32(image.height),
+			jet.Int(image.byteSize),
+			jet.String(image.mimeType),
+		).
+		WHERE(clipboardItemTable.ID.EQ(jet.String(itemId)))
+
+	return database.Exec(queryBuilder)
+}
+
+// updateRecapturedClipboardItem sets the sensitivity and the expiry of the item anew, since the
+// sensitive content rules may have changed since it was first captured.
+func updateRecapturedClipboardItem(itemId string, createdAt uint64, isSensitive bool, expiresAt uint64) error {
+	clipboardItemTable := table.ClipboardItemTable
+	queryBuilder := clipboardItemTable.
+		UPDATE(clipboardItemTable.CreatedAt, clipboardItemTable.IsSensitive, clipboardItemTable.ExpiresAt).
+		SET(jet.Int(int64(createdAt)), jet.Bool(isSensitive), jet.Int(int64(expiresAt))).
+		WHERE(clipboardItemTable.ID.EQ(jet.String(itemId)))
+
+	return database.Exec(queryBuilder)
+}
+
+func updateClipboardItemFingerprint(itemId string, fingerprint int64) error {
+	clipboardItemTable := table.ClipboardItemTable
+	queryBuilder := clipboardItemTable.
+		UPDATE(clipboardItemTable.Fingerprint).
+		SET(jet.Int(fingerprint)).
+		WHERE(clipboardItemTable.ID.EQ(jet.String(itemId)))
+
+	return database.Exec(queryBuilder)
+}
+
+func findClipboardItemsByIdsTx(transaction *sqlx.Tx, itemIds []string) ([]model.ClipboardItem, error) {
+	clipboardItemTable := table.ClipboardItemTable
+	ids := make([]jet.Expression, 0, len(itemIds))
+	for _, itemId := range itemIds {
+		ids = append(ids, jet.String(itemId))
+	}
+
+	queryBuilder := clipboardItemTable.
+		SELECT(clipboardItemTable.AllColumns.As("")).
+		WHERE(clipboardItemTable.ID.IN(ids...))
+
+	clipboardItems, err := database.SelectManyTx[model.ClipboardItem](transaction, queryBuilder)
+	if err != nil {
+		return nil, err
+	}
+
+	return *clipboardItems, nil
+}
+
+func findUnpinnedClipboardItemsTx(transaction *sqlx.Tx) ([]model.ClipboardItem, error) {
+	clipboardItemTable := table.ClipboardItemTable
+	queryBuilder := clipboardItemTable.
+		SELECT(clipboardItemTable.AllColumns.As("")).
+		WHERE(clipboardItemTable.IsPinned.IS_FALSE())
+
+	clipboardItems, err := database.SelectManyTx[model.ClipboardItem](transaction, queryBuilder)
+	if err != nil {
+		return nil, err
+	}
+
+	return *clipboardItems, nil
+}
+
+// findExpiredClipboardItemsTx returns the unpinned items that expired at or before `now`.
+func findExpiredClipboardItemsTx(transaction *sqlx.Tx, now uint64) ([]model.ClipboardItem, error) {
+	clipboardItemTable := table.ClipboardItemTable
+	queryBuilder := clipboardItemTable.
+		SELECT(clipboardItemTable.AllColumns.As("")).
+		WHERE(
+			clipboardItemTable.IsPinned.IS_FALSE().
+				AND(clipboardItemTable.ExpiresAt.GT(jet.Int(0))).
+				AND(clipboardItemTable.ExpiresAt.LT_EQ(jet.Int(int64(now)))),
+		)
+
+	clipboardItems, err := database.SelectManyTx[model.ClipboardItem](transaction, queryBuilder)
+	if err != nil {
+		return nil, err
+	}
+
+	return *clipboardItems, nil
+}
+
+func updateClipboardItemPinnedStateTx(
+	ctx context.Context,
+	transaction *sqlx.Tx,
+	itemId string,
+	isPinned bool,
+	pinnedAt uint64,
+) error {
+	clipboardItemTable := table.ClipboardItemTable
+	queryBuilder := clipboardItemTable.
+		UPDATE(clipboardItemTable.IsPinned, clipboardItemTable.PinnedAt).
+		SET(jet.Bool(isPinned), jet.Int(int64(pinnedAt))).
+		WHERE(clipboardItemTable.ID.EQ(jet.String(itemId)))
+
+	return database.ExecTx(ctx, transaction, queryBuilder)
+}
+
+func deleteClipboardItemsByIdsTx(ctx context.Context, transaction *sqlx.Tx, itemIds []string) error {
+	clipboardItemTable := table.ClipboardItemTable
+	ids := make([]jet.Expression, 0, len(itemIds))
+	for _, itemId := range itemIds {
+		ids = append(ids, jet.String(itemId))
+	}
+
+	queryBuilder := clipboardItemTable.
+		DELETE().
+		WHERE(clipboardItemTable.ID.IN(ids...))
+
+	return database.ExecTx(ctx, transaction, queryBuilder)
+}
+
+func findClipboardItemsByEncryptionState(ctx context.Context, isEncrypted bool) ([]model.ClipboardItem, error) {
+	clipboardItemTable := table.ClipboardItemTable
+	queryBuilder := clipboardItemTable.
+		SELECT(clipboardItemTable.AllColumns.As("")).
+		WHERE(clipboardItemTable.IsEncrypted.EQ(jet.Bool(isEncrypted)))
+
+	clipboardItems, err := database.SelectMany[model.ClipboardItem](ctx, queryBuilder)
+	if err != nil {
+		return nil, err
+	}
+
+	return *clipboardItems, nil
+}
+
+func findImageClipboardItemsWithoutBlob(ctx context.Context) ([]model.ClipboardItem, error) {
+	clipboardItemTable := table.ClipboardItemTable
+	queryBuilder := clipboardItemTable.
+		SELECT(clipboardItemTable.AllColumns.As("")).
+		WHERE(
+			clipboardItemTable.Type.EQ(jet.String(strconv.Itoa(int(dto.ClipboardItemTypeImage)))).
+				AND(clipboardItemTable.ImageBlobHash.IS_NULL()),
+		)
+
+	clipboardItems, err := database.SelectMany[model.ClipboardItem](ctx, queryBuilder)
+	if err != nil {
+		return nil, err
+	}
+
+	return *clipboardItems, nil
+}
+
+// findEncryptedNonImageClipboardItems returns the newest items first.
+func findEncryptedNonImageClipboardItems(
+	ctx context.Context,
+	types []dto.ClipboardItemType,
+) ([]model.ClipboardItem, error) {
+	clipboardItemTable := table.ClipboardItemTable
+	condition := clipboardItemTable.IsEncrypted.IS_TRUE().
+		AND(clipboardItemTable.Type.NOT_EQ(jet.String(strconv.Itoa(int(dto.ClipboardItemTypeImage)))))
+
+	if len(types) > 0 {
+		typeExpressions := make([]jet.Expression, 0, len(types))
+		for _, clipboardItemType := range types {
+			typeExpressions = append(typeExpressions, jet.String(strconv.Itoa(int(clipboardItemType))))
+		}
+
+		condition = condition.AND(clipboardItemTable.Type.IN(typeExpressions...))
+	}
+
+	queryBuilder := clipboardItemTable.
+		SELECT(clipboardItemTable.AllColumns.As("")).
+		WHERE(condition).
+		ORDER_BY(clipboardItemTable.CreatedAt.DESC(), clipboardItemTable.ID.DESC())
+
+	clipboardItems, err := database.SelectMany[model.ClipboardItem](ctx, queryBuilder)
+	if err != nil {
+		return nil, err
+	}
+
+	return *clipboardItems, nil
+}
+
+func countEncryptedClipboardItems() (int64, error) {
+	clipboardItemTable := table.ClipboardItemTable
+	queryBuilder := clipboardItemTable.
+		SELECT(jet.COUNT(jet.STAR).AS("count")).
+		WHERE(clipboardItemTable.IsEncrypted.IS_TRUE())
+
+	var count int64
+
+	err := database.SelectInto(queryBuilder, &count)
+
+	return count, err
+}
+
+func updateClipboardItemEncryptionState(
+	itemId string,
+	content string,
+	richContent *string,
+	imageBlobHash *string,
+	fingerprint int64,
+	isEncrypted bool,
+) error {
+	richContentExpression := jet.Expression(jet.NULL)
+	if richContent != nil {
+		richContentExpression = jet.String(*richContent)
+	}
+
+	imageBlobHashExpression := jet.Expression(jet.NULL)
+	if imageBlobHash != nil {
+		imageBlobHashExpression = jet.String(*imageBlobHash)
+	}
+
+	clipboardItemTable := table.ClipboardItemTable
+	queryBuilder := clipboardItemTable.
+		UPDATE(
+			clipboardItemTable.Content,
+			clipboardItemTable.RichContent,
+			clipboardItemTable.ImageBlobHash,
+			clipboardItemTable.Fingerprint,
+			clipboardItemTable.IsEncrypted,
+		).
+		SET(
+			jet.String(content),
+			richContentExpression,
+			imageBlobHashExpression,
+			jet.Int(fingerprint),
+			jet.Bool(isEncrypted),
+		).
+		WHERE(clipboardItemTable.ID.EQ(jet.String(itemId)))
+
+	return database.Exec(queryBuilder)
+}
+
+func updateClipboardItemImageBlobHash(itemId string, imageBlobHash string) error {
+	clipboardItemTable := table.ClipboardItemTable
+	queryBuilder := clipboardItemTable.
+		UPDATE(clipboardItemTable.ImageBlobHash).
+		SET(jet.String(imageBlobHash)).
+		WHERE(clipboardItemTable.ID.EQ(jet.String(itemId)))
+
+	return database.Exec(queryBuilder)
+}
+
+func findImageBlob(hash string) (*model.ImageBlob, error) {
+	imageBlobTable := table.ImageBlobTable
+	queryBuilder := imageBlobTable.
+		SELECT(imageBlobTable.AllColumns.As("")).
+		WHERE(imageBlobTable.Hash.EQ(jet.String(hash)))
+
+	return database.SelectOne[model.ImageBlob](queryBuilder)
+}
+
+func findImageBlobs(ctx context.Context) ([]model.ImageBlob, error) {
+	imageBlobTable := table.ImageBlobTable
+	queryBuilder := imageBlobTable.SELECT(imageBlobTable.AllColumns.As(""))
+
+	imageBlobs, err := database.SelectMany[model.ImageBlob](ctx, queryBuilder)
+	if err != nil {
+		return nil, err
+	}
+
+	return *imageBlobs, nil
+}
+
+func findUnreferencedImageBlobs(ctx context.Context) ([]model.ImageBlob, error) {
+	imageBlobTable := table.ImageBlobTable
+	queryBuilder := imageBlobTable.
+		SELECT(imageBlobTable.AllColumns.As("")).
+		WHERE(imageBlobTable.ReferenceCount.LT_EQ(jet.Int(0)))
+
+	imageBlobs, err := database.SelectMany[model.ImageBlob](ctx, queryBuilder)
+	if err != nil {
+		return nil, err
+	}
+
+	return *imageBlobs, nil
+}
+
+// insertImageBlob starts the reference count at 0, the triggers on `tbl_clipboard_item` keep it up
+// to date as the items that reference the blob are inserted, updated and deleted.
+func insertImageBlob(imageBlobModel model.ImageBlob) error {
+	imageBlobTable := table.ImageBlobTable
+	queryBuilder := imageBlobTable.
+		INSERT(imageBlobTable.Hash, imageBlobTable.IsEncrypted, imageBlobTable.CreatedAt).
+		MODEL(imageBlobModel).
+		ON_CONFLICT(imageBlobTable.Hash).
+		DO_NOTHING()
+
+	return database.Exec(queryBuilder)
+}
+
+func deleteImageBlobs(hashes []string) error {
+	if len(hashes) == 0 {
+		return nil
+	}
+
+	imageBlobTable := table.ImageBlobTable
+	hashExpressions := make([]jet.Expression, 0, len(hashes))
+	for _, hash := range hashes {
+		hashExpressions = append(hashExpressions, jet.String(hash))
+	}
+
+	queryBuilder := imageBlobTable.
+		DELETE().
+		WHERE(imageBlobTable.Hash.IN(hashExpressions...))
+
+	return database.Exec(queryBuilder)
+}
+
+// findEncryptionSettings returns an empty result error until encryption has been turned on once.
+func findEncryptionSettings() (*model.EncryptionSettings, error) {
+	encryptionSettingsTable := table.EncryptionSettingsTable
+	queryBuilder := encryptionSettingsTable.
+		SELECT(encryptionSettingsTable.AllColumns.As("")).
+		WHERE(encryptionSettingsTable.ID.EQ(jet.Int(encryptionSettingsId)))
+
+	return database.SelectOne[model.EncryptionSettings](queryBuilder)
+}
+
+func upsertEncryptionSettings(encryptionSettingsModel model.EncryptionSettings) error {
+	encryptionSettingsTable := table.EncryptionSettingsTable
+	encryptionSettingsModel.ID = encryptionSettingsId
+	queryBuilder := encryptionSettingsTable.
+		INSERT(encryptionSettingsTable.AllColumns).
+		MODEL(encryptionSettingsModel).
+		ON_CONFLICT(encryptionSettingsTable.ID).
+		DO_UPDATE(
+			jet.SET(
+				encryptionSettingsTable.IsEnabled.SET(encryptionSettingsTable.EXCLUDED.IsEnabled),
+				encryptionSettingsTable.UpdatedAt.SET(encryptionSettingsTable.EXCLUDED.UpdatedAt),
+			),
+		)
+
+	return database.Exec(queryBuilder)
+}
+
+type clipboardItemSearchQueryResult struct {
+	model.ClipboardItem
+	Snippet string `db:"snippet"`
+}
+
+// searchClipboardItems ranks the items matching the FTS5 `matchExpression` in `ftsTableName` by bm25.
+func searchClipboardItems(
+	ctx context.Context,
+	ftsTableName string,
+	matchExpression string,
+	types []dto.ClipboardItemType,
+	limit int64,
+) ([]clipboardItemSearchQueryResult, error) {
+	args := jet.RawArgs{
+		"#matchExpression":   matchExpression,
+		"#snippetMatchStart": snippetMatchStart,
+		"#snippetMatchEnd":   snippetMatchEnd,
+		"#limit":             limit,
+	}
+	typeCondition := ""
+
+	if len(types) > 0 {
+		typePlaceholders := make([]string, 0, len(types))
+		for index, clipboardItemType := range types {
+			typePlaceholder := fmt.Sprintf("#type%d", index)
+			typePlaceholders = append(typePlaceholders, typePlaceholder)
+			args[typePlaceholder] = strconv.Itoa(int(clipboardItemType))
+		}
+
+		typeCondition = "AND item.type IN (" + strings.Join(typePlaceholders, ", ") + ")"
+	}
+
+	queryBuilder := jet.RawStatement(
+		fmt.Sprintf(
+			`
+			SELECT
+				item.id,
+				item.content,
+				item.rich_content,
+				item.type,
+				item.created_at,
+				item.is_pinned,
+				item.pinned_at,
+				item.is_sensitive,
+				item.expires_at,
+				snippet(%[1]s, 0, #snippetMatchStart, #snippetMatchEnd, '…', 16) AS snippet
+			FROM %[1]s
+			INNER JOIN tbl_clipboard_item AS item ON item.rowid = %[1]s.rowid
+			WHERE %[1]s MATCH #matchExpression %[2]s
+			ORDER BY bm25(%[1]s), item.created_at DESC
+			LIMIT #limit
+			`,
+			ftsTableName,
+			typeCondition,
+		),
+		args,
+	)
+
+	queryResults, err := database.SelectMany[clipboardItemSearchQueryResult](ctx, queryBuilder)
+	if err != nil {
+		return nil, err
+	}
+
+	return *queryResults, nil
+}
@@ -0,0 +1,289 @@
+package clipboard
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"cloudy-clip/desktop/internal/clipboard/dto"
+	"cloudy-clip/desktop/internal/common/utils"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+var (
+	htmlWhitespacePattern = regexp.MustCompile(`[ \t\r\n\f]+`)
+	// Elements that start on a new line when rendered.
+	htmlBlockElements = map[atom.Atom]bool{
+		atom.Address: true, atom.Article: true, atom.Aside: true, atom.Blockquote: true, atom.Br: true,
+		atom.Dd: true, atom.Div: true, atom.Dl: true, atom.Dt: true, atom.Figcaption: true, atom.Figure: true,
+		atom.Footer: true, atom.H1: true, atom.H2: true, atom.H3: true, atom.H4: true, atom.H5: true,
+		atom.H6: true, atom.Header: true, atom.Hr: true, atom.Li: true, atom.Main: true, atom.Nav: true,
+		atom.Ol: true, atom.P: true, atom.Pre: true, atom.Section: true, atom.Table: true, atom.Tr: true,
+		atom.Ul: true,
+	}
+	// Elements whose text is not rendered.
+	htmlHiddenElements = map[atom.Atom]bool{
+		atom.Head: true, atom.Noscript: true, atom.Script: true, atom.Style: true, atom.Template: true,
+		atom.Title: true,
+	}
+	// Groups that hold metadata instead of text, the ones marked with `\*` are skipped as well.
+	rtfDestinations = map[string]bool{
+		"author": true, "colortbl": true, "filetbl": true, "fonttbl": true, "footer": true, "footerf": true,
+		"footerl": true, "footerr": true, "header": true, "headerf": true, "headerl": true, "headerr": true,
+		"info": true, "listoverridetable": true, "listtable": true, "object": true, "operator": true,
+		"pict": true, "revtbl": true, "rsidtbl": true, "stylesheet": true, "title": true,
+	}
+	rtfControlWordTexts = map[string]string{
+		"bullet":    "•",
+		"cell":      "\t",
+		"emdash":    "—",
+		"endash":    "–",
+		"ldblquote": "“",
+		"line":      "\n",
+		"lquote":    "‘",
+		"par":       "\n",
+		"rdblquote": "”",
+		"row":       "\n",
+		"rquote":    "’",
+		"sect":      "\n",
+		"tab":       "\t",
+	}
+)
+
+// classifyClipboardContent returns the type of the item to capture along with its plain-text
+// content, and the markup of HTML and RTF items. Files take precedence over the color, which takes
+// precedence over text, so that the plain-text rendering every application adds is only used as
+// the fallback. The image is captured when there is nothing else, or when markup renders as no
+// text at all, e.g. when an image is copied from a web page.
+func classifyClipboardContent(content ClipboardContent) (dto.ClipboardItemType, string, *string) {
+	if len(content.FilePaths) > 0 {
+		return dto.ClipboardItemTypeFile, strings.Join(content.FilePaths, "\n"), nil
+	}
+
+	if content.Color != nil {
+		return dto.ClipboardItemTypeColor, *content.Color, nil
+	}
+
+	text := ""
+	if content.Text != nil {
+		text = strings.TrimSpace(*content.Text)
+	}
+
+	if utils.IsValidUrl(text) {
+		return dto.ClipboardItemTypeUrl, text, nil
+	}
+
+	if content.Html != nil {
+		if text == "" {
+			text = htmlToPlainText(*content.Html)
+		}
+
+		if text != "" || len(content.Image) == 0 {
+			return dto.ClipboardItemTypeHtml, text, content.Html
+		}
+	} else if content.Rtf != nil {
+		if text == "" {
+			text = rtfToPlainText(*content.Rtf)
+		}
+
+		if text != "" || len(content.Image) == 0 {
+			return dto.ClipboardItemTypeRtf, text, content.Rtf
+		}
+	} else if content.Text != nil {
+		return dto.ClipboardItemTypeText, text, nil
+	}
+
+	if len(content.Image) > 0 {
+		return dto.ClipboardItemTypeImage, "", nil
+	}
+
+	return dto.ClipboardItemTypeUnknown, "", nil
+}
+
+// htmlToPlainText renders the text of the markup, with block elements on their own lines.
+func htmlToPlainText(markup string) string {
+	var plainText strings.Builder
+	tokenizer := html.NewTokenizer(strings.NewReader(markup))
+	hiddenElementDepth := 0
+
+	for {
+		tokenType := tokenizer.Next()
+
+		switch tokenType {
+		case html.ErrorToken:
+			return joinNonEmptyLines(plainText.String())
+
+		case html.TextToken:
+			if hiddenElementDepth == 0 {
+				plainText.WriteString(htmlWhitespacePattern.ReplaceAllString(string(tokenizer.Text()), " "))
+			}
+
+		case html.StartTagToken, html.SelfClosingTagToken, html.EndTagToken:
+			tagName, _ := tokenizer.TagName()
+			element := atom.Lookup(tagName)
+
+			if htmlHiddenElements[element] {
+				if tokenType == html.StartTagToken {
+					hiddenElementDepth++
+				} else if tokenType == html.EndTagToken && hiddenElementDepth > 0 {
+					hiddenElementDepth--
+				}
+			} else if htmlBlockElements[element] {
+				plainText.WriteByte('\n')
+			}
+		}
+	}
+}
+
+// rtfToPlainText strips the control words and groups from the RTF source. Characters escaped as
+// `\'hh` are read as Latin-1, which is what most documents that escape them use.
+func rtfToPlainText(source string) string {
+	type rtfGroup struct {
+		isSkipped bool
+		// How many characters follow `\uN` for readers that do not understand it, as set by `\ucN`.
+		unicodeFallbackLength int
+	}
+
+	var plainText strings.Builder
+	groups := []rtfGroup{{unicodeFallbackLength: 1}}
+	skippedCharacterCount := 0
+
+	write := func(text string) {
+		if groups[len(groups)-1].isSkipped {
+			return
+		}
+
+		if skippedCharacterCount > 0 {
+			skippedCharacterCount--
+
+			return
+		}
+
+		plainText.WriteString(text)
+	}
+
+	for index := 0; index < len(source); {
+		group := &groups[len(groups)-1]
+
+		switch character := source[index]; character {
+		case '{':
+			groups = append(groups, *group)
+			index++
+
+		case '}':
+			if len(groups) > 1 {
+				groups = groups[:len(groups)-1]
+			}
+
+			index++
+
+		case '\r', '\n':
+			index++
+
+		case '\\':
+			if index+1 >= len(source) {
+				return joinNonEmptyLines(plainText.String())
+			}
+
+			controlCharacter := source[index+1]
+			index += 2
+
+			switch {
+			case isAsciiLetter(controlCharacter):
+				wordEnd := index
+				for wordEnd < len(source) && isAsciiLetter(source[wordEnd]) {
+					wordEnd++
+				}
+
+				parameterEnd := wordEnd
+				if parameterEnd < len(source) && source[parameterEnd] == '-' {
+					parameterEnd++
+				}
+
+				for parameterEnd < len(source) && source[parameterEnd] >= '0' && source[parameterEnd] <= '9' {
+					parameterEnd++
+				}
+
+				word := source[index-1 : wordEnd]
+				parameter, _ := strconv.Atoi(source[wordEnd:parameterEnd])
+				index = parameterEnd
+
+				// A space ends the control word and is not part of the text.
+				if index < len(source) && source[index] == ' ' {
+					index++
+				}
+
+				switch {
+				case word == "u":
+					// Code points above 32767 are written as negative numbers.
+					if parameter < 0 {
+						parameter += 65536
+					}
+
+					write(string(rune(parameter)))
+					skippedCharacterCount = group.unicodeFallbackLength
+
+				case word == "uc":
+					group.unicodeFallbackLength = parameter
+
+				case rtfDestinations[word]:
+					group.isSkipped = true
+
+				default:
+					if text, isText := rtfControlWordTexts[word]; isText {
+						write(text)
+					}
+				}
+
+			case controlCharacter == '*':
+				group.isSkipped = true
+
+			case controlCharacter == '\'':
+				if index+2 <= len(source) {
+					if value, err := strconv.ParseUint(source[index:index+2], 16, 8); err == nil {
+						write(string(rune(value)))
+					}
+
+					index += 2
+				}
+
+			case controlCharacter == '\\' || controlCharacter == '{' || controlCharacter == '}':
+				write(string(controlCharacter))
+
+			case controlCharacter == '~':
+				write(" ")
+
+			case controlCharacter == '_':
+				write("-")
+
+			case controlCharacter == '\r' || controlCharacter == '\n':
+				write("\n")
+			}
+
+		default:
+			write(source[index : index+1])
+			index++
+		}
+	}
+
+	return joinNonEmptyLines(plainText.String())
+}
+
+func isAsciiLetter(character byte) bool {
+	return (character >= 'a' && character <= 'z') || (character >= 'A' && character <= 'Z')
+}
+
+func joinNonEmptyLines(text string) string {
+	var lines []string
+
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,324 @@
+package clipboard
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"log/slog"
+	"slices"
+	"strings"
+	"unicode"
+
+	"cloudy-clip/desktop/internal/clipboard/dto"
+	"cloudy-clip/desktop/internal/common/exception"
+	"cloudy-clip/desktop/internal/common/logging"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	clipboardItemFtsTableName        = "tbl_clipboard_item_fts"
+	clipboardItemTrigramFtsTableName = "tbl_clipboard_item_trigram_fts"
+	defaultSearchResultLimit         = 50
+	maxSearchResultLimit             = 200
+	trigramLength                    = 3
+	// Same as in the snippet function of `searchClipboardItems`.
+	snippetTokenCount = 16
+	snippetEllipsis   = "…"
+	// Private use characters that delimit the matches of a snippet until `escapeSnippet` replaces
+	// them with <mark></mark>.
+	snippetMatchStart = "\uE000"
+	snippetMatchEnd   = "\uE001"
+)
+
+var (
+	snippetMarkReplacer = strings.NewReplacer(snippetMatchStart, "<mark>", snippetMatchEnd, "</mark>")
+)
+
+// SearchClipboardItems returns the items that contain every word of the text, the last word of
+// which may be incomplete, best matches first. When there are none, it falls back to the items
+// that share the most 3-character sequences with the text, so that typos still find something.
+// Encrypted items are not indexed, they are decrypted and matched after the others, newest first,
+// and are left out of the fallback.
+func SearchClipboardItems(
+	ctx context.Context,
+	query dto.SearchClipboardItemsQuery,
+) (dto.ClipboardItemSearchResults, error) {
+	ctx = context.WithValue(ctx, logging.LoggerContextCallSiteKey, "SearchClipboardItems")
+
+	searchResults, err := searchClipboardItemsByText(ctx, query)
+	if err == nil {
+		return searchResults, nil
+	}
+
+	logger.ErrorAttrs(ctx, err, "failed to search clipboard items", slog.Any("query", query))
+
+	return dto.ClipboardItemSearchResults{}, exception.GetAsApplicationException(
+		err,
+		"failed to search clipboard items",
+	)
+}
+
+func searchClipboardItemsByText(
+	ctx context.Context,
+	query dto.SearchClipboardItemsQuery,
+) (dto.ClipboardItemSearchResults, error) {
+	limit := query.Limit
+	if limit == 0 {
+		limit = defaultSearchResultLimit
+	}
+
+	if limit < 1 || limit > maxSearchResultLimit {
+		return dto.ClipboardItemSearchResults{}, errors.WithStack(exception.NewValidationExceptionWithExtra(
+			fmt.Sprintf("limit must be between 1 and %d", maxSearchResultLimit),
+			map[string]any{
+				"limit": limit,
+			},
+		))
+	}
+
+	words := splitIntoWords(query.Text)
+	if len(words) == 0 {
+		return dto.ClipboardItemSearchResults{Items: []dto.ClipboardItemSearchResult{}}, nil
+	}
+
+	queryResults, err := searchClipboardItems(
+		ctx,
+		clipboardItemFtsTableName,
+		newPrefixMatchExpression(words),
+		query.Types,
+		int64(limit),
+	)
+	if err != nil {
+		return dto.ClipboardItemSearchResults{}, err
+	}
+
+	if len(queryResults) < limit {
+		encryptedQueryResults, err := searchEncryptedClipboardItems(ctx, words, query.Types, limit-len(queryResults))
+		if err != nil {
+			return dto.ClipboardItemSearchResults{}, err
+		}
+
+		queryResults = append(queryResults, encryptedQueryResults...)
+	}
+
+	isFuzzy := false
+
+	if trigramMatchExpression := newTrigramMatchExpression(words); len(queryResults) == 0 && trigramMatchExpression != "" {
+		isFuzzy = true
+		queryResults, err = searchClipboardItems(
+			ctx,
+			clipboardItemTrigramFtsTableName,
+			trigramMatchExpression,
+			query.Types,
+			int64(limit),
+		)
+		if err != nil {
+			return dto.ClipboardItemSearchResults{}, err
+		}
+	}
+
+	searchResults := dto.ClipboardItemSearchResults{
+		Items:   make([]dto.ClipboardItemSearchResult, 0, len(queryResults)),
+		IsFuzzy: isFuzzy,
+	}
+
+	for _, queryResult := range queryResults {
+		clipboardItem, err := newClipboardItem(queryResult.ClipboardItem)
+		if err != nil {
+			return dto.ClipboardItemSearchResults{}, err
+		}
+
+		searchResults.Items = append(
+			searchResults.Items,
+			dto.ClipboardItemSearchResult{
+				ClipboardItem: clipboardItem,
+				Snippet:       escapeSnippet(queryResult.Snippet),
+			},
+		)
+	}
+
+	return searchResults, nil
+}
+
+// searchEncryptedClipboardItems matches words the same way as the prefix search, except that
+// diacritics have to match as well.
+func searchEncryptedClipboardItems(
+	ctx context.Context,
+	words []string,
+	types []dto.ClipboardItemType,
+	limit int,
+) ([]clipboardItemSearchQueryResult, error) {
+	clipboardItemModels, err := findEncryptedNonImageClipboardItems(ctx, types)
+	if err != nil {
+		return nil, err
+	}
+
+	var queryResults []clipboardItemSearchQueryResult
+
+	for _, clipboardItemModel := range clipboardItemModels {
+		if len(queryResults) == limit {
+			break
+		}
+
+		content, err := openClipboardItemContent(clipboardItemModel)
+		if err != nil {
+			return nil, err
+		}
+
+		tokens := splitIntoTokens(content)
+		if !containsEveryWord(tokens, words) {
+			continue
+		}
+
+		queryResults = append(queryResults, clipboardItemSearchQueryResult{
+			ClipboardItem: clipboardItemModel,
+			Snippet:       newSnippet(content, tokens, words),
+		})
+	}
+
+	return queryResults, nil
+}
+
+// token is a word of some content, along with where it is in the runes of the content.
+type token struct {
+	word  string
+	start int
+	end   int
+}
+
+func splitIntoTokens(content string) []token {
+	var tokens []token
+
+	characters := []rune(content)
+	start := -1
+
+	for index := 0; index <= len(characters); index++ {
+		isWordCharacter := index < len(characters) &&
+			(unicode.IsLetter(characters[index]) || unicode.IsNumber(characters[index]))
+		if isWordCharacter && start == -1 {
+			start = index
+		} else if !isWordCharacter && start != -1 {
+			tokens = append(tokens, token{
+				word:  strings.ToLower(string(characters[start:index])),
+				start: start,
+				end:   index,
+			})
+			start = -1
+		}
+	}
+
+	return tokens
+}
+
+func containsEveryWord(tokens []token, words []string) bool {
+	for wordIndex := range words {
+		if !slices.ContainsFunc(tokens, func(token token) bool {
+			return matchesWord(token, words, wordIndex)
+		}) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// matchesWord treats the last word as a prefix, like `newPrefixMatchExpression` does.
+func matchesWord(token token, words []string, wordIndex int) bool {
+	if wordIndex == len(words)-1 {
+		return strings.HasPrefix(token.word, words[wordIndex])
+	}
+
+	return token.word == words[wordIndex]
+}
+
+// newSnippet mimics the FTS5 snippet function, it returns up to `snippetTokenCount` tokens around
+// the first match with every match delimited by `snippetMatchStart` and `snippetMatchEnd`.
+func newSnippet(content string, tokens []token, words []string) string {
+	isMatch := func(token token) bool {
+		for wordIndex := range words {
+			if matchesWord(token, words, wordIndex) {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	firstMatchIndex := max(slices.IndexFunc(tokens, isMatch), 0)
+	firstTokenIndex := max(firstMatchIndex-snippetTokenCount/4, 0)
+	lastTokenIndex := min(firstTokenIndex+snippetTokenCount, len(tokens)) - 1
+
+	characters := []rune(content)
+	var snippet strings.Builder
+
+	start := 0
+	if firstTokenIndex > 0 {
+		snippet.WriteString(snippetEllipsis)
+		start = tokens[firstTokenIndex].start
+	}
+
+	for _, token := range tokens[firstTokenIndex : lastTokenIndex+1] {
+		snippet.WriteString(string(characters[start:token.start]))
+
+		if isMatch(token) {
+			snippet.WriteString(snippetMatchStart + string(characters[token.start:token.end]) + snippetMatchEnd)
+		} else {
+			snippet.WriteString(string(characters[token.start:token.end]))
+		}
+
+		start = token.end
+	}
+
+	if lastTokenIndex < len(tokens)-1 {
+		snippet.WriteString(snippetEllipsis)
+	} else {
+		snippet.WriteString(string(characters[start:]))
+	}
+
+	return snippet.String()
+}
+
+// escapeSnippet escapes the content of the snippet before marking its matches, so that the marks
+// are the only markup that the UI renders.
+func escapeSnippet(snippet string) string {
+	return snippetMarkReplacer.Replace(html.EscapeString(snippet))
+}
+
+func splitIntoWords(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(character rune) bool {
+		return !unicode.IsLetter(character) && !unicode.IsNumber(character)
+	})
+}
+
+// newPrefixMatchExpression requires every word, and treats the last one as a prefix since it
+// may still be being typed. Words only hold letters and numbers, so quoting them is enough to
+// keep them from being interpreted as FTS5 syntax.
+func newPrefixMatchExpression(words []string) string {
+	terms := make([]string, 0, len(words))
+	for _, word := range words {
+		terms = append(terms, `"`+word+`"`)
+	}
+
+	terms[len(terms)-1] += "*"
+
+	return strings.Join(terms, " ")
+}
+
+// newTrigramMatchExpression returns an empty string when none of the words is long enough to
+// have a trigram.
+func newTrigramMatchExpression(words []string) string {
+	var trigrams []string
+
+	for _, word := range words {
+		characters := []rune(word)
+		for index := 0; index+trigramLength <= len(characters); index++ {
+			trigram := `"` + string(characters[index:index+trigramLength]) + `"`
+			if !slices.Contains(trigrams, trigram) {
+				trigrams = append(trigrams, trigram)
+			}
+		}
+	}
+
+	return strings.Join(trigrams, " OR ")
+}
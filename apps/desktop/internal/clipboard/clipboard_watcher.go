@@ -0,0 +1,134 @@
+package clipboard
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"cloudy-clip/desktop/internal/clipboard/dto"
+	"cloudy-clip/desktop/internal/common/logging"
+)
+
+const (
+	// The name of the Wails event that carries every newly captured `dto.ClipboardItem` to the UI.
+	ClipboardItemCapturedEventName = "clipboard:item-captured"
+)
+
+// ClipboardWatcher polls the change count of the clipboard backend and captures the latest item
+// once the clipboard has stopped changing for the debounce interval, so that only the last of
+// several rapid copies is kept.
+type ClipboardWatcher struct {
+	pollInterval     time.Duration
+	debounceInterval time.Duration
+	onItemCaptured   func(item dto.ClipboardItem)
+	isPaused         atomic.Bool
+	stopSignal       chan struct{}
+	stopped          chan struct{}
+	startOnce        sync.Once
+	stopOnce         sync.Once
+}
+
+func NewClipboardWatcher(
+	pollInterval time.Duration,
+	debounceInterval time.Duration,
+	onItemCaptured func(item dto.ClipboardItem),
+) *ClipboardWatcher {
+	return &ClipboardWatcher{
+		pollInterval:     pollInterval,
+		debounceInterval: debounceInterval,
+		onItemCaptured:   onItemCaptured,
+		stopSignal:       make(chan struct{}),
+		stopped:          make(chan struct{}),
+	}
+}
+
+func (watcher *ClipboardWatcher) Start() {
+	watcher.startOnce.Do(func() {
+		go watcher.watch()
+	})
+}
+
+// Stop blocks until the item being captured, if any, has been persisted.
+func (watcher *ClipboardWatcher) Stop() {
+	watcher.stopOnce.Do(func() {
+		close(watcher.stopSignal)
+	})
+
+	watcher.startOnce.Do(func() {
+		close(watcher.stopped)
+	})
+
+	<-watcher.stopped
+}
+
+// Pause stops capturing until `Resume` is called, whatever is copied in the meantime is never captured.
+func (watcher *ClipboardWatcher) Pause() {
+	watcher.isPaused.Store(true)
+}
+
+func (watcher *ClipboardWatcher) Resume() {
+	watcher.isPaused.Store(false)
+}
+
+func (watcher *ClipboardWatcher) IsPaused() bool {
+	return watcher.isPaused.Load()
+}
+
+func (watcher *ClipboardWatcher) watch() {
+	defer close(watcher.stopped)
+
+	ctx := context.WithValue(context.Background(), logging.LoggerContextCallSiteKey, "ClipboardWatcher")
+	ticker := time.NewTicker(watcher.pollInterval)
+	defer ticker.Stop()
+
+	// Start from the current count so that whatever was copied before startup is captured right away.
+	lastChangeCount := int64(-1)
+	var lastChangedAt time.Time
+	hasPendingChange := false
+
+	for {
+		select {
+		case <-watcher.stopSignal:
+			return
+
+		case now := <-ticker.C:
+			changeCount, err := backend.ChangeCount()
+			if err != nil {
+				logger.ErrorAttrs(ctx, err, "failed to get clipboard change count")
+
+				continue
+			}
+
+			if changeCount != lastChangeCount {
+				lastChangeCount = changeCount
+				lastChangedAt = now
+				hasPendingChange = !watcher.IsPaused()
+
+				continue
+			}
+
+			if !hasPendingChange || watcher.IsPaused() || now.Sub(lastChangedAt) < watcher.debounceInterval {
+				continue
+			}
+
+			hasPendingChange = false
+
+			// Checked once the clipboard has settled, since the write back records its change count
+			// right after writing, which may be after this loop has seen the change.
+			if isWriteBackChangeCount(lastChangeCount) {
+				continue
+			}
+
+			item := GetLatestClipboardItem()
+			if item.Id == "" {
+				continue
+			}
+
+			logger.DebugAttrs(ctx, "captured clipboard item", slog.String("itemId", item.Id))
+
+			watcher.onItemCaptured(item)
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package clipboard
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"cloudy-clip/desktop/internal/clipboard/dto"
+	"cloudy-clip/desktop/internal/common/database"
+	"cloudy-clip/desktop/internal/common/exception"
+	"cloudy-clip/desktop/internal/common/logging"
+	"cloudy-clip/desktop/internal/common/utils"
+)
+
+var (
+	// Held while writing to the clipboard, so that the recorded change count is the one of the write.
+	writeBackMutex sync.Mutex
+	// The change count of the clipboard right after the last write back, -1 before the first one.
+	writeBackChangeCount int64 = -1
+)
+
+// CopyToClipboard writes the item back onto the system clipboard and moves it to the top of the
+// history. The watcher skips the change caused by the write, and should it capture the clipboard
+// anyway, the item already is the newest one so nothing new is stored.
+func CopyToClipboard(ctx context.Context, itemId string) (dto.ClipboardItem, error) {
+	ctx = context.WithValue(ctx, logging.LoggerContextCallSiteKey, "CopyToClipboard")
+
+	clipboardItem, err := copyToClipboard(itemId)
+	if err == nil {
+		return clipboardItem, nil
+	}
+
+	if database.IsEmptyResultError(err) {
+		return dto.ClipboardItem{}, exception.NewNotFoundException("clipboard item was not found")
+	}
+
+	logger.ErrorAttrs(ctx, err, "failed to copy clipboard item to clipboard", slog.String("itemId", itemId))
+
+	return dto.ClipboardItem{}, exception.GetAsApplicationException(err, "failed to copy clipboard item to clipboard")
+}
+
+func copyToClipboard(itemId string) (dto.ClipboardItem, error) {
+	clipboardItemModel, err := findClipboardItemById(itemId)
+	if err != nil {
+		return dto.ClipboardItem{}, err
+	}
+
+	contentBytes, err := readClipboardItemContentBytes(*clipboardItemModel)
+	if err != nil {
+		return dto.ClipboardItem{}, err
+	}
+
+	richContent, err := openClipboardItemRichContent(*clipboardItemModel)
+	if err != nil {
+		return dto.ClipboardItem{}, err
+	}
+
+	err = writeBack(newClipboardContent(clipboardItemModel.Type, contentBytes, richContent))
+	if err != nil {
+		return dto.ClipboardItem{}, err
+	}
+
+	clipboardItemModel.CreatedAt = uint64(time.Now().UnixMilli())
+
+	err = utils.Retry(func() error {
+		return updateRecapturedClipboardItem(
+			clipboardItemModel.ID,
+			clipboardItemModel.CreatedAt,
+			clipboardItemModel.IsSensitive,
+			clipboardItemModel.ExpiresAt,
+		)
+	})
+	if err != nil {
+		return dto.ClipboardItem{}, err
+	}
+
+	return newClipboardItem(*clipboardItemModel)
+}
+
+// newClipboardContent offers the plain-text content along with the HTML, RTF, files or color, so
+// that applications which do not understand them can still paste the item.
+func newClipboardContent(itemType dto.ClipboardItemType, contentBytes []byte, richContent *string) ClipboardContent {
+	if itemType == dto.ClipboardItemTypeImage {
+		return NewImageClipboardContent(contentBytes)
+	}
+
+	content := NewTextClipboardContent(string(contentBytes))
+
+	switch itemType {
+	case dto.ClipboardItemTypeHtml:
+		content.Html = richContent
+
+	case dto.ClipboardItemTypeRtf:
+		content.Rtf = richContent
+
+	case dto.ClipboardItemTypeFile:
+		content.FilePaths = strings.Split(*content.Text, "\n")
+
+	case dto.ClipboardItemTypeColor:
+		content.Color = content.Text
+	}
+
+	return content
+}
+
+func writeBack(content ClipboardContent) error {
+	writeBackMutex.Lock()
+	defer writeBackMutex.Unlock()
+
+	err := backend.WriteItem(content)
+	if err != nil {
+		return err
+	}
+
+	changeCount, err := backend.ChangeCount()
+	if err != nil {
+		return err
+	}
+
+	writeBackChangeCount = changeCount
+
+	return nil
+}
+
+// isWriteBackChangeCount tells whether the clipboard last changed because an item was written back.
+func isWriteBackChangeCount(changeCount int64) bool {
+	writeBackMutex.Lock()
+	defer writeBackMutex.Unlock()
+
+	return changeCount == writeBackChangeCount
+}
@@ -0,0 +1,7 @@
+package dto
+
+// ClipboardArchiveProgress is carried by the events that report the progress of an export or an import.
+type ClipboardArchiveProgress struct {
+	ProcessedItemCount int `json:"processedItemCount"`
+	TotalItemCount     int `json:"totalItemCount"`
+}
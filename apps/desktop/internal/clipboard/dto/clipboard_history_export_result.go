@@ -0,0 +1,9 @@
+package dto
+
+type ClipboardHistoryExportResult struct {
+	// Empty when the user cancelled choosing where to save the archive.
+	FilePath          string `json:"filePath"`
+	ExportedItemCount int    `json:"exportedItemCount"`
+	// Images whose file could not be found.
+	SkippedItemCount int `json:"skippedItemCount"`
+}
@@ -0,0 +1,11 @@
+package dto
+
+type ClipboardHistoryImportResult struct {
+	// Empty when the user cancelled choosing the archive to import.
+	FilePath          string `json:"filePath"`
+	ImportedItemCount int    `json:"importedItemCount"`
+	// Items that were already in the history.
+	DuplicateItemCount int `json:"duplicateItemCount"`
+	// Items that could not be read from the archive, such as an image that is missing or corrupted.
+	SkippedItemCount int `json:"skippedItemCount"`
+}
@@ -13,6 +13,14 @@ const (
 	ClipboardItemTypeText
 	ClipboardItemTypeImage
 	ClipboardItemTypeUrl
+	// The content is the plain-text fallback and the markup is kept in the rich content.
+	ClipboardItemTypeHtml
+	// The content is the plain-text fallback and the RTF source is kept in the rich content.
+	ClipboardItemTypeRtf
+	// The content holds the absolute paths of the copied files, one per line.
+	ClipboardItemTypeFile
+	// The content holds the color as `#rrggbb`, or as `#rrggbbaa` when it is not opaque.
+	ClipboardItemTypeColor
 )
 
 func (itemType ClipboardItemType) MarshalJSON() ([]byte, error) {
@@ -27,6 +35,14 @@ func (itemType ClipboardItemType) String() string {
 		return "IMAGE"
 	case ClipboardItemTypeUrl:
 		return "URL"
+	case ClipboardItemTypeHtml:
+		return "HTML"
+	case ClipboardItemTypeRtf:
+		return "RTF"
+	case ClipboardItemTypeFile:
+		return "FILE"
+	case ClipboardItemTypeColor:
+		return "COLOR"
 	case ClipboardItemTypeUnknown:
 		return "UNKNOWN"
 	}
@@ -48,18 +64,36 @@ func (itemType *ClipboardItemType) UnmarshalJSON(buf []byte) error {
 		*itemType = ClipboardItemTypeImage
 	case "URL":
 		*itemType = ClipboardItemTypeUrl
+	case "HTML":
+		*itemType = ClipboardItemTypeHtml
+	case "RTF":
+		*itemType = ClipboardItemTypeRtf
+	case "FILE":
+		*itemType = ClipboardItemTypeFile
+	case "COLOR":
+		*itemType = ClipboardItemTypeColor
 	case "UNKNOWN":
 		*itemType = ClipboardItemTypeUnknown
+	default:
+		return errors.New("unknown content item type '" + itemTypeString + "'")
 	}
 
-	return errors.New("unknown content item type '" + itemTypeString + "'")
+	return nil
 }
 
 type ClipboardItem struct {
-	Id        string            `json:"id"`
-	Type      ClipboardItemType `json:"type" ts_type:"'TEXT'|'IMAGE'|'URL'"`
-	Content   string            `json:"content"`
-	CreatedAt uint64            `json:"createdAt"`
-	IsPinned  bool              `json:"isPinned"`
-	PinnedAt  uint64            `json:"pinnedAt"`
+	Id      string            `json:"id"`
+	Type    ClipboardItemType `json:"type" ts_type:"'TEXT'|'IMAGE'|'URL'|'HTML'|'RTF'|'FILE'|'COLOR'"`
+	Content string            `json:"content"`
+	// The HTML markup or the RTF source, nil for every other type.
+	RichContent *string `json:"richContent"`
+	CreatedAt   uint64  `json:"createdAt"`
+	IsPinned    bool    `json:"isPinned"`
+	PinnedAt    uint64  `json:"pinnedAt"`
+	// Sensitive items are never synced to the cloud.
+	IsSensitive bool `json:"isSensitive"`
+	// When the item is going to be deleted unless it is pinned, 0 when it does not expire.
+	ExpiresAt uint64 `json:"expiresAt"`
+	// Nil for every type but images, and for images that have not been measured yet.
+	ImageMetadata *ImageMetadata `json:"imageMetadata"`
 }
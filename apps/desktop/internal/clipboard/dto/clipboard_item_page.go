@@ -0,0 +1,7 @@
+package dto
+
+type ClipboardItemPage struct {
+	Items      []ClipboardItem `json:"items"`
+	NextCursor string          `json:"nextCursor"`
+	HasMore    bool            `json:"hasMore"`
+}
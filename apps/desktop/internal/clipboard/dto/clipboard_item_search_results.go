@@ -0,0 +1,13 @@
+package dto
+
+type ClipboardItemSearchResult struct {
+	ClipboardItem
+	// A fragment of the content with the matched terms wrapped in <mark></mark>, the content is HTML-escaped.
+	Snippet string `json:"snippet"`
+}
+
+type ClipboardItemSearchResults struct {
+	Items []ClipboardItemSearchResult `json:"items"`
+	// Whether none of the items contain the words and they only resemble them instead.
+	IsFuzzy bool `json:"isFuzzy"`
+}
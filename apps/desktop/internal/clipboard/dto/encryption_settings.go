@@ -0,0 +1,9 @@
+package dto
+
+type EncryptionSettings struct {
+	// Whether the content of new items and their images are encrypted at rest.
+	IsEnabled bool `json:"isEnabled"`
+	// Whether the key could be loaded from the keyring, encrypted items cannot be read nor
+	// new ones captured without it.
+	IsKeyAvailable bool `json:"isKeyAvailable"`
+}
@@ -0,0 +1,12 @@
+package dto
+
+// ExportClipboardHistoryRequest leaves out every filter that has its zero value, exporting the whole
+// history when none is set.
+type ExportClipboardHistoryRequest struct {
+	Types []ClipboardItemType `json:"types" ts_type:"('TEXT'|'IMAGE'|'URL'|'HTML'|'RTF'|'FILE'|'COLOR')[]"`
+	// Unix milliseconds, inclusive.
+	CreatedFrom uint64 `json:"createdFrom"`
+	// Unix milliseconds, exclusive.
+	CreatedTo uint64 `json:"createdTo"`
+	IsPinned  *bool  `json:"isPinned"`
+}
@@ -0,0 +1,14 @@
+package dto
+
+// GetClipboardItemsQuery leaves out every filter that has its zero value.
+type GetClipboardItemsQuery struct {
+	// The `NextCursor` of the previous page, empty for the first page.
+	Cursor string              `json:"cursor"`
+	Limit  int                 `json:"limit"`
+	Types  []ClipboardItemType `json:"types" ts_type:"('TEXT'|'IMAGE'|'URL'|'HTML'|'RTF'|'FILE'|'COLOR')[]"`
+	// Unix milliseconds, inclusive.
+	CreatedFrom uint64 `json:"createdFrom"`
+	// Unix milliseconds, exclusive.
+	CreatedTo uint64 `json:"createdTo"`
+	IsPinned  *bool  `json:"isPinned"`
+}
@@ -0,0 +1,10 @@
+package dto
+
+type ImageMetadata struct {
+	Width    int32  `json:"width"`
+	Height   int32  `json:"height"`
+	ByteSize int64  `json:"byteSize"`
+	MimeType string `json:"mimeType"`
+	// Where the downscaled image is served, while `ClipboardItem.Content` points at the whole image.
+	ThumbnailUrl string `json:"thumbnailUrl"`
+}
@@ -0,0 +1,7 @@
+package dto
+
+type SearchClipboardItemsQuery struct {
+	Text  string              `json:"text"`
+	Types []ClipboardItemType `json:"types" ts_type:"('TEXT'|'IMAGE'|'URL'|'HTML'|'RTF'|'FILE'|'COLOR')[]"`
+	Limit int                 `json:"limit"`
+}
@@ -0,0 +1,5 @@
+package dto
+
+type UpdateEncryptionSettingsRequest struct {
+	IsEnabled bool `json:"isEnabled"`
+}
@@ -1,6 +1,8 @@
 package database
 
 import (
+	"database/sql"
+
 	"github.com/pkg/errors"
 	"modernc.org/sqlite"
 )
@@ -28,5 +30,5 @@ func IsEmptyResultError(err error) bool {
 		return false
 	}
 
-	return err.Error() == "no rows in result set"
+	return errors.Is(err, sql.ErrNoRows) || err.Error() == "no rows in result set"
 }
@@ -12,10 +12,20 @@ import (
 )
 
 type ClipboardItem struct {
-	ID        string                `sql:"primary_key" db:"id"`
-	Content   string                `db:"content"`
-	Type      dto.ClipboardItemType `db:"type"`
-	CreatedAt uint64                `db:"created_at"`
-	IsPinned  bool                  `db:"is_pinned"`
-	PinnedAt  uint64                `db:"pinned_at"`
+	ID            string                `sql:"primary_key" db:"id"`
+	Content       string                `db:"content"`
+	Type          dto.ClipboardItemType `db:"type"`
+	CreatedAt     uint64                `db:"created_at"`
+	IsPinned      bool                  `db:"is_pinned"`
+	PinnedAt      uint64                `db:"pinned_at"`
+	Fingerprint   *int64                `db:"fingerprint"`
+	IsEncrypted   bool                  `db:"is_encrypted"`
+	IsSensitive   bool                  `db:"is_sensitive"`
+	ExpiresAt     uint64                `db:"expires_at"`
+	RichContent   *string               `db:"rich_content"`
+	ImageWidth    *int32                `db:"image_width"`
+	ImageHeight   *int32                `db:"image_height"`
+	ImageByteSize *int64                `db:"image_byte_size"`
+	ImageMimeType *string               `db:"image_mime_type"`
+	ImageBlobHash *string               `db:"image_blob_hash"`
 }
@@ -11,4 +11,10 @@ package table
 // this method only once at the beginning of the program.
 func UseSchema(schema string) {
 	ClipboardItemTable = ClipboardItemTable.FromSchema(schema)
+	EncryptionSettingsTable = EncryptionSettingsTable.FromSchema(schema)
+	IgnoreRuleTable = IgnoreRuleTable.FromSchema(schema)
+	ImageBlobTable = ImageBlobTable.FromSchema(schema)
+	IncognitoModeTable = IncognitoModeTable.FromSchema(schema)
+	RetentionPolicyTable = RetentionPolicyTable.FromSchema(schema)
+	SensitiveContentRuleTable = SensitiveContentRuleTable.FromSchema(schema)
 }
@@ -17,12 +17,22 @@ type tblClipboardItem struct {
 	sqlite.Table
 
 	// Columns
-	ID        sqlite.ColumnString
-	Content   sqlite.ColumnString
-	Type      sqlite.ColumnString
-	CreatedAt sqlite.ColumnInteger
-	IsPinned  sqlite.ColumnBool
-	PinnedAt  sqlite.ColumnInteger
+	ID            sqlite.ColumnString
+	Content       sqlite.ColumnString
+	Type          sqlite.ColumnString
+	CreatedAt     sqlite.ColumnInteger
+	IsPinned      sqlite.ColumnBool
+	PinnedAt      sqlite.ColumnInteger
+	Fingerprint   sqlite.ColumnInteger
+	IsEncrypted   sqlite.ColumnBool
+	IsSensitive   sqlite.ColumnBool
+	ExpiresAt     sqlite.ColumnInteger
+	RichContent   sqlite.ColumnString
+	ImageWidth    sqlite.ColumnInteger
+	ImageHeight   sqlite.ColumnInteger
+	ImageByteSize sqlite.ColumnInteger
+	ImageMimeType sqlite.ColumnString
+	ImageBlobHash sqlite.ColumnString
 
 	AllColumns     sqlite.ColumnList
 	MutableColumns sqlite.ColumnList
@@ -64,27 +74,47 @@ func newTblClipboardItem(schemaName, tableName, alias string) *TblClipboardItem
 
 func newTblClipboardItemImpl(schemaName, tableName, alias string) tblClipboardItem {
 	var (
-		IDColumn        = sqlite.StringColumn("id")
-		ContentColumn   = sqlite.StringColumn("content")
-		TypeColumn      = sqlite.StringColumn("type")
-		CreatedAtColumn = sqlite.IntegerColumn("created_at")
-		IsPinnedColumn  = sqlite.BoolColumn("is_pinned")
-		PinnedAtColumn  = sqlite.IntegerColumn("pinned_at")
-		allColumns      = sqlite.ColumnList{IDColumn, ContentColumn, TypeColumn, CreatedAtColumn, IsPinnedColumn, PinnedAtColumn}
-		mutableColumns  = sqlite.ColumnList{ContentColumn, TypeColumn, CreatedAtColumn, IsPinnedColumn, PinnedAtColumn}
-		defaultColumns  = sqlite.ColumnList{}
+		IDColumn            = sqlite.StringColumn("id")
+		ContentColumn       = sqlite.StringColumn("content")
+		TypeColumn          = sqlite.StringColumn("type")
+		CreatedAtColumn     = sqlite.IntegerColumn("created_at")
+		IsPinnedColumn      = sqlite.BoolColumn("is_pinned")
+		PinnedAtColumn      = sqlite.IntegerColumn("pinned_at")
+		FingerprintColumn   = sqlite.IntegerColumn("fingerprint")
+		IsEncryptedColumn   = sqlite.BoolColumn("is_encrypted")
+		IsSensitiveColumn   = sqlite.BoolColumn("is_sensitive")
+		ExpiresAtColumn     = sqlite.IntegerColumn("expires_at")
+		RichContentColumn   = sqlite.StringColumn("rich_content")
+		ImageWidthColumn    = sqlite.IntegerColumn("image_width")
+		ImageHeightColumn   = sqlite.IntegerColumn("image_height")
+		ImageByteSizeColumn = sqlite.IntegerColumn("image_byte_size")
+		ImageMimeTypeColumn = sqlite.StringColumn("image_mime_type")
+		ImageBlobHashColumn = sqlite.StringColumn("image_blob_hash")
+		allColumns          = sqlite.ColumnList{IDColumn, ContentColumn, TypeColumn, CreatedAtColumn, IsPinnedColumn, PinnedAtColumn, FingerprintColumn, IsEncryptedColumn, IsSensitiveColumn, ExpiresAtColumn, RichContentColumn, ImageWidthColumn, ImageHeightColumn, ImageByteSizeColumn, ImageMimeTypeColumn, ImageBlobHashColumn}
+		mutableColumns      = sqlite.ColumnList{ContentColumn, TypeColumn, CreatedAtColumn, IsPinnedColumn, PinnedAtColumn, FingerprintColumn, IsEncryptedColumn, IsSensitiveColumn, ExpiresAtColumn, RichContentColumn, ImageWidthColumn, ImageHeightColumn, ImageByteSizeColumn, ImageMimeTypeColumn, ImageBlobHashColumn}
+		defaultColumns      = sqlite.ColumnList{IsEncryptedColumn, IsSensitiveColumn, ExpiresAtColumn}
 	)
 
 	return tblClipboardItem{
 		Table: sqlite.NewTable(schemaName, tableName, alias, allColumns...),
 
 		//Columns
-		ID:        IDColumn,
-		Content:   ContentColumn,
-		Type:      TypeColumn,
-		CreatedAt: CreatedAtColumn,
-		IsPinned:  IsPinnedColumn,
-		PinnedAt:  PinnedAtColumn,
+		ID:            IDColumn,
+		Content:       ContentColumn,
+		Type:          TypeColumn,
+		CreatedAt:     CreatedAtColumn,
+		IsPinned:      IsPinnedColumn,
+		PinnedAt:      PinnedAtColumn,
+		Fingerprint:   FingerprintColumn,
+		IsEncrypted:   IsEncryptedColumn,
+		IsSensitive:   IsSensitiveColumn,
+		ExpiresAt:     ExpiresAtColumn,
+		RichContent:   RichContentColumn,
+		ImageWidth:    ImageWidthColumn,
+		ImageHeight:   ImageHeightColumn,
+		ImageByteSize: ImageByteSizeColumn,
+		ImageMimeType: ImageMimeTypeColumn,
+		ImageBlobHash: ImageBlobHashColumn,
 
 		AllColumns:     allColumns,
 		MutableColumns: mutableColumns,
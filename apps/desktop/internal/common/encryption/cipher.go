@@ -0,0 +1,79 @@
+package encryption
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// AES-256
+	KeySize = 32
+)
+
+func NewKey() ([]byte, error) {
+	key := make([]byte, KeySize)
+
+	_, err := rand.Read(key)
+
+	return key, errors.WithStack(err)
+}
+
+// Encrypt seals the plaintext with AES-GCM and prepends the random nonce to the result.
+// `additionalData` is authenticated but not encrypted, decrypting with different additional data fails,
+// which keeps ciphertexts from being swapped between the records they belong to.
+func Encrypt(key []byte, plaintext []byte, additionalData []byte) ([]byte, error) {
+	aead, err := newAead(key)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(plaintext)+aead.Overhead())
+
+	_, err = rand.Read(nonce)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return aead.Seal(nonce, nonce, plaintext, additionalData), nil
+}
+
+func Decrypt(key []byte, ciphertext []byte, additionalData []byte) ([]byte, error) {
+	aead, err := newAead(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ciphertext) < aead.NonceSize() {
+		return nil, errors.New("ciphertext is too short")
+	}
+
+	nonce, sealed := ciphertext[:aead.NonceSize()], ciphertext[aead.NonceSize():]
+	plaintext, err := aead.Open(nil, nonce, sealed, additionalData)
+
+	return plaintext, errors.WithStack(err)
+}
+
+// DeriveKey derives an independent key for `purpose`, so that the same key is never used
+// for two different things.
+func DeriveKey(key []byte, purpose string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(purpose))
+
+	return mac.Sum(nil)
+}
+
+func newAead(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	aead, err := cipher.NewGCM(block)
+
+	return aead, errors.WithStack(err)
+}
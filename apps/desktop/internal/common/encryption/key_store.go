@@ -0,0 +1,104 @@
+package encryption
+
+import (
+	"encoding/base64"
+	"path/filepath"
+
+	"cloudy-clip/desktop/internal/common/utils"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	keyringServiceName = "cloudy-clip"
+	keyringAccountName = "clipboard-encryption-key"
+	keyFileName        = "clipboard-encryption.key"
+)
+
+var (
+	ErrKeyNotFound         = errors.New("encryption key was not found")
+	ErrKeyStoreUnsupported = errors.New("key store is not supported on this platform")
+)
+
+// KeyStore keeps the encryption key outside of the data it encrypts.
+type KeyStore interface {
+	// LoadKey returns `ErrKeyNotFound` when no key has been stored yet.
+	LoadKey() ([]byte, error)
+	StoreKey(key []byte) error
+}
+
+// NewKeyStore returns a key store backed by the OS keyring, that falls back to a file in the app
+// home directory when the keyring is not available, as is often the case on headless Linux.
+func NewKeyStore() KeyStore {
+	return &fallbackKeyStore{
+		primary:  newPlatformKeyStore(),
+		fallback: NewFileKeyStore(filepath.Join(utils.GetAppHomeDirectory(), keyFileName)),
+	}
+}
+
+// GetOrCreateKey returns the stored key, or generates and stores one when there is none.
+func GetOrCreateKey(keyStore KeyStore) ([]byte, error) {
+	key, err := keyStore.LoadKey()
+	if !errors.Is(err, ErrKeyNotFound) {
+		return key, err
+	}
+
+	key, err = NewKey()
+	if err != nil {
+		return nil, err
+	}
+
+	err = keyStore.StoreKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return key, nil
+}
+
+type fallbackKeyStore struct {
+	primary  KeyStore
+	fallback KeyStore
+}
+
+func (keyStore *fallbackKeyStore) LoadKey() ([]byte, error) {
+	key, primaryErr := keyStore.primary.LoadKey()
+	if primaryErr == nil {
+		return key, nil
+	}
+
+	key, err := keyStore.fallback.LoadKey()
+	if errors.Is(err, ErrKeyNotFound) && !errors.Is(primaryErr, ErrKeyNotFound) &&
+		!errors.Is(primaryErr, ErrKeyStoreUnsupported) {
+		// The key may well be in the keyring, which just cannot be reached right now.
+		return nil, primaryErr
+	}
+
+	return key, err
+}
+
+func (keyStore *fallbackKeyStore) StoreKey(key []byte) error {
+	err := keyStore.primary.StoreKey(key)
+	if err == nil {
+		return nil
+	}
+
+	return keyStore.fallback.StoreKey(key)
+}
+
+func encodeKey(key []byte) string {
+	return base64.StdEncoding.EncodeToString(key)
+}
+
+func decodeKey(encodedKey string) ([]byte, error) {
+	key, err := base64.StdEncoding.DecodeString(encodedKey)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	if len(key) != KeySize {
+		return nil, errors.Errorf("encryption key must be %d bytes long but is %d", KeySize, len(key))
+	}
+
+	return key, nil
+}
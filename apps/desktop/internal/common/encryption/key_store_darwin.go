@@ -0,0 +1,60 @@
+package encryption
+
+import (
+	"os/exec"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// Returned by `security` when the keychain has no such item.
+	securityItemNotFoundExitCode = 44
+)
+
+// keychainKeyStore keeps the key in the login keychain through the `security` command.
+type keychainKeyStore struct {
+}
+
+func newPlatformKeyStore() KeyStore {
+	return &keychainKeyStore{}
+}
+
+func (keyStore *keychainKeyStore) LoadKey() ([]byte, error) {
+	output, err := exec.
+		Command("security", "find-generic-password", "-s", keyringServiceName, "-a", keyringAccountName, "-w").
+		Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitCode() == securityItemNotFoundExitCode {
+			return nil, ErrKeyNotFound
+		}
+
+		if errors.Is(err, exec.ErrNotFound) {
+			return nil, ErrKeyStoreUnsupported
+		}
+
+		return nil, errors.WithStack(err)
+	}
+
+	return decodeKey(strings.TrimSpace(string(output)))
+}
+
+// StoreKey passes the key as an argument since `security` cannot read it from stdin without a terminal.
+func (keyStore *keychainKeyStore) StoreKey(key []byte) error {
+	err := exec.
+		Command(
+			"security",
+			"add-generic-password",
+			"-U",
+			"-s", keyringServiceName,
+			"-a", keyringAccountName,
+			"-w", encodeKey(key),
+		).
+		Run()
+	if errors.Is(err, exec.ErrNotFound) {
+		return ErrKeyStoreUnsupported
+	}
+
+	return errors.WithStack(err)
+}
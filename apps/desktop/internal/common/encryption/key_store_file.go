@@ -0,0 +1,58 @@
+package encryption
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// FileKeyStore keeps the key in a file that only the current user can read.
+type FileKeyStore struct {
+	filePath string
+}
+
+func NewFileKeyStore(filePath string) *FileKeyStore {
+	return &FileKeyStore{
+		filePath: filePath,
+	}
+}
+
+func (keyStore *FileKeyStore) LoadKey() ([]byte, error) {
+	encodedKey, err := os.ReadFile(keyStore.filePath)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, ErrKeyNotFound
+		}
+
+		return nil, errors.WithStack(err)
+	}
+
+	return decodeKey(strings.TrimSpace(string(encodedKey)))
+}
+
+// StoreKey writes the key to a temporary file first, so that a crash cannot leave a partial key behind.
+func (keyStore *FileKeyStore) StoreKey(key []byte) error {
+	temporaryFile, err := os.CreateTemp(filepath.Dir(keyStore.filePath), filepath.Base(keyStore.filePath)+".*")
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer os.Remove(temporaryFile.Name())
+
+	_, err = temporaryFile.WriteString(encodeKey(key))
+	if err == nil {
+		err = temporaryFile.Sync()
+	}
+
+	closeErr := temporaryFile.Close()
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	if closeErr != nil {
+		return errors.WithStack(closeErr)
+	}
+
+	return errors.WithStack(os.Rename(temporaryFile.Name(), keyStore.filePath))
+}
@@ -0,0 +1,36 @@
+package encryption
+
+import (
+	"bytes"
+	"sync"
+)
+
+// InMemoryKeyStore keeps the key for as long as the process runs, e.g. in tests.
+type InMemoryKeyStore struct {
+	mutex sync.Mutex
+	key   []byte
+}
+
+func NewInMemoryKeyStore() *InMemoryKeyStore {
+	return &InMemoryKeyStore{}
+}
+
+func (keyStore *InMemoryKeyStore) LoadKey() ([]byte, error) {
+	keyStore.mutex.Lock()
+	defer keyStore.mutex.Unlock()
+
+	if keyStore.key == nil {
+		return nil, ErrKeyNotFound
+	}
+
+	return bytes.Clone(keyStore.key), nil
+}
+
+func (keyStore *InMemoryKeyStore) StoreKey(key []byte) error {
+	keyStore.mutex.Lock()
+	defer keyStore.mutex.Unlock()
+
+	keyStore.key = bytes.Clone(key)
+
+	return nil
+}
@@ -0,0 +1,82 @@
+package encryption
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// secretServiceKeyStore keeps the key in the Secret Service (GNOME Keyring, KWallet...) through
+// `secret-tool`, which needs both libsecret and a running D-Bus session.
+type secretServiceKeyStore struct {
+}
+
+func newPlatformKeyStore() KeyStore {
+	return &secretServiceKeyStore{}
+}
+
+func (keyStore *secretServiceKeyStore) LoadKey() ([]byte, error) {
+	if !isSecretServiceReachable() {
+		return nil, ErrKeyStoreUnsupported
+	}
+
+	var stderr bytes.Buffer
+
+	command := exec.Command("secret-tool", "lookup", "service", keyringServiceName, "account", keyringAccountName)
+	command.Stderr = &stderr
+
+	output, err := command.Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		// A lookup that finds nothing exits with 1 without printing anything.
+		if errors.As(err, &exitErr) && len(output) == 0 && stderr.Len() == 0 {
+			return nil, ErrKeyNotFound
+		}
+
+		if errors.Is(err, exec.ErrNotFound) {
+			return nil, ErrKeyStoreUnsupported
+		}
+
+		return nil, errors.Wrap(err, strings.TrimSpace(stderr.String()))
+	}
+
+	return decodeKey(strings.TrimSpace(string(output)))
+}
+
+func (keyStore *secretServiceKeyStore) StoreKey(key []byte) error {
+	if !isSecretServiceReachable() {
+		return ErrKeyStoreUnsupported
+	}
+
+	var stderr bytes.Buffer
+
+	command := exec.Command(
+		"secret-tool",
+		"store",
+		"--label=Cloudy Clip clipboard encryption key",
+		"service", keyringServiceName,
+		"account", keyringAccountName,
+	)
+	// Read from stdin so that the key does not show up in the process list.
+	command.Stdin = strings.NewReader(encodeKey(key))
+	command.Stderr = &stderr
+
+	err := command.Run()
+	if err != nil {
+		if errors.Is(err, exec.ErrNotFound) {
+			return ErrKeyStoreUnsupported
+		}
+
+		return errors.Wrap(err, strings.TrimSpace(stderr.String()))
+	}
+
+	return nil
+}
+
+// isSecretServiceReachable returns false in headless sessions, which have no D-Bus session bus.
+func isSecretServiceReachable() bool {
+	return os.Getenv("DBUS_SESSION_BUS_ADDRESS") != ""
+}
@@ -0,0 +1,18 @@
+//go:build !darwin && !linux
+
+package encryption
+
+type unsupportedKeyStore struct {
+}
+
+func newPlatformKeyStore() KeyStore {
+	return &unsupportedKeyStore{}
+}
+
+func (keyStore *unsupportedKeyStore) LoadKey() ([]byte, error) {
+	return nil, ErrKeyStoreUnsupported
+}
+
+func (keyStore *unsupportedKeyStore) StoreKey(_ []byte) error {
+	return ErrKeyStoreUnsupported
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"path/filepath"
 	"runtime"
+	"time"
 
 	env "github.com/caarlos0/env/v11"
 )
@@ -21,9 +22,14 @@ const (
 )
 
 type config struct {
-	ApplicationLogLevel int8             `env:"APPLICATION_LOG_LEVEL,notEmpty"`
-	DatabaseName        string           `env:"DATABASE_NAME,notEmpty"`
-	ExecutionProfile    ExecutionProfile `env:"EXECUTION_PROFILE,notEmpty"`
+	ApplicationLogLevel         int8             `env:"APPLICATION_LOG_LEVEL,notEmpty"`
+	ClipboardDebounceInterval   time.Duration    `env:"CLIPBOARD_DEBOUNCE_INTERVAL" envDefault:"300ms"`
+	ClipboardPollInterval       time.Duration    `env:"CLIPBOARD_POLL_INTERVAL" envDefault:"250ms"`
+	DatabaseName                string           `env:"DATABASE_NAME,notEmpty"`
+	ExecutionProfile            ExecutionProfile `env:"EXECUTION_PROFILE,notEmpty"`
+	ExpiredItemSweepInterval    time.Duration    `env:"EXPIRED_ITEM_SWEEP_INTERVAL" envDefault:"1m"`
+	ImageBlobCollectionInterval time.Duration    `env:"IMAGE_BLOB_COLLECTION_INTERVAL" envDefault:"1h"`
+	RetentionSweepInterval      time.Duration    `env:"RETENTION_SWEEP_INTERVAL" envDefault:"1h"`
 }
 
 var Config config
@@ -7,7 +7,20 @@ import (
 	"path/filepath"
 )
 
+// Replaces the home directory of the current user when set.
+var appHomeDirectoryOverride string
+
+// SetAppHomeDirectory keeps every file of the app in `directoryPath` instead of in the home
+// directory of the current user, e.g. in a temporary directory in tests.
+func SetAppHomeDirectory(directoryPath string) {
+	appHomeDirectoryOverride = directoryPath
+}
+
 func GetAppHomeDirectory() string {
+	if appHomeDirectoryOverride != "" {
+		return appHomeDirectoryOverride
+	}
+
 	usr, err := user.Current()
 	if err != nil {
 		panic(fmt.Errorf("failed to get user home directory: %w", err))
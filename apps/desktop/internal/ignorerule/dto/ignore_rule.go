@@ -0,0 +1,23 @@
+package dto
+
+import (
+	"cloudy-clip/desktop/internal/clipboard/dto"
+)
+
+// IgnoreRule keeps the content that meets every condition that is set from being captured,
+// conditions that are nil or empty are left out. Images have no text, so rules with a pattern
+// or a length never ignore them.
+type IgnoreRule struct {
+	Id   string `json:"id"`
+	Name string `json:"name"`
+	// A regular expression in the RE2 syntax that has to match somewhere in the content.
+	Pattern *string `json:"pattern"`
+	// The content has at least this many characters.
+	MinLength *int32 `json:"minLength"`
+	// The content has at most this many characters.
+	MaxLength    *int32                  `json:"maxLength"`
+	ContentTypes []dto.ClipboardItemType `json:"contentTypes" ts_type:"('TEXT'|'IMAGE'|'URL'|'HTML'|'RTF'|'FILE'|'COLOR')[]"`
+	IsEnabled    bool                    `json:"isEnabled"`
+	CreatedAt    uint64                  `json:"createdAt"`
+	UpdatedAt    uint64                  `json:"updatedAt"`
+}
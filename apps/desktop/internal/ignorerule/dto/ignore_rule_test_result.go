@@ -0,0 +1,8 @@
+package dto
+
+type IgnoreRuleTestResult struct {
+	// Whether the content would be ignored by the saved rules, the draft rule is left out.
+	IsIgnored          bool     `json:"isIgnored"`
+	MatchedRuleIds     []string `json:"matchedRuleIds"`
+	IsDraftRuleMatched bool     `json:"isDraftRuleMatched"`
+}
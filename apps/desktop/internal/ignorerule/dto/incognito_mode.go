@@ -0,0 +1,8 @@
+package dto
+
+// IncognitoMode keeps anything that is copied from being captured until it ends.
+type IncognitoMode struct {
+	IsActive bool `json:"isActive"`
+	// Unix milliseconds, 0 when it is not active.
+	EndsAt uint64 `json:"endsAt"`
+}
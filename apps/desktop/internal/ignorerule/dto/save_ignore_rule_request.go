@@ -0,0 +1,15 @@
+package dto
+
+import (
+	"cloudy-clip/desktop/internal/clipboard/dto"
+)
+
+// SaveIgnoreRuleRequest creates a rule or replaces every field of an existing one.
+type SaveIgnoreRuleRequest struct {
+	Name         string                  `json:"name"`
+	Pattern      *string                 `json:"pattern"`
+	MinLength    *int32                  `json:"minLength"`
+	MaxLength    *int32                  `json:"maxLength"`
+	ContentTypes []dto.ClipboardItemType `json:"contentTypes" ts_type:"('TEXT'|'IMAGE'|'URL'|'HTML'|'RTF'|'FILE'|'COLOR')[]"`
+	IsEnabled    bool                    `json:"isEnabled"`
+}
@@ -0,0 +1,5 @@
+package dto
+
+type StartIncognitoModeRequest struct {
+	DurationMinutes int32 `json:"durationMinutes"`
+}
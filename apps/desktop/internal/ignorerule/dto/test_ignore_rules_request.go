@@ -0,0 +1,15 @@
+package dto
+
+import (
+	"cloudy-clip/desktop/internal/clipboard/dto"
+)
+
+// TestIgnoreRulesRequest checks content against the rules without capturing anything.
+type TestIgnoreRulesRequest struct {
+	// Trimmed the same way as when it is captured.
+	Content string `json:"content"`
+	// Detected from the content the same way as when it is captured when unknown.
+	ContentType dto.ClipboardItemType `json:"contentType" ts_type:"'UNKNOWN'|'TEXT'|'IMAGE'|'URL'|'HTML'|'RTF'|'FILE'|'COLOR'"`
+	// A rule that is being written, which is checked along with the saved ones when set.
+	DraftRule *SaveIgnoreRuleRequest `json:"draftRule"`
+}
@@ -0,0 +1,98 @@
+package ignorerule
+
+import (
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"cloudy-clip/desktop/internal/clipboard/dto"
+	"cloudy-clip/desktop/internal/common/database/generated/model"
+
+	"github.com/pkg/errors"
+)
+
+const contentTypeSeparator = ","
+
+type ignoreRuleMatcher struct {
+	ruleId       string
+	pattern      *regexp.Regexp
+	minLength    *int32
+	maxLength    *int32
+	contentTypes []dto.ClipboardItemType
+}
+
+func newIgnoreRuleMatcher(ignoreRuleModel model.IgnoreRule) (ignoreRuleMatcher, error) {
+	contentTypes, err := decodeContentTypes(ignoreRuleModel.ContentTypes)
+	if err != nil {
+		return ignoreRuleMatcher{}, err
+	}
+
+	matcher := ignoreRuleMatcher{
+		ruleId:       ignoreRuleModel.ID,
+		minLength:    ignoreRuleModel.MinLength,
+		maxLength:    ignoreRuleModel.MaxLength,
+		contentTypes: contentTypes,
+	}
+
+	if ignoreRuleModel.Pattern != nil {
+		matcher.pattern, err = regexp.Compile(*ignoreRuleModel.Pattern)
+		if err != nil {
+			return ignoreRuleMatcher{}, errors.WithStack(err)
+		}
+	}
+
+	return matcher, nil
+}
+
+// matches checks every condition of the rule, `content` is empty for images.
+func (matcher ignoreRuleMatcher) matches(contentType dto.ClipboardItemType, content string) bool {
+	if len(matcher.contentTypes) > 0 && !slices.Contains(matcher.contentTypes, contentType) {
+		return false
+	}
+
+	hasTextCondition := matcher.pattern != nil || matcher.minLength != nil || matcher.maxLength != nil
+	if contentType == dto.ClipboardItemTypeImage {
+		return !hasTextCondition
+	}
+
+	contentLength := int32(utf8.RuneCountInString(content))
+
+	if matcher.minLength != nil && contentLength < *matcher.minLength {
+		return false
+	}
+
+	if matcher.maxLength != nil && contentLength > *matcher.maxLength {
+		return false
+	}
+
+	return matcher.pattern == nil || matcher.pattern.MatchString(content)
+}
+
+func encodeContentTypes(contentTypes []dto.ClipboardItemType) string {
+	encodedContentTypes := make([]string, 0, len(contentTypes))
+	for _, contentType := range contentTypes {
+		encodedContentTypes = append(encodedContentTypes, strconv.Itoa(int(contentType)))
+	}
+
+	return strings.Join(encodedContentTypes, contentTypeSeparator)
+}
+
+func decodeContentTypes(encodedContentTypes string) ([]dto.ClipboardItemType, error) {
+	contentTypes := []dto.ClipboardItemType{}
+	if encodedContentTypes == "" {
+		return contentTypes, nil
+	}
+
+	for _, encodedContentType := range strings.Split(encodedContentTypes, contentTypeSeparator) {
+		contentType, err := strconv.Atoi(encodedContentType)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+
+		contentTypes = append(contentTypes, dto.ClipboardItemType(contentType))
+	}
+
+	return contentTypes, nil
+}
@@ -0,0 +1,110 @@
+package ignorerule
+
+import (
+	"context"
+
+	"cloudy-clip/desktop/internal/common/database"
+	"cloudy-clip/desktop/internal/common/database/generated/model"
+	"cloudy-clip/desktop/internal/common/database/generated/table"
+
+	jet "github.com/go-jet/jet/v2/sqlite"
+)
+
+const incognitoModeId = 1
+
+// findIgnoreRules returns the oldest rules first.
+func findIgnoreRules(ctx context.Context) ([]model.IgnoreRule, error) {
+	ignoreRuleTable := table.IgnoreRuleTable
+	queryBuilder := ignoreRuleTable.
+		SELECT(ignoreRuleTable.AllColumns.As("")).
+		ORDER_BY(ignoreRuleTable.CreatedAt.ASC(), ignoreRuleTable.ID.ASC())
+
+	ignoreRules, err := database.SelectMany[model.IgnoreRule](ctx, queryBuilder)
+	if err != nil {
+		return nil, err
+	}
+
+	return *ignoreRules, nil
+}
+
+func findEnabledIgnoreRules(ctx context.Context) ([]model.IgnoreRule, error) {
+	ignoreRuleTable := table.IgnoreRuleTable
+	queryBuilder := ignoreRuleTable.
+		SELECT(ignoreRuleTable.AllColumns.As("")).
+		WHERE(ignoreRuleTable.IsEnabled.IS_TRUE()).
+		ORDER_BY(ignoreRuleTable.CreatedAt.ASC(), ignoreRuleTable.ID.ASC())
+
+	ignoreRules, err := database.SelectMany[model.IgnoreRule](ctx, queryBuilder)
+	if err != nil {
+		return nil, err
+	}
+
+	return *ignoreRules, nil
+}
+
+func findIgnoreRuleById(ruleId string) (*model.IgnoreRule, error) {
+	ignoreRuleTable := table.IgnoreRuleTable
+	queryBuilder := ignoreRuleTable.
+		SELECT(ignoreRuleTable.AllColumns.As("")).
+		WHERE(ignoreRuleTable.ID.EQ(jet.String(ruleId)))
+
+	return database.SelectOne[model.IgnoreRule](queryBuilder)
+}
+
+func insertIgnoreRule(ignoreRuleModel model.IgnoreRule) error {
+	return database.Exec(table.IgnoreRuleTable.INSERT().MODEL(ignoreRuleModel))
+}
+
+func updateIgnoreRule(ignoreRuleModel model.IgnoreRule) error {
+	ignoreRuleTable := table.IgnoreRuleTable
+	queryBuilder := ignoreRuleTable.
+		UPDATE(
+			ignoreRuleTable.Name,
+			ignoreRuleTable.Pattern,
+			ignoreRuleTable.MinLength,
+			ignoreRuleTable.MaxLength,
+			ignoreRuleTable.ContentTypes,
+			ignoreRuleTable.IsEnabled,
+			ignoreRuleTable.UpdatedAt,
+		).
+		MODEL(ignoreRuleModel).
+		WHERE(ignoreRuleTable.ID.EQ(jet.String(ignoreRuleModel.ID)))
+
+	return database.Exec(queryBuilder)
+}
+
+func deleteIgnoreRuleById(ruleId string) error {
+	ignoreRuleTable := table.IgnoreRuleTable
+	queryBuilder := ignoreRuleTable.
+		DELETE().
+		WHERE(ignoreRuleTable.ID.EQ(jet.String(ruleId)))
+
+	return database.Exec(queryBuilder)
+}
+
+// findIncognitoMode returns an empty result error until incognito mode has been started once.
+func findIncognitoMode() (*model.IncognitoMode, error) {
+	incognitoModeTable := table.IncognitoModeTable
+	queryBuilder := incognitoModeTable.
+		SELECT(incognitoModeTable.AllColumns.As("")).
+		WHERE(incognitoModeTable.ID.EQ(jet.Int(incognitoModeId)))
+
+	return database.SelectOne[model.IncognitoMode](queryBuilder)
+}
+
+func upsertIncognitoMode(incognitoModeModel model.IncognitoMode) error {
+	incognitoModeTable := table.IncognitoModeTable
+	incognitoModeModel.ID = incognitoModeId
+	queryBuilder := incognitoModeTable.
+		INSERT(incognitoModeTable.AllColumns).
+		MODEL(incognitoModeModel).
+		ON_CONFLICT(incognitoModeTable.ID).
+		DO_UPDATE(
+			jet.SET(
+				incognitoModeTable.EndsAt.SET(incognitoModeTable.EXCLUDED.EndsAt),
+				incognitoModeTable.UpdatedAt.SET(incognitoModeTable.EXCLUDED.UpdatedAt),
+			),
+		)
+
+	return database.Exec(queryBuilder)
+}
@@ -0,0 +1,316 @@
+package ignorerule
+
+import (
+	"context"
+	"log/slog"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	_clipboardDto "cloudy-clip/desktop/internal/clipboard/dto"
+	"cloudy-clip/desktop/internal/common/database"
+	"cloudy-clip/desktop/internal/common/database/generated/model"
+	"cloudy-clip/desktop/internal/common/exception"
+	"cloudy-clip/desktop/internal/common/logging"
+	"cloudy-clip/desktop/internal/common/utils"
+	"cloudy-clip/desktop/internal/ignorerule/dto"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	logger                = logging.NewLogger("ignorerule", slog.LevelInfo)
+	ignorableContentTypes = []_clipboardDto.ClipboardItemType{
+		_clipboardDto.ClipboardItemTypeText,
+		_clipboardDto.ClipboardItemTypeImage,
+		_clipboardDto.ClipboardItemTypeUrl,
+		_clipboardDto.ClipboardItemTypeHtml,
+		_clipboardDto.ClipboardItemTypeRtf,
+		_clipboardDto.ClipboardItemTypeFile,
+		_clipboardDto.ClipboardItemTypeColor,
+	}
+)
+
+func GetIgnoreRules(ctx context.Context) ([]dto.IgnoreRule, error) {
+	ctx = context.WithValue(ctx, logging.LoggerContextCallSiteKey, "GetIgnoreRules")
+
+	ignoreRules, err := getIgnoreRules(ctx)
+	if err == nil {
+		return ignoreRules, nil
+	}
+
+	logger.ErrorAttrs(ctx, err, "failed to get ignore rules")
+
+	return nil, exception.NewUnknownException("failed to get ignore rules")
+}
+
+func getIgnoreRules(ctx context.Context) ([]dto.IgnoreRule, error) {
+	ignoreRuleModels, err := findIgnoreRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ignoreRules := make([]dto.IgnoreRule, 0, len(ignoreRuleModels))
+
+	for _, ignoreRuleModel := range ignoreRuleModels {
+		ignoreRule, err := newIgnoreRule(ignoreRuleModel)
+		if err != nil {
+			return nil, err
+		}
+
+		ignoreRules = append(ignoreRules, ignoreRule)
+	}
+
+	return ignoreRules, nil
+}
+
+func newIgnoreRule(ignoreRuleModel model.IgnoreRule) (dto.IgnoreRule, error) {
+	contentTypes, err := decodeContentTypes(ignoreRuleModel.ContentTypes)
+	if err != nil {
+		return dto.IgnoreRule{}, err
+	}
+
+	return dto.IgnoreRule{
+		Id:           ignoreRuleModel.ID,
+		Name:         ignoreRuleModel.Name,
+		Pattern:      ignoreRuleModel.Pattern,
+		MinLength:    ignoreRuleModel.MinLength,
+		MaxLength:    ignoreRuleModel.MaxLength,
+		ContentTypes: contentTypes,
+		IsEnabled:    ignoreRuleModel.IsEnabled,
+		CreatedAt:    ignoreRuleModel.CreatedAt,
+		UpdatedAt:    ignoreRuleModel.UpdatedAt,
+	}, nil
+}
+
+func CreateIgnoreRule(ctx context.Context, request dto.SaveIgnoreRuleRequest) (dto.IgnoreRule, error) {
+	ctx = context.WithValue(ctx, logging.LoggerContextCallSiteKey, "CreateIgnoreRule")
+
+	err := validateSaveIgnoreRuleRequest(request)
+	if err != nil {
+		return dto.IgnoreRule{}, err
+	}
+
+	now := uint64(time.Now().UnixMilli())
+	ignoreRuleModel := newIgnoreRuleModel(utils.Generate(), request)
+	ignoreRuleModel.CreatedAt = now
+	ignoreRuleModel.UpdatedAt = now
+
+	err = insertIgnoreRule(ignoreRuleModel)
+	if err == nil {
+		return newIgnoreRule(ignoreRuleModel)
+	}
+
+	logger.ErrorAttrs(ctx, err, "failed to create ignore rule", slog.Any("request", request))
+
+	return dto.IgnoreRule{}, exception.NewUnknownException("failed to create ignore rule")
+}
+
+func UpdateIgnoreRule(
+	ctx context.Context,
+	ruleId string,
+	request dto.SaveIgnoreRuleRequest,
+) (dto.IgnoreRule, error) {
+	ctx = context.WithValue(ctx, logging.LoggerContextCallSiteKey, "UpdateIgnoreRule")
+
+	err := validateSaveIgnoreRuleRequest(request)
+	if err != nil {
+		return dto.IgnoreRule{}, err
+	}
+
+	existingIgnoreRuleModel, err := findIgnoreRuleById(ruleId)
+	if err == nil {
+		ignoreRuleModel := newIgnoreRuleModel(ruleId, request)
+		ignoreRuleModel.CreatedAt = existingIgnoreRuleModel.CreatedAt
+		ignoreRuleModel.UpdatedAt = uint64(time.Now().UnixMilli())
+
+		err = updateIgnoreRule(ignoreRuleModel)
+		if err == nil {
+			return newIgnoreRule(ignoreRuleModel)
+		}
+	}
+
+	if database.IsEmptyResultError(err) {
+		return dto.IgnoreRule{}, exception.NewNotFoundException("ignore rule was not found")
+	}
+
+	logger.ErrorAttrs(
+		ctx,
+		err,
+		"failed to update ignore rule",
+		slog.String("ruleId", ruleId),
+		slog.Any("request", request),
+	)
+
+	return dto.IgnoreRule{}, exception.NewUnknownException("failed to update ignore rule")
+}
+
+func DeleteIgnoreRule(ctx context.Context, ruleId string) error {
+	ctx = context.WithValue(ctx, logging.LoggerContextCallSiteKey, "DeleteIgnoreRule")
+
+	_, err := findIgnoreRuleById(ruleId)
+	if err == nil {
+		err = deleteIgnoreRuleById(ruleId)
+		if err == nil {
+			return nil
+		}
+	}
+
+	if database.IsEmptyResultError(err) {
+		return exception.NewNotFoundException("ignore rule was not found")
+	}
+
+	logger.ErrorAttrs(ctx, err, "failed to delete ignore rule", slog.String("ruleId", ruleId))
+
+	return exception.NewUnknownException("failed to delete ignore rule")
+}
+
+// newIgnoreRuleModel leaves out the timestamps, and stores an empty pattern as no pattern.
+func newIgnoreRuleModel(ruleId string, request dto.SaveIgnoreRuleRequest) model.IgnoreRule {
+	ignoreRuleModel := model.IgnoreRule{
+		ID:           ruleId,
+		Name:         strings.TrimSpace(request.Name),
+		MinLength:    request.MinLength,
+		MaxLength:    request.MaxLength,
+		ContentTypes: encodeContentTypes(request.ContentTypes),
+		IsEnabled:    request.IsEnabled,
+	}
+
+	if request.Pattern != nil && *request.Pattern != "" {
+		ignoreRuleModel.Pattern = request.Pattern
+	}
+
+	return ignoreRuleModel
+}
+
+func validateSaveIgnoreRuleRequest(request dto.SaveIgnoreRuleRequest) error {
+	invalidFields := map[string]any{}
+
+	if strings.TrimSpace(request.Name) == "" {
+		invalidFields["name"] = request.Name
+	}
+
+	hasPattern := request.Pattern != nil && *request.Pattern != ""
+	if hasPattern {
+		_, err := regexp.Compile(*request.Pattern)
+		if err != nil {
+			// The reason tells where the pattern went wrong, which helps when writing it.
+			invalidFields["pattern"] = err.Error()
+		}
+	}
+
+	if request.MinLength != nil && *request.MinLength < 1 {
+		invalidFields["minLength"] = *request.MinLength
+	}
+
+	if request.MaxLength != nil &&
+		(*request.MaxLength < 1 || (request.MinLength != nil && *request.MaxLength < *request.MinLength)) {
+		invalidFields["maxLength"] = *request.MaxLength
+	}
+
+	for _, contentType := range request.ContentTypes {
+		if !slices.Contains(ignorableContentTypes, contentType) {
+			invalidFields["contentTypes"] = request.ContentTypes
+		}
+	}
+
+	if !hasPattern && request.MinLength == nil && request.MaxLength == nil && len(request.ContentTypes) == 0 {
+		invalidFields["conditions"] = "at least one condition must be set"
+	}
+
+	if len(invalidFields) == 0 {
+		return nil
+	}
+
+	return errors.WithStack(exception.NewValidationExceptionWithExtra("invalid ignore rule", invalidFields))
+}
+
+// MatchIgnoreRules returns the IDs of the enabled rules that ignore the content, which is empty for
+// images. Rules that can no longer be compiled are skipped.
+func MatchIgnoreRules(
+	ctx context.Context,
+	contentType _clipboardDto.ClipboardItemType,
+	content string,
+) ([]string, error) {
+	ignoreRuleModels, err := findEnabledIgnoreRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return matchIgnoreRuleModels(ctx, ignoreRuleModels, contentType, content), nil
+}
+
+func matchIgnoreRuleModels(
+	ctx context.Context,
+	ignoreRuleModels []model.IgnoreRule,
+	contentType _clipboardDto.ClipboardItemType,
+	content string,
+) []string {
+	matchedRuleIds := []string{}
+
+	for _, ignoreRuleModel := range ignoreRuleModels {
+		matcher, err := newIgnoreRuleMatcher(ignoreRuleModel)
+		if err != nil {
+			logger.WarnAttrs(
+				ctx,
+				"failed to compile ignore rule",
+				slog.String("ruleId", ignoreRuleModel.ID),
+				slog.Any("error", err),
+			)
+
+			continue
+		}
+
+		if matcher.matches(contentType, content) {
+			matchedRuleIds = append(matchedRuleIds, ignoreRuleModel.ID)
+		}
+	}
+
+	return matchedRuleIds
+}
+
+// TestIgnoreRules tells whether content would be ignored when it is copied, without capturing it.
+func TestIgnoreRules(ctx context.Context, request dto.TestIgnoreRulesRequest) (dto.IgnoreRuleTestResult, error) {
+	ctx = context.WithValue(ctx, logging.LoggerContextCallSiteKey, "TestIgnoreRules")
+
+	if request.DraftRule != nil {
+		err := validateSaveIgnoreRuleRequest(*request.DraftRule)
+		if err != nil {
+			return dto.IgnoreRuleTestResult{}, err
+		}
+	}
+
+	content := strings.TrimSpace(request.Content)
+	contentType := request.ContentType
+	if contentType == _clipboardDto.ClipboardItemTypeUnknown {
+		contentType = _clipboardDto.ClipboardItemTypeText
+		if utils.IsValidUrl(content) {
+			contentType = _clipboardDto.ClipboardItemTypeUrl
+		}
+	}
+
+	if contentType == _clipboardDto.ClipboardItemTypeImage {
+		content = ""
+	}
+
+	ignoreRuleModels, err := findEnabledIgnoreRules(ctx)
+	if err != nil {
+		logger.ErrorAttrs(ctx, err, "failed to find enabled ignore rules")
+
+		return dto.IgnoreRuleTestResult{}, exception.NewUnknownException("failed to test ignore rules")
+	}
+
+	testResult := dto.IgnoreRuleTestResult{
+		MatchedRuleIds: matchIgnoreRuleModels(ctx, ignoreRuleModels, contentType, content),
+	}
+	testResult.IsIgnored = len(testResult.MatchedRuleIds) > 0
+
+	if request.DraftRule != nil {
+		// The request has been validated, so the draft rule compiles.
+		draftRuleMatcher, _ := newIgnoreRuleMatcher(newIgnoreRuleModel("", *request.DraftRule))
+		testResult.IsDraftRuleMatched = draftRuleMatcher.matches(contentType, content)
+	}
+
+	return testResult, nil
+}
@@ -0,0 +1,98 @@
+package ignorerule
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"cloudy-clip/desktop/internal/common/database"
+	"cloudy-clip/desktop/internal/common/database/generated/model"
+	"cloudy-clip/desktop/internal/common/exception"
+	"cloudy-clip/desktop/internal/common/logging"
+	"cloudy-clip/desktop/internal/ignorerule/dto"
+
+	"github.com/pkg/errors"
+)
+
+// Capture can be paused until it is resumed instead, for anything longer than that.
+const maxIncognitoModeDurationMinutes = 24 * 60
+
+func GetIncognitoMode(ctx context.Context) (dto.IncognitoMode, error) {
+	ctx = context.WithValue(ctx, logging.LoggerContextCallSiteKey, "GetIncognitoMode")
+
+	incognitoMode, err := getIncognitoMode(time.Now())
+	if err == nil {
+		return incognitoMode, nil
+	}
+
+	logger.ErrorAttrs(ctx, err, "failed to get incognito mode")
+
+	return dto.IncognitoMode{}, exception.NewUnknownException("failed to get incognito mode")
+}
+
+func getIncognitoMode(now time.Time) (dto.IncognitoMode, error) {
+	incognitoModeModel, err := findIncognitoMode()
+	if err != nil {
+		if database.IsEmptyResultError(err) {
+			return dto.IncognitoMode{}, nil
+		}
+
+		return dto.IncognitoMode{}, err
+	}
+
+	if incognitoModeModel.EndsAt <= uint64(now.UnixMilli()) {
+		return dto.IncognitoMode{}, nil
+	}
+
+	return dto.IncognitoMode{
+		IsActive: true,
+		EndsAt:   incognitoModeModel.EndsAt,
+	}, nil
+}
+
+// IsIncognitoModeActive is checked before capturing anything, incognito mode survives restarts.
+func IsIncognitoModeActive(now time.Time) (bool, error) {
+	incognitoMode, err := getIncognitoMode(now)
+
+	return incognitoMode.IsActive, err
+}
+
+// StartIncognitoMode stops capturing for the duration, starting it again while it is active
+// replaces when it ends.
+func StartIncognitoMode(ctx context.Context, request dto.StartIncognitoModeRequest) (dto.IncognitoMode, error) {
+	ctx = context.WithValue(ctx, logging.LoggerContextCallSiteKey, "StartIncognitoMode")
+
+	if request.DurationMinutes < 1 || request.DurationMinutes > maxIncognitoModeDurationMinutes {
+		return dto.IncognitoMode{}, errors.WithStack(
+			exception.NewValidationExceptionWithExtra(
+				"duration must be between 1 minute and 24 hours",
+				map[string]any{"durationMinutes": request.DurationMinutes},
+			),
+		)
+	}
+
+	now := time.Now()
+
+	return setIncognitoModeEnd(ctx, now, now.Add(time.Duration(request.DurationMinutes)*time.Minute))
+}
+
+func StopIncognitoMode(ctx context.Context) (dto.IncognitoMode, error) {
+	ctx = context.WithValue(ctx, logging.LoggerContextCallSiteKey, "StopIncognitoMode")
+	now := time.Now()
+
+	return setIncognitoModeEnd(ctx, now, now)
+}
+
+func setIncognitoModeEnd(ctx context.Context, now time.Time, endsAt time.Time) (dto.IncognitoMode, error) {
+	err := upsertIncognitoMode(model.IncognitoMode{
+		EndsAt:    uint64(endsAt.UnixMilli()),
+		UpdatedAt: uint64(now.UnixMilli()),
+	})
+	if err == nil {
+		return getIncognitoMode(now)
+	}
+
+	logger.ErrorAttrs(ctx, err, "failed to update incognito mode", slog.Time("endsAt", endsAt))
+
+	return dto.IncognitoMode{}, exception.NewUnknownException("failed to update incognito mode")
+}
@@ -0,0 +1,21 @@
+package dto
+
+// RetentionPolicy caps the local history, every limit that is nil is unlimited.
+// Pinned items are never evicted, but still count towards the limits.
+type RetentionPolicy struct {
+	// Falls back to `PlanRetentionPeriodDays` when nil.
+	MaxAgeDays         *int32 `json:"maxAgeDays"`
+	MaxItemCount       *int32 `json:"maxItemCount"`
+	MaxTotalImageBytes *int64 `json:"maxTotalImageBytes"`
+	// The retention period of the plan of the signed in user, read-only.
+	PlanRetentionPeriodDays *int32 `json:"planRetentionPeriodDays"`
+}
+
+// EffectiveMaxAgeDays returns nil when items may be kept forever.
+func (policy RetentionPolicy) EffectiveMaxAgeDays() *int32 {
+	if policy.MaxAgeDays != nil {
+		return policy.MaxAgeDays
+	}
+
+	return policy.PlanRetentionPeriodDays
+}
@@ -0,0 +1,7 @@
+package dto
+
+type UpdateRetentionPolicyRequest struct {
+	MaxAgeDays         *int32 `json:"maxAgeDays"`
+	MaxItemCount       *int32 `json:"maxItemCount"`
+	MaxTotalImageBytes *int64 `json:"maxTotalImageBytes"`
+}
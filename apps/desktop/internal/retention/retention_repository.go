@@ -0,0 +1,131 @@
+package retention
+
+import (
+	"context"
+	"strconv"
+
+	"cloudy-clip/desktop/internal/clipboard/dto"
+	"cloudy-clip/desktop/internal/common/database"
+	"cloudy-clip/desktop/internal/common/database/generated/model"
+	"cloudy-clip/desktop/internal/common/database/generated/table"
+
+	jet "github.com/go-jet/jet/v2/sqlite"
+)
+
+const (
+	retentionPolicyId           = 1
+	sqliteAutoVacuumIncremental = 2
+)
+
+// findRetentionPolicy returns an empty result error until the policy has been updated once.
+func findRetentionPolicy() (*model.RetentionPolicy, error) {
+	retentionPolicyTable := table.RetentionPolicyTable
+	queryBuilder := retentionPolicyTable.
+		SELECT(retentionPolicyTable.AllColumns.As("")).
+		WHERE(retentionPolicyTable.ID.EQ(jet.Int(retentionPolicyId)))
+
+	return database.SelectOne[model.RetentionPolicy](queryBuilder)
+}
+
+func upsertRetentionPolicy(retentionPolicyModel model.RetentionPolicy) error {
+	retentionPolicyTable := table.RetentionPolicyTable
+	retentionPolicyModel.ID = retentionPolicyId
+	queryBuilder := retentionPolicyTable.
+		INSERT(retentionPolicyTable.AllColumns).
+		MODEL(retentionPolicyModel).
+		ON_CONFLICT(retentionPolicyTable.ID).
+		DO_UPDATE(
+			jet.SET(
+				retentionPolicyTable.MaxAgeDays.SET(retentionPolicyTable.EXCLUDED.MaxAgeDays),
+				retentionPolicyTable.MaxItemCount.SET(retentionPolicyTable.EXCLUDED.MaxItemCount),
+				retentionPolicyTable.MaxTotalImageBytes.SET(retentionPolicyTable.EXCLUDED.MaxTotalImageBytes),
+				retentionPolicyTable.UpdatedAt.SET(retentionPolicyTable.EXCLUDED.UpdatedAt),
+			),
+		)
+
+	return database.Exec(queryBuilder)
+}
+
+func findUnpinnedClipboardItemIdsCreatedBefore(ctx context.Context, createdBefore uint64) ([]string, error) {
+	clipboardItemTable := table.ClipboardItemTable
+	queryBuilder := clipboardItemTable.
+		SELECT(clipboardItemTable.ID.AS("id")).
+		WHERE(
+			clipboardItemTable.IsPinned.IS_FALSE().
+				AND(clipboardItemTable.CreatedAt.LT(jet.Int(int64(createdBefore)))),
+		)
+
+	itemIds, err := database.SelectMany[string](ctx, queryBuilder)
+	if err != nil {
+		return nil, err
+	}
+
+	return *itemIds, nil
+}
+
+func countClipboardItems() (int64, error) {
+	clipboardItemTable := table.ClipboardItemTable
+	queryBuilder := clipboardItemTable.
+		SELECT(jet.COUNT(jet.STAR).AS("count"))
+
+	var count int64
+
+	err := database.SelectInto(queryBuilder, &count)
+
+	return count, err
+}
+
+func findOldestUnpinnedClipboardItemIds(ctx context.Context, limit int64) ([]string, error) {
+	clipboardItemTable := table.ClipboardItemTable
+	queryBuilder := clipboardItemTable.
+		SELECT(clipboardItemTable.ID.AS("id")).
+		WHERE(clipboardItemTable.IsPinned.IS_FALSE()).
+		ORDER_BY(clipboardItemTable.CreatedAt.ASC(), clipboardItemTable.ID.ASC()).
+		LIMIT(limit)
+
+	itemIds, err := database.SelectMany[string](ctx, queryBuilder)
+	if err != nil {
+		return nil, err
+	}
+
+	return *itemIds, nil
+}
+
+// findImageClipboardItems returns the oldest items first.
+func findImageClipboardItems(ctx context.Context) ([]model.ClipboardItem, error) {
+	clipboardItemTable := table.ClipboardItemTable
+	queryBuilder := clipboardItemTable.
+		SELECT(clipboardItemTable.AllColumns.As("")).
+		WHERE(clipboardItemTable.Type.EQ(jet.String(strconv.Itoa(int(dto.ClipboardItemTypeImage))))).
+		ORDER_BY(clipboardItemTable.CreatedAt.ASC(), clipboardItemTable.ID.ASC())
+
+	clipboardItems, err := database.SelectMany[model.ClipboardItem](ctx, queryBuilder)
+	if err != nil {
+		return nil, err
+	}
+
+	return *clipboardItems, nil
+}
+
+// enableIncrementalVacuum rebuilds the database once when it was created without incremental
+// auto-vacuum, which cannot be turned on otherwise.
+func enableIncrementalVacuum() error {
+	var autoVacuum int
+
+	err := database.SelectInto(jet.RawStatement("PRAGMA auto_vacuum"), &autoVacuum)
+	if err != nil || autoVacuum == sqliteAutoVacuumIncremental {
+		return err
+	}
+
+	err = database.Exec(jet.RawStatement("PRAGMA auto_vacuum = INCREMENTAL"))
+	if err != nil {
+		return err
+	}
+
+	return database.Exec(jet.RawStatement("VACUUM"))
+}
+
+// runIncrementalVacuum returns the pages freed up by deleted items to the file system.
+func runIncrementalVacuum() error {
+	return database.Exec(jet.RawStatement("PRAGMA incremental_vacuum"))
+}
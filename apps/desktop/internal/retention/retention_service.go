@@ -0,0 +1,240 @@
+package retention
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+
+	"cloudy-clip/desktop/internal/clipboard"
+	"cloudy-clip/desktop/internal/common/database"
+	"cloudy-clip/desktop/internal/common/database/generated/model"
+	"cloudy-clip/desktop/internal/common/exception"
+	"cloudy-clip/desktop/internal/common/logging"
+	"cloudy-clip/desktop/internal/retention/dto"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	logger                  = logging.NewLogger("retention", slog.LevelInfo)
+	planRetentionPeriodDays *int32
+	planRetentionMutex      sync.RWMutex
+)
+
+// SetPlanRetentionPeriod makes the history follow the `RETENTION_PERIOD` entitlement of the plan of
+// the user who signed in, pass nil when the user signs out or when the plan retains items forever.
+func SetPlanRetentionPeriod(retentionPeriodDays *int32) {
+	planRetentionMutex.Lock()
+	defer planRetentionMutex.Unlock()
+
+	planRetentionPeriodDays = retentionPeriodDays
+}
+
+func getPlanRetentionPeriod() *int32 {
+	planRetentionMutex.RLock()
+	defer planRetentionMutex.RUnlock()
+
+	return planRetentionPeriodDays
+}
+
+func GetRetentionPolicy(ctx context.Context) (dto.RetentionPolicy, error) {
+	ctx = context.WithValue(ctx, logging.LoggerContextCallSiteKey, "GetRetentionPolicy")
+
+	retentionPolicy, err := getRetentionPolicy()
+	if err == nil {
+		return retentionPolicy, nil
+	}
+
+	logger.ErrorAttrs(ctx, err, "failed to get retention policy")
+
+	return dto.RetentionPolicy{}, exception.NewUnknownException("failed to get retention policy")
+}
+
+func getRetentionPolicy() (dto.RetentionPolicy, error) {
+	retentionPolicy := dto.RetentionPolicy{
+		PlanRetentionPeriodDays: getPlanRetentionPeriod(),
+	}
+
+	retentionPolicyModel, err := findRetentionPolicy()
+	if err != nil {
+		if database.IsEmptyResultError(err) {
+			return retentionPolicy, nil
+		}
+
+		return dto.RetentionPolicy{}, err
+	}
+
+	retentionPolicy.MaxAgeDays = retentionPolicyModel.MaxAgeDays
+	retentionPolicy.MaxItemCount = retentionPolicyModel.MaxItemCount
+	retentionPolicy.MaxTotalImageBytes = retentionPolicyModel.MaxTotalImageBytes
+
+	return retentionPolicy, nil
+}
+
+func UpdateRetentionPolicy(
+	ctx context.Context,
+	request dto.UpdateRetentionPolicyRequest,
+) (dto.RetentionPolicy, error) {
+	ctx = context.WithValue(ctx, logging.LoggerContextCallSiteKey, "UpdateRetentionPolicy")
+
+	err := validateUpdateRetentionPolicyRequest(request)
+	if err != nil {
+		return dto.RetentionPolicy{}, err
+	}
+
+	err = upsertRetentionPolicy(model.RetentionPolicy{
+		MaxAgeDays:         request.MaxAgeDays,
+		MaxItemCount:       request.MaxItemCount,
+		MaxTotalImageBytes: request.MaxTotalImageBytes,
+		UpdatedAt:          uint64(time.Now().UnixMilli()),
+	})
+	if err == nil {
+		return getRetentionPolicy()
+	}
+
+	logger.ErrorAttrs(ctx, err, "failed to update retention policy", slog.Any("request", request))
+
+	return dto.RetentionPolicy{}, exception.NewUnknownException("failed to update retention policy")
+}
+
+func validateUpdateRetentionPolicyRequest(request dto.UpdateRetentionPolicyRequest) error {
+	invalidFields := map[string]any{}
+
+	if request.MaxAgeDays != nil && *request.MaxAgeDays < 1 {
+		invalidFields["maxAgeDays"] = *request.MaxAgeDays
+	}
+
+	if request.MaxItemCount != nil && *request.MaxItemCount < 1 {
+		invalidFields["maxItemCount"] = *request.MaxItemCount
+	}
+
+	if request.MaxTotalImageBytes != nil && *request.MaxTotalImageBytes < 1 {
+		invalidFields["maxTotalImageBytes"] = *request.MaxTotalImageBytes
+	}
+
+	if len(invalidFields) == 0 {
+		return nil
+	}
+
+	return errors.WithStack(exception.NewValidationExceptionWithExtra("limits must be positive when set", invalidFields))
+}
+
+// SweepClipboardHistory evicts the oldest unpinned items until the history is within the limits
+// of the retention policy as of `now`, and returns how many were evicted.
+func SweepClipboardHistory(ctx context.Context, now time.Time) (int, error) {
+	retentionPolicy, err := getRetentionPolicy()
+	if err != nil {
+		return 0, err
+	}
+
+	evictedItemCount := 0
+
+	if maxAgeDays := retentionPolicy.EffectiveMaxAgeDays(); maxAgeDays != nil {
+		createdBefore := now.AddDate(0, 0, -int(*maxAgeDays))
+		itemIds, err := findUnpinnedClipboardItemIdsCreatedBefore(ctx, uint64(createdBefore.UnixMilli()))
+		if err != nil {
+			return evictedItemCount, err
+		}
+
+		deletedItemCount, err := clipboard.DeleteClipboardItems(ctx, itemIds)
+		evictedItemCount += deletedItemCount
+		if err != nil {
+			return evictedItemCount, err
+		}
+	}
+
+	if retentionPolicy.MaxItemCount != nil {
+		deletedItemCount, err := evictClipboardItemsOverMaxCount(ctx, int64(*retentionPolicy.MaxItemCount))
+		evictedItemCount += deletedItemCount
+		if err != nil {
+			return evictedItemCount, err
+		}
+	}
+
+	if retentionPolicy.MaxTotalImageBytes != nil {
+		deletedItemCount, err := evictImageClipboardItemsOverMaxBytes(ctx, *retentionPolicy.MaxTotalImageBytes)
+		evictedItemCount += deletedItemCount
+		if err != nil {
+			return evictedItemCount, err
+		}
+	}
+
+	if evictedItemCount > 0 {
+		err = runIncrementalVacuum()
+	}
+
+	return evictedItemCount, err
+}
+
+func evictClipboardItemsOverMaxCount(ctx context.Context, maxItemCount int64) (int, error) {
+	itemCount, err := countClipboardItems()
+	if err != nil || itemCount <= maxItemCount {
+		return 0, err
+	}
+
+	itemIds, err := findOldestUnpinnedClipboardItemIds(ctx, itemCount-maxItemCount)
+	if err != nil {
+		return 0, err
+	}
+
+	return clipboard.DeleteClipboardItems(ctx, itemIds)
+}
+
+func evictImageClipboardItemsOverMaxBytes(ctx context.Context, maxTotalImageBytes int64) (int, error) {
+	imageClipboardItems, err := findImageClipboardItems(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	totalImageBytes := int64(0)
+	// Items that hold the same image share its file, which only frees its bytes once the last of
+	// them is evicted.
+	imageBytesByFilePath := make(map[string]int64, len(imageClipboardItems))
+	referenceCountByFilePath := make(map[string]int, len(imageClipboardItems))
+
+	for _, imageClipboardItem := range imageClipboardItems {
+		imageFilePath := clipboard.ResolveImageFilePath(imageClipboardItem)
+		referenceCountByFilePath[imageFilePath]++
+
+		if referenceCountByFilePath[imageFilePath] > 1 {
+			continue
+		}
+
+		imageFileInfo, err := os.Stat(imageFilePath)
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+
+			return 0, errors.WithStack(err)
+		}
+
+		imageBytesByFilePath[imageFilePath] = imageFileInfo.Size()
+		totalImageBytes += imageFileInfo.Size()
+	}
+
+	var itemIds []string
+
+	for _, imageClipboardItem := range imageClipboardItems {
+		if totalImageBytes <= maxTotalImageBytes {
+			break
+		}
+
+		if imageClipboardItem.IsPinned {
+			continue
+		}
+
+		itemIds = append(itemIds, imageClipboardItem.ID)
+
+		imageFilePath := clipboard.ResolveImageFilePath(imageClipboardItem)
+		referenceCountByFilePath[imageFilePath]--
+
+		if referenceCountByFilePath[imageFilePath] == 0 {
+			totalImageBytes -= imageBytesByFilePath[imageFilePath]
+		}
+	}
+
+	return clipboard.DeleteClipboardItems(ctx, itemIds)
+}
@@ -0,0 +1,123 @@
+package retention
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"cloudy-clip/desktop/internal/clipboard"
+	"cloudy-clip/desktop/internal/common/logging"
+)
+
+const (
+	// The name of the Wails event that carries the IDs of the items that expired to the UI.
+	ClipboardItemsExpiredEventName = "clipboard:items-expired"
+)
+
+// RetentionSweeper enforces the retention policy right away and then once every sweep interval,
+// and deletes the items that expired once every expired item sweep interval.
+type RetentionSweeper struct {
+	sweepInterval            time.Duration
+	expiredItemSweepInterval time.Duration
+	// Called with the IDs of the items that expired, from the goroutine of the sweeper.
+	onItemsExpired func(itemIds []string)
+	stopSignal     chan struct{}
+	stopped        chan struct{}
+	startOnce      sync.Once
+	stopOnce       sync.Once
+}
+
+func NewRetentionSweeper(
+	sweepInterval time.Duration,
+	expiredItemSweepInterval time.Duration,
+	onItemsExpired func(itemIds []string),
+) *RetentionSweeper {
+	return &RetentionSweeper{
+		sweepInterval:            sweepInterval,
+		expiredItemSweepInterval: expiredItemSweepInterval,
+		onItemsExpired:           onItemsExpired,
+		stopSignal:               make(chan struct{}),
+		stopped:                  make(chan struct{}),
+	}
+}
+
+func (sweeper *RetentionSweeper) Start() {
+	sweeper.startOnce.Do(func() {
+		go sweeper.run()
+	})
+}
+
+// Stop blocks until the ongoing sweep, if any, has finished.
+func (sweeper *RetentionSweeper) Stop() {
+	sweeper.stopOnce.Do(func() {
+		close(sweeper.stopSignal)
+	})
+
+	sweeper.startOnce.Do(func() {
+		close(sweeper.stopped)
+	})
+
+	<-sweeper.stopped
+}
+
+func (sweeper *RetentionSweeper) run() {
+	defer close(sweeper.stopped)
+
+	ctx := context.WithValue(context.Background(), logging.LoggerContextCallSiteKey, "RetentionSweeper")
+
+	err := enableIncrementalVacuum()
+	if err != nil {
+		logger.ErrorAttrs(ctx, err, "failed to enable incremental vacuum")
+	}
+
+	ticker := time.NewTicker(sweeper.sweepInterval)
+	defer ticker.Stop()
+
+	expiredItemTicker := time.NewTicker(sweeper.expiredItemSweepInterval)
+	defer expiredItemTicker.Stop()
+
+	sweep(ctx)
+	sweeper.sweepExpiredItems(ctx)
+
+	for {
+		select {
+		case <-sweeper.stopSignal:
+			return
+
+		case <-ticker.C:
+			sweep(ctx)
+
+		case <-expiredItemTicker.C:
+			sweeper.sweepExpiredItems(ctx)
+		}
+	}
+}
+
+func (sweeper *RetentionSweeper) sweepExpiredItems(ctx context.Context) {
+	itemIds, err := clipboard.DeleteExpiredClipboardItems(ctx, time.Now())
+	if err != nil || len(itemIds) == 0 {
+		return
+	}
+
+	logger.InfoAttrs(ctx, "deleted expired clipboard items", slog.Int("itemCount", len(itemIds)))
+	sweeper.onItemsExpired(itemIds)
+}
+
+func sweep(ctx context.Context) {
+	evictedItemCount, err := SweepClipboardHistory(ctx, time.Now())
+	if err != nil {
+		logger.ErrorAttrs(
+			ctx,
+			err,
+			"failed to sweep clipboard history",
+			slog.Int("evictedItemCount", evictedItemCount),
+		)
+
+		return
+	}
+
+	if evictedItemCount > 0 {
+		logger.InfoAttrs(ctx, "swept clipboard history", slog.Int("evictedItemCount", evictedItemCount))
+	}
+}
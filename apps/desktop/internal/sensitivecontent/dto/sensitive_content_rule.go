@@ -0,0 +1,32 @@
+package dto
+
+type SensitiveContentRuleId string
+
+const (
+	SensitiveContentRuleIdCreditCardNumber  SensitiveContentRuleId = "CREDIT_CARD_NUMBER"
+	SensitiveContentRuleIdPrivateKey        SensitiveContentRuleId = "PRIVATE_KEY"
+	SensitiveContentRuleIdJwt               SensitiveContentRuleId = "JWT"
+	SensitiveContentRuleIdAwsAccessKey      SensitiveContentRuleId = "AWS_ACCESS_KEY"
+	SensitiveContentRuleIdGithubToken       SensitiveContentRuleId = "GITHUB_TOKEN"
+	SensitiveContentRuleIdStripeKey         SensitiveContentRuleId = "STRIPE_KEY"
+	SensitiveContentRuleIdHighEntropyString SensitiveContentRuleId = "HIGH_ENTROPY_STRING"
+)
+
+// SensitiveContentPolicy decides what happens to content that a rule flags.
+type SensitiveContentPolicy string
+
+const (
+	// The content is not captured at all.
+	SensitiveContentPolicySkip SensitiveContentPolicy = "SKIP"
+	// The flagged parts of the content are masked before it is stored.
+	SensitiveContentPolicyMask SensitiveContentPolicy = "MASK"
+	// The content is stored as is and deleted once `ExpireAfterMinutes` have passed, unless it is pinned.
+	SensitiveContentPolicyExpire SensitiveContentPolicy = "EXPIRE"
+)
+
+type SensitiveContentRule struct {
+	Id                 SensitiveContentRuleId `json:"id" ts_type:"'CREDIT_CARD_NUMBER'|'PRIVATE_KEY'|'JWT'|'AWS_ACCESS_KEY'|'GITHUB_TOKEN'|'STRIPE_KEY'|'HIGH_ENTROPY_STRING'"`
+	IsEnabled          bool                   `json:"isEnabled"`
+	Policy             SensitiveContentPolicy `json:"policy" ts_type:"'SKIP'|'MASK'|'EXPIRE'"`
+	ExpireAfterMinutes int32                  `json:"expireAfterMinutes"`
+}
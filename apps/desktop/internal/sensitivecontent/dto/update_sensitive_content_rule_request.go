@@ -0,0 +1,8 @@
+package dto
+
+type UpdateSensitiveContentRuleRequest struct {
+	Id                 SensitiveContentRuleId `json:"id" ts_type:"'CREDIT_CARD_NUMBER'|'PRIVATE_KEY'|'JWT'|'AWS_ACCESS_KEY'|'GITHUB_TOKEN'|'STRIPE_KEY'|'HIGH_ENTROPY_STRING'"`
+	IsEnabled          bool                   `json:"isEnabled"`
+	Policy             SensitiveContentPolicy `json:"policy" ts_type:"'SKIP'|'MASK'|'EXPIRE'"`
+	ExpireAfterMinutes int32                  `json:"expireAfterMinutes"`
+}
@@ -0,0 +1,118 @@
+package sensitivecontent
+
+import (
+	"math"
+	"regexp"
+	"unicode"
+
+	"cloudy-clip/desktop/internal/sensitivecontent/dto"
+)
+
+const (
+	minCreditCardNumberDigitCount = 13
+	maxCreditCardNumberDigitCount = 19
+	// Random base64 strings of this length score about 4.5 bits per character, while hex digests,
+	// such as commit hashes, cannot score more than 4 and are left alone, so the entropy has to exceed it.
+	highEntropyStringEntropyThreshold = 4.0
+)
+
+var (
+	// Digits may be grouped with spaces or dashes.
+	creditCardNumberPattern  = regexp.MustCompile(`\b\d(?:[ -]?\d){12,18}\b`)
+	privateKeyPattern        = regexp.MustCompile(`-----BEGIN[A-Z0-9 ]*PRIVATE KEY-----(?s:.*?)(?:-----END[A-Z0-9 ]*PRIVATE KEY-----|\z)`)
+	jwtPattern               = regexp.MustCompile(`\beyJ[A-Za-z0-9_-]{8,}\.[A-Za-z0-9_-]{8,}\.[A-Za-z0-9_-]{8,}`)
+	awsAccessKeyPattern      = regexp.MustCompile(`\b(?:AKIA|ASIA|ABIA|ACCA)[A-Z0-9]{16}\b`)
+	githubTokenPattern       = regexp.MustCompile(`\b(?:gh[pousr]_[A-Za-z0-9]{36,255}|github_pat_[A-Za-z0-9_]{22,255})\b`)
+	stripeKeyPattern         = regexp.MustCompile(`\b(?:sk|rk)_(?:live|test)_[A-Za-z0-9]{16,}\b`)
+	highEntropyStringPattern = regexp.MustCompile(`[A-Za-z0-9+/=_-]{20,}`)
+	detectors                = map[dto.SensitiveContentRuleId]detector{
+		dto.SensitiveContentRuleIdCreditCardNumber:  newFilteredPatternDetector(creditCardNumberPattern, isCreditCardNumber),
+		dto.SensitiveContentRuleIdPrivateKey:        newPatternDetector(privateKeyPattern),
+		dto.SensitiveContentRuleIdJwt:               newPatternDetector(jwtPattern),
+		dto.SensitiveContentRuleIdAwsAccessKey:      newPatternDetector(awsAccessKeyPattern),
+		dto.SensitiveContentRuleIdGithubToken:       newPatternDetector(githubTokenPattern),
+		dto.SensitiveContentRuleIdStripeKey:         newPatternDetector(stripeKeyPattern),
+		dto.SensitiveContentRuleIdHighEntropyString: newFilteredPatternDetector(highEntropyStringPattern, isHighEntropyString),
+	}
+)
+
+// detector returns the byte offsets of every part of the content that it flags, as [start, end) pairs.
+type detector func(content string) [][]int
+
+func newPatternDetector(pattern *regexp.Regexp) detector {
+	return func(content string) [][]int {
+		return pattern.FindAllStringIndex(content, -1)
+	}
+}
+
+func newFilteredPatternDetector(pattern *regexp.Regexp, isMatch func(match string) bool) detector {
+	return func(content string) [][]int {
+		var spans [][]int
+
+		for _, span := range pattern.FindAllStringIndex(content, -1) {
+			if isMatch(content[span[0]:span[1]]) {
+				spans = append(spans, span)
+			}
+		}
+
+		return spans
+	}
+}
+
+// isCreditCardNumber checks the number of digits and the Luhn checksum.
+func isCreditCardNumber(match string) bool {
+	var digits []int
+
+	for _, character := range match {
+		if unicode.IsDigit(character) {
+			digits = append(digits, int(character-'0'))
+		}
+	}
+
+	if len(digits) < minCreditCardNumberDigitCount || len(digits) > maxCreditCardNumberDigitCount {
+		return false
+	}
+
+	sum := 0
+
+	for index := range digits {
+		digit := digits[len(digits)-1-index]
+		if index%2 == 1 {
+			digit *= 2
+			if digit > 9 {
+				digit -= 9
+			}
+		}
+
+		sum += digit
+	}
+
+	return sum%10 == 0
+}
+
+// isHighEntropyString requires letters and digits on top of the entropy, so that long words and
+// numbers are not flagged.
+func isHighEntropyString(match string) bool {
+	hasLetter := false
+	hasDigit := false
+	characterCounts := map[rune]int{}
+
+	for _, character := range match {
+		hasLetter = hasLetter || unicode.IsLetter(character)
+		hasDigit = hasDigit || unicode.IsDigit(character)
+		characterCounts[character]++
+	}
+
+	if !hasLetter || !hasDigit {
+		return false
+	}
+
+	entropy := 0.0
+
+	for _, characterCount := range characterCounts {
+		probability := float64(characterCount) / float64(len(match))
+		entropy -= probability * math.Log2(probability)
+	}
+
+	return entropy > highEntropyStringEntropyThreshold
+}
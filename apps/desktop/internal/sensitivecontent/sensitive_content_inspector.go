@@ -0,0 +1,144 @@
+package sensitivecontent
+
+import (
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"cloudy-clip/desktop/internal/sensitivecontent/dto"
+)
+
+const (
+	maskCharacter = '•'
+	// How many letters and digits are left visible at the end of every masked part, so that one
+	// can still tell which card or key it was.
+	unmaskedTrailingCharacterCount = 4
+)
+
+// Inspection tells what has to happen to content before it is stored.
+type Inspection struct {
+	// Sensitive content is never synced to the cloud.
+	IsSensitive bool
+	ShouldSkip  bool
+	// The content to store, in which the parts flagged by rules with the MASK policy are masked.
+	Content string
+	// Zero when the content does not expire.
+	ExpiresAfter time.Duration
+	RuleIds      []dto.SensitiveContentRuleId
+}
+
+// inspect runs the detectors of the enabled rules. When several rules flag the content, skipping
+// wins over everything else, and otherwise the content is both masked and expired as needed,
+// after the shortest period of the rules with the EXPIRE policy.
+func inspect(content string, rules []dto.SensitiveContentRule) Inspection {
+	inspection := Inspection{
+		Content: content,
+	}
+
+	var maskedSpans [][]int
+
+	for _, rule := range rules {
+		if !rule.IsEnabled {
+			continue
+		}
+
+		spans := detectors[rule.Id](content)
+		if len(spans) == 0 {
+			continue
+		}
+
+		inspection.IsSensitive = true
+		inspection.RuleIds = append(inspection.RuleIds, rule.Id)
+
+		switch rule.Policy {
+		case dto.SensitiveContentPolicySkip:
+			inspection.ShouldSkip = true
+
+		case dto.SensitiveContentPolicyMask:
+			maskedSpans = append(maskedSpans, spans...)
+
+		case dto.SensitiveContentPolicyExpire:
+			expiresAfter := time.Duration(rule.ExpireAfterMinutes) * time.Minute
+			if inspection.ExpiresAfter == 0 || expiresAfter < inspection.ExpiresAfter {
+				inspection.ExpiresAfter = expiresAfter
+			}
+		}
+	}
+
+	if inspection.ShouldSkip {
+		inspection.Content = ""
+		inspection.ExpiresAfter = 0
+	} else if len(maskedSpans) > 0 {
+		inspection.Content = mask(content, maskedSpans)
+	}
+
+	return inspection
+}
+
+// mask replaces the letters and digits of every span, except for the last few of them, and keeps
+// separators around so that the masked content keeps its shape.
+func mask(content string, spans [][]int) string {
+	var maskedContent strings.Builder
+	position := 0
+
+	for _, span := range mergeSpans(spans) {
+		maskedContent.WriteString(content[position:span[0]])
+
+		part := content[span[0]:span[1]]
+		unmaskedCharacterCount := 0
+		firstUnmaskedIndex := len(part)
+
+		for firstUnmaskedIndex > 0 && unmaskedCharacterCount < unmaskedTrailingCharacterCount {
+			character, size := utf8.DecodeLastRuneInString(part[:firstUnmaskedIndex])
+			firstUnmaskedIndex -= size
+			if isMaskable(character) {
+				unmaskedCharacterCount++
+			}
+		}
+
+		for _, character := range part[:firstUnmaskedIndex] {
+			if isMaskable(character) {
+				maskedContent.WriteRune(maskCharacter)
+			} else {
+				maskedContent.WriteRune(character)
+			}
+		}
+
+		maskedContent.WriteString(part[firstUnmaskedIndex:])
+		position = span[1]
+	}
+
+	maskedContent.WriteString(content[position:])
+
+	return maskedContent.String()
+}
+
+func isMaskable(character rune) bool {
+	return unicode.IsLetter(character) || unicode.IsDigit(character)
+}
+
+// mergeSpans sorts the spans and merges the ones that overlap, since several rules may flag the
+// same part of the content.
+func mergeSpans(spans [][]int) [][]int {
+	sortedSpans := slices.Clone(spans)
+	slices.SortFunc(sortedSpans, func(a []int, b []int) int {
+		return a[0] - b[0]
+	})
+
+	var mergedSpans [][]int
+
+	for _, span := range sortedSpans {
+		lastIndex := len(mergedSpans) - 1
+		if lastIndex >= 0 && span[0] <= mergedSpans[lastIndex][1] {
+			mergedSpans[lastIndex][1] = max(mergedSpans[lastIndex][1], span[1])
+
+			continue
+		}
+
+		mergedSpans = append(mergedSpans, []int{span[0], span[1]})
+	}
+
+	return mergedSpans
+}
@@ -0,0 +1,43 @@
+package sensitivecontent
+
+import (
+	"context"
+
+	"cloudy-clip/desktop/internal/common/database"
+	"cloudy-clip/desktop/internal/common/database/generated/model"
+	"cloudy-clip/desktop/internal/common/database/generated/table"
+
+	jet "github.com/go-jet/jet/v2/sqlite"
+)
+
+// findSensitiveContentRules only returns the rules that have been updated once.
+func findSensitiveContentRules(ctx context.Context) ([]model.SensitiveContentRule, error) {
+	sensitiveContentRuleTable := table.SensitiveContentRuleTable
+	queryBuilder := sensitiveContentRuleTable.
+		SELECT(sensitiveContentRuleTable.AllColumns.As(""))
+
+	sensitiveContentRules, err := database.SelectMany[model.SensitiveContentRule](ctx, queryBuilder)
+	if err != nil {
+		return nil, err
+	}
+
+	return *sensitiveContentRules, nil
+}
+
+func upsertSensitiveContentRule(sensitiveContentRuleModel model.SensitiveContentRule) error {
+	sensitiveContentRuleTable := table.SensitiveContentRuleTable
+	queryBuilder := sensitiveContentRuleTable.
+		INSERT(sensitiveContentRuleTable.AllColumns).
+		MODEL(sensitiveContentRuleModel).
+		ON_CONFLICT(sensitiveContentRuleTable.ID).
+		DO_UPDATE(
+			jet.SET(
+				sensitiveContentRuleTable.IsEnabled.SET(sensitiveContentRuleTable.EXCLUDED.IsEnabled),
+				sensitiveContentRuleTable.Policy.SET(sensitiveContentRuleTable.EXCLUDED.Policy),
+				sensitiveContentRuleTable.ExpireAfterMinutes.SET(sensitiveContentRuleTable.EXCLUDED.ExpireAfterMinutes),
+				sensitiveContentRuleTable.UpdatedAt.SET(sensitiveContentRuleTable.EXCLUDED.UpdatedAt),
+			),
+		)
+
+	return database.Exec(queryBuilder)
+}
@@ -0,0 +1,153 @@
+package sensitivecontent
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"time"
+
+	"cloudy-clip/desktop/internal/common/database/generated/model"
+	"cloudy-clip/desktop/internal/common/exception"
+	"cloudy-clip/desktop/internal/common/logging"
+	"cloudy-clip/desktop/internal/sensitivecontent/dto"
+
+	"github.com/pkg/errors"
+)
+
+const defaultExpireAfterMinutes = 15
+
+var (
+	logger = logging.NewLogger("sensitivecontent", slog.LevelInfo)
+	// Rules that have never been updated use these, in the order in which they are listed to users.
+	defaultSensitiveContentRules = []dto.SensitiveContentRule{
+		newDefaultSensitiveContentRule(dto.SensitiveContentRuleIdCreditCardNumber, dto.SensitiveContentPolicyMask),
+		newDefaultSensitiveContentRule(dto.SensitiveContentRuleIdPrivateKey, dto.SensitiveContentPolicySkip),
+		newDefaultSensitiveContentRule(dto.SensitiveContentRuleIdJwt, dto.SensitiveContentPolicyExpire),
+		newDefaultSensitiveContentRule(dto.SensitiveContentRuleIdAwsAccessKey, dto.SensitiveContentPolicyExpire),
+		newDefaultSensitiveContentRule(dto.SensitiveContentRuleIdGithubToken, dto.SensitiveContentPolicyExpire),
+		newDefaultSensitiveContentRule(dto.SensitiveContentRuleIdStripeKey, dto.SensitiveContentPolicyExpire),
+		newDefaultSensitiveContentRule(dto.SensitiveContentRuleIdHighEntropyString, dto.SensitiveContentPolicyExpire),
+	}
+	sensitiveContentPolicies = []dto.SensitiveContentPolicy{
+		dto.SensitiveContentPolicySkip,
+		dto.SensitiveContentPolicyMask,
+		dto.SensitiveContentPolicyExpire,
+	}
+)
+
+func newDefaultSensitiveContentRule(
+	ruleId dto.SensitiveContentRuleId,
+	policy dto.SensitiveContentPolicy,
+) dto.SensitiveContentRule {
+	return dto.SensitiveContentRule{
+		Id:                 ruleId,
+		IsEnabled:          true,
+		Policy:             policy,
+		ExpireAfterMinutes: defaultExpireAfterMinutes,
+	}
+}
+
+func GetSensitiveContentRules(ctx context.Context) ([]dto.SensitiveContentRule, error) {
+	ctx = context.WithValue(ctx, logging.LoggerContextCallSiteKey, "GetSensitiveContentRules")
+
+	sensitiveContentRules, err := getSensitiveContentRules(ctx)
+	if err == nil {
+		return sensitiveContentRules, nil
+	}
+
+	logger.ErrorAttrs(ctx, err, "failed to get sensitive content rules")
+
+	return nil, exception.NewUnknownException("failed to get sensitive content rules")
+}
+
+func getSensitiveContentRules(ctx context.Context) ([]dto.SensitiveContentRule, error) {
+	sensitiveContentRuleModels, err := findSensitiveContentRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sensitiveContentRules := slices.Clone(defaultSensitiveContentRules)
+
+	for index, sensitiveContentRule := range sensitiveContentRules {
+		modelIndex := slices.IndexFunc(sensitiveContentRuleModels, func(sensitiveContentRuleModel model.SensitiveContentRule) bool {
+			return sensitiveContentRuleModel.ID == sensitiveContentRule.Id
+		})
+		if modelIndex == -1 {
+			continue
+		}
+
+		sensitiveContentRuleModel := sensitiveContentRuleModels[modelIndex]
+		sensitiveContentRules[index].IsEnabled = sensitiveContentRuleModel.IsEnabled
+		sensitiveContentRules[index].Policy = sensitiveContentRuleModel.Policy
+		sensitiveContentRules[index].ExpireAfterMinutes = sensitiveContentRuleModel.ExpireAfterMinutes
+	}
+
+	return sensitiveContentRules, nil
+}
+
+func UpdateSensitiveContentRule(
+	ctx context.Context,
+	request dto.UpdateSensitiveContentRuleRequest,
+) (dto.SensitiveContentRule, error) {
+	ctx = context.WithValue(ctx, logging.LoggerContextCallSiteKey, "UpdateSensitiveContentRule")
+
+	err := validateUpdateSensitiveContentRuleRequest(request)
+	if err != nil {
+		return dto.SensitiveContentRule{}, err
+	}
+
+	sensitiveContentRuleModel := model.SensitiveContentRule{
+		ID:                 request.Id,
+		IsEnabled:          request.IsEnabled,
+		Policy:             request.Policy,
+		ExpireAfterMinutes: request.ExpireAfterMinutes,
+		UpdatedAt:          uint64(time.Now().UnixMilli()),
+	}
+
+	err = upsertSensitiveContentRule(sensitiveContentRuleModel)
+	if err == nil {
+		return dto.SensitiveContentRule{
+			Id:                 sensitiveContentRuleModel.ID,
+			IsEnabled:          sensitiveContentRuleModel.IsEnabled,
+			Policy:             sensitiveContentRuleModel.Policy,
+			ExpireAfterMinutes: sensitiveContentRuleModel.ExpireAfterMinutes,
+		}, nil
+	}
+
+	logger.ErrorAttrs(ctx, err, "failed to update sensitive content rule", slog.Any("request", request))
+
+	return dto.SensitiveContentRule{}, exception.NewUnknownException("failed to update sensitive content rule")
+}
+
+func validateUpdateSensitiveContentRuleRequest(request dto.UpdateSensitiveContentRuleRequest) error {
+	invalidFields := map[string]any{}
+
+	if _, exists := detectors[request.Id]; !exists {
+		invalidFields["id"] = request.Id
+	}
+
+	if !slices.Contains(sensitiveContentPolicies, request.Policy) {
+		invalidFields["policy"] = request.Policy
+	}
+
+	if request.ExpireAfterMinutes < 1 {
+		invalidFields["expireAfterMinutes"] = request.ExpireAfterMinutes
+	}
+
+	if len(invalidFields) == 0 {
+		return nil
+	}
+
+	return errors.WithStack(exception.NewValidationExceptionWithExtra("invalid sensitive content rule", invalidFields))
+}
+
+// InspectContent runs the content through the detectors of every enabled rule, and tells
+// whether it may be stored and in which form.
+func InspectContent(ctx context.Context, content string) (Inspection, error) {
+	sensitiveContentRules, err := getSensitiveContentRules(ctx)
+	if err != nil {
+		return Inspection{}, err
+	}
+
+	return inspect(content, sensitiveContentRules), nil
+}
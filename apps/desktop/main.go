@@ -1,6 +1,7 @@
 package main
 
 import (
+	"cloudy-clip/desktop/internal/clipboard"
 	"embed"
 
 	"github.com/wailsapp/wails/v2"
@@ -24,7 +25,8 @@ func main() {
 		Width:  600,
 		Height: 800,
 		AssetServer: &assetserver.Options{
-			Assets: assets,
+			Assets:  assets,
+			Handler: clipboard.ImageHandler(),
 		},
 		BackgroundColour: &options.RGBA{R: 255, G: 0, B: 0, A: 1},
 		OnStartup:        app.startup,
@@ -4,6 +4,7 @@ import (
 	"cloudy-clip/desktop/internal/clipboard/dto"
 	"cloudy-clip/desktop/internal/common/database"
 	"cloudy-clip/desktop/internal/common/utils"
+	_sensitiveContentDto "cloudy-clip/desktop/internal/sensitivecontent/dto"
 	"cloudy-clip/desktop/test/debug"
 	"fmt"
 	"os"
@@ -21,9 +22,20 @@ import (
 
 func main() {
 	modelPropertyToTypeMap := map[string]any{
-		"ClipboardItem:Type":      dto.ClipboardItemTypeText,
-		"ClipboardItem:CreatedAt": uint64(0),
-		"ClipboardItem:PinnedAt":  uint64(0),
+		"ClipboardItem:Type":             dto.ClipboardItemTypeText,
+		"ClipboardItem:CreatedAt":        uint64(0),
+		"ClipboardItem:PinnedAt":         uint64(0),
+		"ClipboardItem:ExpiresAt":        uint64(0),
+		"EncryptionSettings:UpdatedAt":   uint64(0),
+		"IgnoreRule:CreatedAt":           uint64(0),
+		"ImageBlob:CreatedAt":            uint64(0),
+		"IgnoreRule:UpdatedAt":           uint64(0),
+		"IncognitoMode:EndsAt":           uint64(0),
+		"IncognitoMode:UpdatedAt":        uint64(0),
+		"RetentionPolicy:UpdatedAt":      uint64(0),
+		"SensitiveContentRule:ID":        _sensitiveContentDto.SensitiveContentRuleIdCreditCardNumber,
+		"SensitiveContentRule:Policy":    _sensitiveContentDto.SensitiveContentPolicySkip,
+		"SensitiveContentRule:UpdatedAt": uint64(0),
 	}
 
 	debug.Debugf("Generating jet code for %s", database.ResolveDbConnectionString())
@@ -32,6 +44,10 @@ func main() {
 		"schema_migrations",
 		"table_use_schema",
 	}
+	// Full-text search tables and their shadow tables are queried with raw SQL.
+	isFullTextSearchTable := func(tableName string) bool {
+		return strings.Contains(tableName, "_fts")
+	}
 	err := sqlite.GenerateDSN(
 		fmt.Sprintf("%s/%s.db", utils.GetAppHomeDirectory(), os.Getenv("CLOUDY_CLIP_DATABASE_NAME")),
 		"./internal/common/database/generated",
@@ -41,7 +57,7 @@ func main() {
 					UseSQLBuilder(
 						template.DefaultSQLBuilder().
 							UseTable(func(table metadata.Table) template.TableSQLBuilder {
-								if slices.Contains(tableNamesToSkip, table.Name) {
+								if slices.Contains(tableNamesToSkip, table.Name) || isFullTextSearchTable(table.Name) {
 									return template.TableSQLBuilder{
 										Skip: true,
 									}
@@ -59,7 +75,7 @@ func main() {
 					UseModel(
 						template.DefaultModel().
 							UseTable(func(table metadata.Table) template.TableModel {
-								if strings.Contains(table.Name, "databasechangelog") {
+								if strings.Contains(table.Name, "databasechangelog") || isFullTextSearchTable(table.Name) {
 									return template.TableModel{
 										Skip: true,
 									}
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx__clipboard_item__created_at;
+DROP TABLE IF EXISTS tbl_retention_policy;
@@ -0,0 +1,11 @@
+CREATE TABLE tbl_retention_policy (
+    id INTEGER NOT NULL,
+    max_age_days INTEGER,
+    max_item_count INTEGER,
+    max_total_image_bytes BIGINT,
+    updated_at BIGINT NOT NULL,
+    CONSTRAINT pk__retention_policy PRIMARY KEY (id),
+    CONSTRAINT ck__retention_policy__single_row CHECK (id = 1)
+);
+
+CREATE INDEX idx__clipboard_item__created_at ON tbl_clipboard_item (created_at);
@@ -0,0 +1,5 @@
+DROP TRIGGER IF EXISTS trg__clipboard_item__after_update;
+DROP TRIGGER IF EXISTS trg__clipboard_item__after_delete;
+DROP TRIGGER IF EXISTS trg__clipboard_item__after_insert;
+DROP TABLE IF EXISTS tbl_clipboard_item_trigram_fts;
+DROP TABLE IF EXISTS tbl_clipboard_item_fts;
@@ -0,0 +1,38 @@
+-- Matches whole words and their prefixes.
+CREATE VIRTUAL TABLE tbl_clipboard_item_fts USING fts5 (
+    content,
+    content = 'tbl_clipboard_item',
+    content_rowid = 'rowid',
+    tokenize = 'unicode61 remove_diacritics 2'
+);
+
+-- Matches any 3 consecutive characters, which makes up for typos when no word matches.
+CREATE VIRTUAL TABLE tbl_clipboard_item_trigram_fts USING fts5 (
+    content,
+    content = 'tbl_clipboard_item',
+    content_rowid = 'rowid',
+    tokenize = 'trigram'
+);
+
+CREATE TRIGGER trg__clipboard_item__after_insert AFTER INSERT ON tbl_clipboard_item
+BEGIN
+    INSERT INTO tbl_clipboard_item_fts (rowid, content) VALUES (new.rowid, new.content);
+    INSERT INTO tbl_clipboard_item_trigram_fts (rowid, content) VALUES (new.rowid, new.content);
+END;
+
+CREATE TRIGGER trg__clipboard_item__after_delete AFTER DELETE ON tbl_clipboard_item
+BEGIN
+    INSERT INTO tbl_clipboard_item_fts (tbl_clipboard_item_fts, rowid, content) VALUES ('delete', old.rowid, old.content);
+    INSERT INTO tbl_clipboard_item_trigram_fts (tbl_clipboard_item_trigram_fts, rowid, content) VALUES ('delete', old.rowid, old.content);
+END;
+
+CREATE TRIGGER trg__clipboard_item__after_update AFTER UPDATE OF content ON tbl_clipboard_item
+BEGIN
+    INSERT INTO tbl_clipboard_item_fts (tbl_clipboard_item_fts, rowid, content) VALUES ('delete', old.rowid, old.content);
+    INSERT INTO tbl_clipboard_item_trigram_fts (tbl_clipboard_item_trigram_fts, rowid, content) VALUES ('delete', old.rowid, old.content);
+    INSERT INTO tbl_clipboard_item_fts (rowid, content) VALUES (new.rowid, new.content);
+    INSERT INTO tbl_clipboard_item_trigram_fts (rowid, content) VALUES (new.rowid, new.content);
+END;
+
+INSERT INTO tbl_clipboard_item_fts (tbl_clipboard_item_fts) VALUES ('rebuild');
+INSERT INTO tbl_clipboard_item_trigram_fts (tbl_clipboard_item_trigram_fts) VALUES ('rebuild');
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx__clipboard_item__fingerprint;
+ALTER TABLE tbl_clipboard_item DROP COLUMN fingerprint;
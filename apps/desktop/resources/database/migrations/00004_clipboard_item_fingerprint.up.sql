@@ -0,0 +1,4 @@
+-- Items captured before this column existed are fingerprinted when the app starts.
+ALTER TABLE tbl_clipboard_item ADD COLUMN fingerprint BIGINT;
+
+CREATE INDEX idx__clipboard_item__fingerprint ON tbl_clipboard_item (fingerprint);
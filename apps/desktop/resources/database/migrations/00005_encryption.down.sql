@@ -0,0 +1,27 @@
+-- Items that are still encrypted have to be decrypted by turning encryption off before rolling back.
+DROP TRIGGER IF EXISTS trg__clipboard_item__after_update;
+DROP TRIGGER IF EXISTS trg__clipboard_item__after_delete;
+DROP TRIGGER IF EXISTS trg__clipboard_item__after_insert;
+
+CREATE TRIGGER trg__clipboard_item__after_insert AFTER INSERT ON tbl_clipboard_item
+BEGIN
+    INSERT INTO tbl_clipboard_item_fts (rowid, content) VALUES (new.rowid, new.content);
+    INSERT INTO tbl_clipboard_item_trigram_fts (rowid, content) VALUES (new.rowid, new.content);
+END;
+
+CREATE TRIGGER trg__clipboard_item__after_delete AFTER DELETE ON tbl_clipboard_item
+BEGIN
+    INSERT INTO tbl_clipboard_item_fts (tbl_clipboard_item_fts, rowid, content) VALUES ('delete', old.rowid, old.content);
+    INSERT INTO tbl_clipboard_item_trigram_fts (tbl_clipboard_item_trigram_fts, rowid, content) VALUES ('delete', old.rowid, old.content);
+END;
+
+CREATE TRIGGER trg__clipboard_item__after_update AFTER UPDATE OF content ON tbl_clipboard_item
+BEGIN
+    INSERT INTO tbl_clipboard_item_fts (tbl_clipboard_item_fts, rowid, content) VALUES ('delete', old.rowid, old.content);
+    INSERT INTO tbl_clipboard_item_trigram_fts (tbl_clipboard_item_trigram_fts, rowid, content) VALUES ('delete', old.rowid, old.content);
+    INSERT INTO tbl_clipboard_item_fts (rowid, content) VALUES (new.rowid, new.content);
+    INSERT INTO tbl_clipboard_item_trigram_fts (rowid, content) VALUES (new.rowid, new.content);
+END;
+
+DROP TABLE IF EXISTS tbl_encryption_settings;
+ALTER TABLE tbl_clipboard_item DROP COLUMN is_encrypted;
@@ -0,0 +1,43 @@
+-- The content of encrypted text items and the file of encrypted image items are sealed with the
+-- key from the OS keyring.
+ALTER TABLE tbl_clipboard_item ADD COLUMN is_encrypted BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE TABLE tbl_encryption_settings (
+    id INTEGER NOT NULL,
+    is_enabled BOOLEAN NOT NULL,
+    updated_at BIGINT NOT NULL,
+    CONSTRAINT pk__encryption_settings PRIMARY KEY (id),
+    CONSTRAINT ck__encryption_settings__single_row CHECK (id = 1)
+);
+
+-- Encrypted items are kept out of the full-text search indexes, which would otherwise hold their
+-- content in plaintext, and are searched through after being decrypted instead.
+DROP TRIGGER trg__clipboard_item__after_insert;
+DROP TRIGGER trg__clipboard_item__after_delete;
+DROP TRIGGER trg__clipboard_item__after_update;
+
+CREATE TRIGGER trg__clipboard_item__after_insert AFTER INSERT ON tbl_clipboard_item
+WHEN NOT new.is_encrypted
+BEGIN
+    INSERT INTO tbl_clipboard_item_fts (rowid, content) VALUES (new.rowid, new.content);
+    INSERT INTO tbl_clipboard_item_trigram_fts (rowid, content) VALUES (new.rowid, new.content);
+END;
+
+CREATE TRIGGER trg__clipboard_item__after_delete AFTER DELETE ON tbl_clipboard_item
+WHEN NOT old.is_encrypted
+BEGIN
+    INSERT INTO tbl_clipboard_item_fts (tbl_clipboard_item_fts, rowid, content) VALUES ('delete', old.rowid, old.content);
+    INSERT INTO tbl_clipboard_item_trigram_fts (tbl_clipboard_item_trigram_fts, rowid, content) VALUES ('delete', old.rowid, old.content);
+END;
+
+CREATE TRIGGER trg__clipboard_item__after_update AFTER UPDATE OF content, is_encrypted ON tbl_clipboard_item
+BEGIN
+    INSERT INTO tbl_clipboard_item_fts (tbl_clipboard_item_fts, rowid, content)
+    SELECT 'delete', old.rowid, old.content WHERE NOT old.is_encrypted;
+    INSERT INTO tbl_clipboard_item_trigram_fts (tbl_clipboard_item_trigram_fts, rowid, content)
+    SELECT 'delete', old.rowid, old.content WHERE NOT old.is_encrypted;
+    INSERT INTO tbl_clipboard_item_fts (rowid, content)
+    SELECT new.rowid, new.content WHERE NOT new.is_encrypted;
+    INSERT INTO tbl_clipboard_item_trigram_fts (rowid, content)
+    SELECT new.rowid, new.content WHERE NOT new.is_encrypted;
+END;
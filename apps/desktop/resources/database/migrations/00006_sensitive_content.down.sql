@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS tbl_sensitive_content_rule;
+DROP INDEX IF EXISTS idx__clipboard_item__expires_at;
+ALTER TABLE tbl_clipboard_item DROP COLUMN expires_at;
+ALTER TABLE tbl_clipboard_item DROP COLUMN is_sensitive;
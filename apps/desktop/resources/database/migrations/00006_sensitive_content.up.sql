@@ -0,0 +1,16 @@
+-- Sensitive items are never synced to the cloud, and items that expire are deleted once `expires_at`
+-- has passed unless they are pinned, 0 means never.
+ALTER TABLE tbl_clipboard_item ADD COLUMN is_sensitive BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE tbl_clipboard_item ADD COLUMN expires_at BIGINT NOT NULL DEFAULT 0;
+
+CREATE INDEX idx__clipboard_item__expires_at ON tbl_clipboard_item (expires_at);
+
+-- Only holds the rules that have been updated once, the others use their defaults.
+CREATE TABLE tbl_sensitive_content_rule (
+    id VARCHAR NOT NULL,
+    is_enabled BOOLEAN NOT NULL,
+    policy VARCHAR NOT NULL,
+    expire_after_minutes INTEGER NOT NULL,
+    updated_at BIGINT NOT NULL,
+    CONSTRAINT pk__sensitive_content_rule PRIMARY KEY (id)
+);
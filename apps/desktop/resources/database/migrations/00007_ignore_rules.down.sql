@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS tbl_incognito_mode;
+DROP TABLE IF EXISTS tbl_ignore_rule;
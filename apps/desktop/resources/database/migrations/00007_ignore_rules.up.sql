@@ -0,0 +1,23 @@
+-- Content that matches every condition that is set on an enabled rule is not captured,
+-- `content_types` lists the numeric item types separated by commas and is empty for any type.
+CREATE TABLE tbl_ignore_rule (
+    id CHAR(26) NOT NULL,
+    name VARCHAR NOT NULL,
+    pattern VARCHAR,
+    min_length INTEGER,
+    max_length INTEGER,
+    content_types VARCHAR NOT NULL,
+    is_enabled BOOLEAN NOT NULL,
+    created_at BIGINT NOT NULL,
+    updated_at BIGINT NOT NULL,
+    CONSTRAINT pk__ignore_rule PRIMARY KEY (id)
+);
+
+-- Nothing is captured until `ends_at` has passed.
+CREATE TABLE tbl_incognito_mode (
+    id INTEGER NOT NULL,
+    ends_at BIGINT NOT NULL,
+    updated_at BIGINT NOT NULL,
+    CONSTRAINT pk__incognito_mode PRIMARY KEY (id),
+    CONSTRAINT ck__incognito_mode__single_row CHECK (id = 1)
+);
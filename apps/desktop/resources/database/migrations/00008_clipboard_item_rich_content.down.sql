@@ -0,0 +1 @@
+ALTER TABLE tbl_clipboard_item DROP COLUMN rich_content;
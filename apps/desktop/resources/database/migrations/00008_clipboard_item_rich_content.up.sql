@@ -0,0 +1,3 @@
+-- The HTML markup or the RTF source of rich items, while `content` keeps their plain-text fallback
+-- so that they can be searched like any other item.
+ALTER TABLE tbl_clipboard_item ADD COLUMN rich_content TEXT;
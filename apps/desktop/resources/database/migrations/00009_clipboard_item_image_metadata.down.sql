@@ -0,0 +1,4 @@
+ALTER TABLE tbl_clipboard_item DROP COLUMN image_mime_type;
+ALTER TABLE tbl_clipboard_item DROP COLUMN image_byte_size;
+ALTER TABLE tbl_clipboard_item DROP COLUMN image_height;
+ALTER TABLE tbl_clipboard_item DROP COLUMN image_width;
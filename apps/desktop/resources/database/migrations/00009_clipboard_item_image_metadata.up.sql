@@ -0,0 +1,5 @@
+-- Only set for image items, whose images are stored on disk along with a downscaled thumbnail.
+ALTER TABLE tbl_clipboard_item ADD COLUMN image_width INTEGER;
+ALTER TABLE tbl_clipboard_item ADD COLUMN image_height INTEGER;
+ALTER TABLE tbl_clipboard_item ADD COLUMN image_byte_size BIGINT;
+ALTER TABLE tbl_clipboard_item ADD COLUMN image_mime_type VARCHAR;
@@ -0,0 +1,7 @@
+DROP TRIGGER trg__clipboard_item__image_blob__after_update;
+DROP TRIGGER trg__clipboard_item__image_blob__after_delete;
+DROP TRIGGER trg__clipboard_item__image_blob__after_insert;
+DROP INDEX idx__clipboard_item__image_blob_hash;
+ALTER TABLE tbl_clipboard_item DROP COLUMN image_blob_hash;
+DROP INDEX idx__image_blob__reference_count;
+DROP TABLE tbl_image_blob;
@@ -0,0 +1,36 @@
+-- Images are stored once per distinct content, in a file named after the hash of the image, and are
+-- shared by every item that holds the same image. Blobs that are no longer referenced are left for
+-- the garbage collector, which removes their files along with the row.
+CREATE TABLE tbl_image_blob (
+    hash VARCHAR NOT NULL,
+    is_encrypted BOOLEAN NOT NULL,
+    reference_count INTEGER NOT NULL DEFAULT 0,
+    created_at BIGINT NOT NULL,
+    CONSTRAINT pk__image_blob PRIMARY KEY (hash)
+);
+
+CREATE INDEX idx__image_blob__reference_count ON tbl_image_blob (reference_count);
+
+-- Null for images that are still stored in a file named after the item.
+ALTER TABLE tbl_clipboard_item ADD COLUMN image_blob_hash VARCHAR;
+
+CREATE INDEX idx__clipboard_item__image_blob_hash ON tbl_clipboard_item (image_blob_hash);
+
+CREATE TRIGGER trg__clipboard_item__image_blob__after_insert AFTER INSERT ON tbl_clipboard_item
+WHEN new.image_blob_hash IS NOT NULL
+BEGIN
+    UPDATE tbl_image_blob SET reference_count = reference_count + 1 WHERE hash = new.image_blob_hash;
+END;
+
+CREATE TRIGGER trg__clipboard_item__image_blob__after_delete AFTER DELETE ON tbl_clipboard_item
+WHEN old.image_blob_hash IS NOT NULL
+BEGIN
+    UPDATE tbl_image_blob SET reference_count = reference_count - 1 WHERE hash = old.image_blob_hash;
+END;
+
+CREATE TRIGGER trg__clipboard_item__image_blob__after_update AFTER UPDATE OF image_blob_hash ON tbl_clipboard_item
+WHEN old.image_blob_hash IS NOT new.image_blob_hash
+BEGIN
+    UPDATE tbl_image_blob SET reference_count = reference_count - 1 WHERE hash = old.image_blob_hash;
+    UPDATE tbl_image_blob SET reference_count = reference_count + 1 WHERE hash = new.image_blob_hash;
+END;
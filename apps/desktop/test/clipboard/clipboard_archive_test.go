@@ -0,0 +1,98 @@
+package clipboard
+
+import (
+	"archive/zip"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"cloudy-clip/desktop/internal/clipboard"
+	"cloudy-clip/desktop/internal/clipboard/dto"
+	test "cloudy-clip/desktop/test/utils"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClipboardArchiveImport(t1 *testing.T) {
+	test.Integration(t1, func(backend *clipboard.InMemoryClipboardBackend) {
+		t1.Run("1. skips items whose type is unknown", func(t2 *testing.T) {
+			archiveFilePath := writeArchive(t2, `
+				{
+					"version": 1,
+					"exportedAt": 1700000000000,
+					"items": [
+						{"type": "TEXT", "content": "Imported", "createdAt": 1700000000000},
+						{"type": "VIDEO", "content": "From a later version", "createdAt": 1700000000000},
+						{"type": 42, "content": "Not a type name", "createdAt": 1700000000000},
+						{"type": "UNKNOWN", "content": "Unknown", "createdAt": 1700000000000}
+					]
+				}
+			`)
+
+			result, err := clipboard.ImportClipboardHistory(
+				context.Background(),
+				archiveFilePath,
+				func(progress dto.ClipboardArchiveProgress) {},
+			)
+
+			require.NoError(t2, err)
+			require.Equal(t2, 1, result.ImportedItemCount)
+			require.Equal(t2, 0, result.DuplicateItemCount)
+			require.Equal(t2, 3, result.SkippedItemCount)
+		})
+
+		t1.Run("2. imports exported items as duplicates of themselves", func(t2 *testing.T) {
+			text := "Exported HTML"
+			html := "<b>Exported HTML</b>"
+
+			err := backend.WriteItem(clipboard.ClipboardContent{Text: &text, Html: &html})
+
+			require.NoError(t2, err)
+			require.NotEmpty(t2, clipboard.GetLatestClipboardItem().Id)
+
+			archiveFilePath := filepath.Join(t2.TempDir(), "clipboard-history.zip")
+
+			exportResult, err := clipboard.ExportClipboardHistory(
+				context.Background(),
+				archiveFilePath,
+				dto.ExportClipboardHistoryRequest{Types: []dto.ClipboardItemType{dto.ClipboardItemTypeHtml}},
+				func(progress dto.ClipboardArchiveProgress) {},
+			)
+
+			require.NoError(t2, err)
+			require.Equal(t2, 1, exportResult.ExportedItemCount)
+
+			importResult, err := clipboard.ImportClipboardHistory(
+				context.Background(),
+				archiveFilePath,
+				func(progress dto.ClipboardArchiveProgress) {},
+			)
+
+			require.NoError(t2, err)
+			require.Equal(t2, 0, importResult.ImportedItemCount)
+			require.Equal(t2, 1, importResult.DuplicateItemCount)
+			require.Equal(t2, 0, importResult.SkippedItemCount)
+		})
+	})
+}
+
+func writeArchive(t *testing.T, manifest string) string {
+	archiveFilePath := filepath.Join(t.TempDir(), "clipboard-history.zip")
+
+	archiveFile, err := os.Create(archiveFilePath)
+	require.NoError(t, err)
+
+	defer archiveFile.Close()
+
+	archiveWriter := zip.NewWriter(archiveFile)
+
+	manifestWriter, err := archiveWriter.Create("manifest.json")
+	require.NoError(t, err)
+
+	_, err = manifestWriter.Write([]byte(manifest))
+	require.NoError(t, err)
+	require.NoError(t, archiveWriter.Close())
+
+	return archiveFilePath
+}
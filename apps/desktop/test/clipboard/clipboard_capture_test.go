@@ -0,0 +1,126 @@
+package clipboard
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"cloudy-clip/desktop/internal/clipboard"
+	"cloudy-clip/desktop/internal/clipboard/dto"
+	test "cloudy-clip/desktop/test/utils"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClipboardCapture(t1 *testing.T) {
+	test.Integration(t1, func(backend *clipboard.InMemoryClipboardBackend) {
+		t1.Run("1. captures nothing when the clipboard is empty", func(t2 *testing.T) {
+			item := clipboard.GetLatestClipboardItem()
+
+			require.Empty(t2, item.Id)
+		})
+
+		t1.Run("2. captures the text on the clipboard as the newest item", func(t2 *testing.T) {
+			writeText(t2, backend, "Hello world")
+
+			item := clipboard.GetLatestClipboardItem()
+
+			require.NotEmpty(t2, item.Id)
+			require.Equal(t2, dto.ClipboardItemTypeText, item.Type)
+			require.Equal(t2, "Hello world", item.Content)
+
+			storedItem, err := clipboard.GetClipboardItem(context.Background(), item.Id)
+
+			require.NoError(t2, err)
+			require.Equal(t2, item.Content, storedItem.Content)
+			require.Equal(t2, item.CreatedAt, storedItem.CreatedAt)
+		})
+
+		t1.Run("3. captures HTML along with its plain-text rendering", func(t2 *testing.T) {
+			text := "Hello bold world"
+			html := "<p>Hello <b>bold</b> world</p>"
+
+			err := backend.WriteItem(clipboard.ClipboardContent{Text: &text, Html: &html})
+
+			require.NoError(t2, err)
+
+			item := clipboard.GetLatestClipboardItem()
+
+			require.Equal(t2, dto.ClipboardItemTypeHtml, item.Type)
+			require.Equal(t2, text, item.Content)
+			require.Equal(t2, &html, item.RichContent)
+		})
+
+		t1.Run("4. captures nothing when the content already is the newest item", func(t2 *testing.T) {
+			writeText(t2, backend, "Copied twice")
+
+			item := clipboard.GetLatestClipboardItem()
+
+			require.NotEmpty(t2, item.Id)
+
+			writeText(t2, backend, "Copied twice")
+
+			item = clipboard.GetLatestClipboardItem()
+
+			require.Empty(t2, item.Id)
+		})
+
+		t1.Run("5. moves content that is already in the history to the top", func(t2 *testing.T) {
+			writeText(t2, backend, "Copied first")
+
+			firstItem := clipboard.GetLatestClipboardItem()
+
+			writeText(t2, backend, "Copied in between")
+
+			require.NotEmpty(t2, clipboard.GetLatestClipboardItem().Id)
+
+			// Items are ordered by the millisecond they were captured at.
+			time.Sleep(2 * time.Millisecond)
+
+			writeText(t2, backend, "Copied first")
+
+			recapturedItem := clipboard.GetLatestClipboardItem()
+
+			require.Equal(t2, firstItem.Id, recapturedItem.Id)
+			require.Greater(t2, recapturedItem.CreatedAt, firstItem.CreatedAt)
+
+			page, err := clipboard.GetClipboardItems(context.Background(), dto.GetClipboardItemsQuery{Limit: 10})
+
+			require.NoError(t2, err)
+			require.Equal(t2, firstItem.Id, page.Items[0].Id)
+
+			matchingItemCount := 0
+			for _, item := range page.Items {
+				if item.Content == "Copied first" {
+					matchingItemCount++
+				}
+			}
+
+			require.Equal(t2, 1, matchingItemCount)
+		})
+
+		t1.Run("6. tells apart the same text with other formatting", func(t2 *testing.T) {
+			text := "Formatted text"
+			html := "<i>Formatted text</i>"
+
+			writeText(t2, backend, text)
+
+			textItem := clipboard.GetLatestClipboardItem()
+
+			err := backend.WriteItem(clipboard.ClipboardContent{Text: &text, Html: &html})
+
+			require.NoError(t2, err)
+
+			htmlItem := clipboard.GetLatestClipboardItem()
+
+			require.NotEmpty(t2, htmlItem.Id)
+			require.NotEqual(t2, textItem.Id, htmlItem.Id)
+		})
+	})
+}
+
+func writeText(t *testing.T, backend *clipboard.InMemoryClipboardBackend, text string) {
+	err := backend.WriteItem(clipboard.NewTextClipboardContent(text))
+
+	require.NoError(t, err)
+}
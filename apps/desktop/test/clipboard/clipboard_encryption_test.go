@@ -0,0 +1,283 @@
+package clipboard
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/color"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"cloudy-clip/desktop/internal/clipboard"
+	"cloudy-clip/desktop/internal/clipboard/dto"
+	"cloudy-clip/desktop/internal/common/database"
+	"cloudy-clip/desktop/internal/common/database/generated/model"
+	"cloudy-clip/desktop/internal/common/database/generated/table"
+	"cloudy-clip/desktop/internal/common/encryption"
+	"cloudy-clip/desktop/internal/common/utils"
+	test "cloudy-clip/desktop/test/utils"
+
+	jet "github.com/go-jet/jet/v2/sqlite"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClipboardEncryption(t1 *testing.T) {
+	test.Integration(t1, func(backend *clipboard.InMemoryClipboardBackend) {
+		t1.Run("1. encrypts and decrypts text and rich content in place", func(t2 *testing.T) {
+			text := "Encrypted text"
+			html := "<b>Encrypted HTML</b>"
+
+			writeText(t2, backend, text)
+			textItem := clipboard.GetLatestClipboardItem()
+
+			err := backend.WriteItem(clipboard.ClipboardContent{Text: &text, Html: &html})
+
+			require.NoError(t2, err)
+
+			htmlItem := clipboard.GetLatestClipboardItem()
+
+			require.NotEmpty(t2, htmlItem.Id)
+
+			updateEncryptionSettings(t2, true)
+
+			for _, item := range []dto.ClipboardItem{textItem, htmlItem} {
+				clipboardItemModel := findClipboardItemModel(t2, item.Id)
+
+				require.True(t2, clipboardItemModel.IsEncrypted)
+				require.NotContains(t2, clipboardItemModel.Content, "Encrypted")
+
+				storedItem, err := clipboard.GetClipboardItem(context.Background(), item.Id)
+
+				require.NoError(t2, err)
+				require.Equal(t2, text, storedItem.Content)
+				require.Equal(t2, item.RichContent, storedItem.RichContent)
+			}
+
+			require.NotContains(t2, *findClipboardItemModel(t2, htmlItem.Id).RichContent, "Encrypted")
+
+			updateEncryptionSettings(t2, false)
+
+			clipboardItemModel := findClipboardItemModel(t2, htmlItem.Id)
+
+			require.False(t2, clipboardItemModel.IsEncrypted)
+			require.Equal(t2, text, clipboardItemModel.Content)
+			require.Equal(t2, &html, clipboardItemModel.RichContent)
+		})
+
+		t1.Run("2. finishes encrypting the history on start when the app quit before it was done", func(t2 *testing.T) {
+			writeText(t2, backend, "Captured before encryption was turned on")
+			item := clipboard.GetLatestClipboardItem()
+
+			// The key is created and the settings are saved before any item is encrypted.
+			keyStore := encryption.NewInMemoryKeyStore()
+			clipboard.SetEncryptionKeyStore(keyStore)
+
+			_, err := encryption.GetOrCreateKey(keyStore)
+
+			require.NoError(t2, err)
+
+			err = database.Exec(
+				table.EncryptionSettingsTable.
+					UPDATE(table.EncryptionSettingsTable.IsEnabled).
+					SET(jet.Bool(true)).
+					WHERE(table.EncryptionSettingsTable.ID.EQ(jet.Int(1))),
+			)
+
+			require.NoError(t2, err)
+			require.False(t2, findClipboardItemModel(t2, item.Id).IsEncrypted)
+
+			clipboard.InitializeEncryption(context.Background())
+
+			require.True(t2, findClipboardItemModel(t2, item.Id).IsEncrypted)
+
+			storedItem, err := clipboard.GetClipboardItem(context.Background(), item.Id)
+
+			require.NoError(t2, err)
+			require.Equal(t2, item.Content, storedItem.Content)
+
+			updateEncryptionSettings(t2, false)
+		})
+
+		t1.Run("3. binds rich content to the item so that it cannot be swapped with the content", func(t2 *testing.T) {
+			text := "Bound text"
+			html := "<i>Bound HTML</i>"
+
+			err := backend.WriteItem(clipboard.ClipboardContent{Text: &text, Html: &html})
+
+			require.NoError(t2, err)
+
+			item := clipboard.GetLatestClipboardItem()
+
+			updateEncryptionSettings(t2, true)
+			defer updateEncryptionSettings(t2, false)
+
+			clipboardItemModel := findClipboardItemModel(t2, item.Id)
+
+			err = database.Exec(
+				table.ClipboardItemTable.
+					UPDATE(table.ClipboardItemTable.Content, table.ClipboardItemTable.RichContent).
+					SET(jet.String(*clipboardItemModel.RichContent), jet.String(clipboardItemModel.Content)).
+					WHERE(table.ClipboardItemTable.ID.EQ(jet.String(item.Id))),
+			)
+
+			require.NoError(t2, err)
+
+			_, err = clipboard.GetClipboardItem(context.Background(), item.Id)
+
+			require.Error(t2, err)
+
+			err = database.Exec(table.ClipboardItemTable.DELETE().WHERE(table.ClipboardItemTable.ID.EQ(jet.String(item.Id))))
+
+			require.NoError(t2, err)
+		})
+
+		t1.Run("4. encrypts images and binds thumbnails to their image", func(t2 *testing.T) {
+			imageBytes := generatePng(t2, 640, 480)
+
+			err := backend.WriteItem(clipboard.NewImageClipboardContent(imageBytes))
+
+			require.NoError(t2, err)
+
+			item := clipboard.GetLatestClipboardItem()
+
+			require.Equal(t2, dto.ClipboardItemTypeImage, item.Type)
+
+			updateEncryptionSettings(t2, true)
+			defer updateEncryptionSettings(t2, false)
+
+			imageFilePath := clipboard.ResolveImageFilePath(findClipboardItemModel(t2, item.Id))
+			encryptedImageBytes, err := os.ReadFile(imageFilePath)
+
+			require.NoError(t2, err)
+			require.NotEqual(t2, imageBytes, encryptedImageBytes)
+			require.Equal(t2, imageBytes, requestImage(t2, clipboard.ImageRequestPath+item.Id).Body.Bytes())
+
+			thumbnailFilePath := filepath.Join(filepath.Dir(imageFilePath), "thumbnails", filepath.Base(imageFilePath))
+
+			encryptedThumbnailBytes, err := os.ReadFile(thumbnailFilePath)
+
+			require.NoError(t2, err)
+			require.Equal(t2, http.StatusOK, requestImage(t2, clipboard.ThumbnailRequestPath+item.Id).Code)
+
+			err = os.WriteFile(thumbnailFilePath, encryptedImageBytes, 0644)
+
+			require.NoError(t2, err)
+			require.Equal(t2, http.StatusInternalServerError, requestImage(t2, clipboard.ThumbnailRequestPath+item.Id).Code)
+
+			err = os.WriteFile(thumbnailFilePath, encryptedThumbnailBytes, 0644)
+
+			require.NoError(t2, err)
+		})
+
+		t1.Run("5. moves images that predate blobs into encrypted blobs", func(t2 *testing.T) {
+			imageBytes := generatePng(t2, 32, 32)
+			clipboardItemModel := model.ClipboardItem{
+				ID:        utils.Generate(),
+				Type:      dto.ClipboardItemTypeImage,
+				CreatedAt: uint64(time.Now().UnixMilli()),
+			}
+
+			err := database.Exec(table.ClipboardItemTable.INSERT().MODEL(clipboardItemModel))
+
+			require.NoError(t2, err)
+
+			legacyImageFilePath := clipboard.ResolveImageFilePath(clipboardItemModel)
+			err = os.WriteFile(legacyImageFilePath, imageBytes, 0644)
+
+			require.NoError(t2, err)
+
+			updateEncryptionSettings(t2, true)
+			defer updateEncryptionSettings(t2, false)
+
+			convertedClipboardItemModel := findClipboardItemModel(t2, clipboardItemModel.ID)
+
+			require.True(t2, convertedClipboardItemModel.IsEncrypted)
+			require.NotNil(t2, convertedClipboardItemModel.ImageBlobHash)
+			require.NoFileExists(t2, legacyImageFilePath)
+			require.Equal(t2, imageBytes, requestImage(t2, clipboard.ImageRequestPath+clipboardItemModel.ID).Body.Bytes())
+		})
+
+		t1.Run("6. refuses to capture while encryption is on and the key is not available", func(t2 *testing.T) {
+			keyStore := encryption.NewInMemoryKeyStore()
+			clipboard.SetEncryptionKeyStore(keyStore)
+
+			updateEncryptionSettings(t2, true)
+
+			clipboard.SetEncryptionKeyStore(encryption.NewInMemoryKeyStore())
+			clipboard.InitializeEncryption(context.Background())
+
+			require.Equal(
+				t2,
+				dto.EncryptionSettings{IsEnabled: true, IsKeyAvailable: false},
+				clipboard.GetEncryptionSettings(),
+			)
+
+			writeText(t2, backend, "Never stored in plaintext")
+
+			require.Empty(t2, clipboard.GetLatestClipboardItem().Id)
+
+			clipboard.SetEncryptionKeyStore(keyStore)
+			clipboard.InitializeEncryption(context.Background())
+
+			require.Equal(
+				t2,
+				dto.EncryptionSettings{IsEnabled: true, IsKeyAvailable: true},
+				clipboard.GetEncryptionSettings(),
+			)
+			require.NotEmpty(t2, clipboard.GetLatestClipboardItem().Id)
+
+			updateEncryptionSettings(t2, false)
+		})
+	})
+}
+
+func updateEncryptionSettings(t *testing.T, isEnabled bool) {
+	settings, err := clipboard.UpdateEncryptionSettings(
+		context.Background(),
+		dto.UpdateEncryptionSettingsRequest{IsEnabled: isEnabled},
+	)
+
+	require.NoError(t, err)
+	require.Equal(t, dto.EncryptionSettings{IsEnabled: isEnabled, IsKeyAvailable: true}, settings)
+}
+
+func findClipboardItemModel(t *testing.T, itemId string) model.ClipboardItem {
+	clipboardItemModel, err := database.SelectOne[model.ClipboardItem](
+		table.ClipboardItemTable.
+			SELECT(table.ClipboardItemTable.AllColumns.As("")).
+			WHERE(table.ClipboardItemTable.ID.EQ(jet.String(itemId))),
+	)
+
+	require.NoError(t, err)
+
+	return *clipboardItemModel
+}
+
+func requestImage(t *testing.T, requestPath string) *httptest.ResponseRecorder {
+	responseRecorder := httptest.NewRecorder()
+
+	clipboard.ImageHandler().ServeHTTP(responseRecorder, httptest.NewRequest(http.MethodGet, requestPath, nil))
+
+	return responseRecorder
+}
+
+func generatePng(t *testing.T, width int, height int) []byte {
+	generatedImage := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := range height {
+		for x := range width {
+			generatedImage.Set(x, y, color.RGBA{R: uint8(x), G: uint8(y), B: uint8(x + y), A: 255})
+		}
+	}
+
+	var imageBuffer bytes.Buffer
+	err := png.Encode(&imageBuffer, generatedImage)
+
+	require.NoError(t, err)
+
+	return imageBuffer.Bytes()
+}
@@ -0,0 +1,136 @@
+package clipboard
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"cloudy-clip/desktop/internal/clipboard"
+	"cloudy-clip/desktop/internal/clipboard/dto"
+	"cloudy-clip/desktop/internal/common/database"
+	"cloudy-clip/desktop/internal/common/database/generated/model"
+	"cloudy-clip/desktop/internal/common/database/generated/table"
+	"cloudy-clip/desktop/internal/common/utils"
+	test "cloudy-clip/desktop/test/utils"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClipboardImageBlob(t1 *testing.T) {
+	test.Integration(t1, func(backend *clipboard.InMemoryClipboardBackend) {
+		t1.Run("1. stores the same image only once", func(t2 *testing.T) {
+			imageBytes := generatePng(t2, 48, 48)
+			var clipboardItemModels []model.ClipboardItem
+
+			// Images that predate blobs are stored once per item, even when they are the same.
+			for range 2 {
+				clipboardItemModel := model.ClipboardItem{
+					ID:        utils.Generate(),
+					Type:      dto.ClipboardItemTypeImage,
+					CreatedAt: uint64(time.Now().UnixMilli()),
+				}
+
+				err := database.Exec(table.ClipboardItemTable.INSERT().MODEL(clipboardItemModel))
+
+				require.NoError(t2, err)
+
+				err = os.WriteFile(clipboard.ResolveImageFilePath(clipboardItemModel), imageBytes, 0644)
+
+				require.NoError(t2, err)
+
+				clipboardItemModels = append(clipboardItemModels, clipboardItemModel)
+			}
+
+			clipboard.MigrateImageFilesToBlobs(context.Background())
+
+			firstClipboardItemModel := findClipboardItemModel(t2, clipboardItemModels[0].ID)
+			secondClipboardItemModel := findClipboardItemModel(t2, clipboardItemModels[1].ID)
+
+			require.NotNil(t2, firstClipboardItemModel.ImageBlobHash)
+			require.Equal(t2, firstClipboardItemModel.ImageBlobHash, secondClipboardItemModel.ImageBlobHash)
+
+			for _, clipboardItemModel := range clipboardItemModels {
+				require.NoFileExists(t2, clipboard.ResolveImageFilePath(clipboardItemModel))
+				require.Equal(t2, imageBytes, requestImage(t2, clipboard.ImageRequestPath+clipboardItemModel.ID).Body.Bytes())
+			}
+
+			require.Len(t2, listImageBlobFiles(t2, firstClipboardItemModel), 1)
+
+			// The blob is still referenced by the other item.
+			err := clipboard.DeleteClipboardItem(context.Background(), firstClipboardItemModel.ID)
+
+			require.NoError(t2, err)
+
+			removedBlobCount, err := clipboard.CollectImageBlobGarbage(context.Background())
+
+			require.NoError(t2, err)
+			require.Zero(t2, removedBlobCount)
+			require.Equal(t2, imageBytes, requestImage(t2, clipboard.ImageRequestPath+secondClipboardItemModel.ID).Body.Bytes())
+
+			err = clipboard.DeleteClipboardItem(context.Background(), secondClipboardItemModel.ID)
+
+			require.NoError(t2, err)
+
+			removedBlobCount, err = clipboard.CollectImageBlobGarbage(context.Background())
+
+			require.NoError(t2, err)
+			require.Equal(t2, 1, removedBlobCount)
+			require.Empty(t2, listImageBlobFiles(t2, secondClipboardItemModel))
+		})
+
+		t1.Run("2. only collects blobs that no item references along with stray files", func(t2 *testing.T) {
+			deletedItem := captureImage(t2, backend, generatePng(t2, 64, 32))
+			keptImageBytes := generatePng(t2, 32, 64)
+			keptItem := captureImage(t2, backend, keptImageBytes)
+
+			keptClipboardItemModel := findClipboardItemModel(t2, keptItem.Id)
+			deletedClipboardItemModel := findClipboardItemModel(t2, deletedItem.Id)
+			deletedImageFilePath := clipboard.ResolveImageFilePath(deletedClipboardItemModel)
+
+			// Left behind when the app quits between writing the file of a blob and its row.
+			strayFilePath := filepath.Join(filepath.Dir(deletedImageFilePath), "stray.png")
+			err := os.WriteFile(strayFilePath, keptImageBytes, 0644)
+
+			require.NoError(t2, err)
+
+			err = clipboard.DeleteClipboardItem(context.Background(), deletedItem.Id)
+
+			require.NoError(t2, err)
+
+			removedBlobCount, err := clipboard.CollectImageBlobGarbage(context.Background())
+
+			require.NoError(t2, err)
+			require.Equal(t2, 2, removedBlobCount)
+			require.NoFileExists(t2, deletedImageFilePath)
+			require.NoFileExists(t2, strayFilePath)
+			require.Equal(t2, []string{filepath.Base(clipboard.ResolveImageFilePath(keptClipboardItemModel))}, listImageBlobFiles(t2, keptClipboardItemModel))
+			require.Equal(t2, keptImageBytes, requestImage(t2, clipboard.ImageRequestPath+keptItem.Id).Body.Bytes())
+			require.Equal(t2, http.StatusOK, requestImage(t2, clipboard.ThumbnailRequestPath+keptItem.Id).Code)
+
+			removedBlobCount, err = clipboard.CollectImageBlobGarbage(context.Background())
+
+			require.NoError(t2, err)
+			require.Zero(t2, removedBlobCount)
+		})
+	})
+}
+
+// listImageBlobFiles returns the names of the image files in the directory of the blob of the item,
+// leaving out the directory of the thumbnails.
+func listImageBlobFiles(t *testing.T, clipboardItemModel model.ClipboardItem) []string {
+	directoryEntries, err := os.ReadDir(filepath.Dir(clipboard.ResolveImageFilePath(clipboardItemModel)))
+
+	require.NoError(t, err)
+
+	var fileNames []string
+	for _, directoryEntry := range directoryEntries {
+		if !directoryEntry.IsDir() {
+			fileNames = append(fileNames, directoryEntry.Name())
+		}
+	}
+
+	return fileNames
+}
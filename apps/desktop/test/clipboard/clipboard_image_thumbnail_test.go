@@ -0,0 +1,81 @@
+package clipboard
+
+import (
+	"bytes"
+	"image/png"
+	"net/http"
+	"testing"
+
+	"cloudy-clip/desktop/internal/clipboard"
+	"cloudy-clip/desktop/internal/clipboard/dto"
+	test "cloudy-clip/desktop/test/utils"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClipboardImageThumbnail(t1 *testing.T) {
+	test.Integration(t1, func(backend *clipboard.InMemoryClipboardBackend) {
+		t1.Run("1. fits thumbnails in 320 pixels and keeps the aspect ratio", func(t2 *testing.T) {
+			for _, testCase := range []struct {
+				width           int
+				height          int
+				thumbnailWidth  int
+				thumbnailHeight int
+			}{
+				{width: 640, height: 480, thumbnailWidth: 320, thumbnailHeight: 240},
+				{width: 100, height: 2000, thumbnailWidth: 16, thumbnailHeight: 320},
+				{width: 321, height: 321, thumbnailWidth: 320, thumbnailHeight: 320},
+				// Neither side drops to 0.
+				{width: 4000, height: 1, thumbnailWidth: 320, thumbnailHeight: 1},
+			} {
+				item := captureImage(t2, backend, generatePng(t2, testCase.width, testCase.height))
+
+				require.Equal(
+					t2,
+					dto.ImageMetadata{
+						Width:        int32(testCase.width),
+						Height:       int32(testCase.height),
+						ByteSize:     item.ImageMetadata.ByteSize,
+						MimeType:     "image/png",
+						ThumbnailUrl: clipboard.ThumbnailRequestPath + item.Id,
+					},
+					*item.ImageMetadata,
+				)
+
+				thumbnailConfig, err := png.DecodeConfig(bytes.NewReader(requestThumbnail(t2, item.Id)))
+
+				require.NoError(t2, err)
+				require.Equal(t2, testCase.thumbnailWidth, thumbnailConfig.Width)
+				require.Equal(t2, testCase.thumbnailHeight, thumbnailConfig.Height)
+			}
+		})
+
+		t1.Run("2. uses images that already fit as their own thumbnail", func(t2 *testing.T) {
+			imageBytes := generatePng(t2, 320, 200)
+			item := captureImage(t2, backend, imageBytes)
+
+			require.Equal(t2, imageBytes, requestThumbnail(t2, item.Id))
+		})
+	})
+}
+
+func captureImage(t *testing.T, backend *clipboard.InMemoryClipboardBackend, imageBytes []byte) dto.ClipboardItem {
+	err := backend.WriteItem(clipboard.NewImageClipboardContent(imageBytes))
+
+	require.NoError(t, err)
+
+	item := clipboard.GetLatestClipboardItem()
+
+	require.Equal(t, dto.ClipboardItemTypeImage, item.Type)
+	require.NotNil(t, item.ImageMetadata)
+
+	return item
+}
+
+func requestThumbnail(t *testing.T, itemId string) []byte {
+	response := requestImage(t, clipboard.ThumbnailRequestPath+itemId)
+
+	require.Equal(t, http.StatusOK, response.Code)
+
+	return response.Body.Bytes()
+}
@@ -0,0 +1,53 @@
+package clipboard
+
+import (
+	"context"
+	"testing"
+
+	"cloudy-clip/desktop/internal/clipboard"
+	"cloudy-clip/desktop/internal/clipboard/dto"
+	test "cloudy-clip/desktop/test/utils"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClipboardSearch(t1 *testing.T) {
+	test.Integration(t1, func(backend *clipboard.InMemoryClipboardBackend) {
+		t1.Run("1. marks the matches in the snippet", func(t2 *testing.T) {
+			writeText(t2, backend, "The quick brown fox")
+
+			item := clipboard.GetLatestClipboardItem()
+
+			require.NotEmpty(t2, item.Id)
+
+			searchResults, err := clipboard.SearchClipboardItems(
+				context.Background(),
+				dto.SearchClipboardItemsQuery{Text: "quick"},
+			)
+
+			require.NoError(t2, err)
+			require.Len(t2, searchResults.Items, 1)
+			require.Equal(t2, item.Id, searchResults.Items[0].Id)
+			require.Equal(t2, "The <mark>quick</mark> brown fox", searchResults.Items[0].Snippet)
+		})
+
+		t1.Run("2. escapes the content of the snippet", func(t2 *testing.T) {
+			writeText(t2, backend, `<img src=x onerror="alert(1)"> & markup`)
+
+			require.NotEmpty(t2, clipboard.GetLatestClipboardItem().Id)
+
+			searchResults, err := clipboard.SearchClipboardItems(
+				context.Background(),
+				dto.SearchClipboardItemsQuery{Text: "markup"},
+			)
+
+			require.NoError(t2, err)
+			require.Len(t2, searchResults.Items, 1)
+			require.Equal(
+				t2,
+				`&lt;img src=x onerror=&#34;alert(1)&#34;&gt; &amp; <mark>markup</mark>`,
+				searchResults.Items[0].Snippet,
+			)
+		})
+	})
+}
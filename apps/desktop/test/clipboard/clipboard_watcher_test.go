@@ -0,0 +1,107 @@
+package clipboard
+
+import (
+	"testing"
+	"time"
+
+	"cloudy-clip/desktop/internal/clipboard"
+	"cloudy-clip/desktop/internal/clipboard/dto"
+	test "cloudy-clip/desktop/test/utils"
+
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	watcherPollInterval     = 10 * time.Millisecond
+	watcherDebounceInterval = 100 * time.Millisecond
+	// Long enough for the watcher to notice the change and for the debounce interval to pass.
+	watcherSettleDuration = 4 * watcherDebounceInterval
+)
+
+func TestClipboardWatcher(t1 *testing.T) {
+	test.Integration(t1, func(backend *clipboard.InMemoryClipboardBackend) {
+		t1.Run("1. only captures the last of several rapid copies", func(t2 *testing.T) {
+			watcher, capturedItems := startClipboardWatcher(t2)
+			defer watcher.Stop()
+
+			for _, text := range []string{"Rapid copy 1", "Rapid copy 2", "Rapid copy 3"} {
+				writeText(t2, backend, text)
+				time.Sleep(watcherPollInterval)
+			}
+
+			item := requireCapturedItem(t2, capturedItems)
+
+			require.Equal(t2, "Rapid copy 3", item.Content)
+			requireNoCapturedItem(t2, capturedItems)
+		})
+
+		t1.Run("2. never captures what is copied while paused", func(t2 *testing.T) {
+			watcher, capturedItems := startClipboardWatcher(t2)
+			defer watcher.Stop()
+
+			// Lets the watcher pick up the change count from before it started.
+			time.Sleep(watcherSettleDuration)
+			drainCapturedItems(capturedItems)
+
+			watcher.Pause()
+
+			require.True(t2, watcher.IsPaused())
+
+			writeText(t2, backend, "Copied while paused")
+
+			requireNoCapturedItem(t2, capturedItems)
+
+			watcher.Resume()
+
+			require.False(t2, watcher.IsPaused())
+			requireNoCapturedItem(t2, capturedItems)
+
+			writeText(t2, backend, "Copied after resuming")
+
+			require.Equal(t2, "Copied after resuming", requireCapturedItem(t2, capturedItems).Content)
+		})
+	})
+}
+
+func startClipboardWatcher(t *testing.T) (*clipboard.ClipboardWatcher, chan dto.ClipboardItem) {
+	capturedItems := make(chan dto.ClipboardItem, 10)
+	watcher := clipboard.NewClipboardWatcher(
+		watcherPollInterval,
+		watcherDebounceInterval,
+		func(item dto.ClipboardItem) {
+			capturedItems <- item
+		},
+	)
+	watcher.Start()
+
+	return watcher, capturedItems
+}
+
+func requireCapturedItem(t *testing.T, capturedItems chan dto.ClipboardItem) dto.ClipboardItem {
+	select {
+	case item := <-capturedItems:
+		return item
+	case <-time.After(watcherSettleDuration):
+		t.Fatal("no clipboard item was captured")
+
+		return dto.ClipboardItem{}
+	}
+}
+
+func requireNoCapturedItem(t *testing.T, capturedItems chan dto.ClipboardItem) {
+	select {
+	case item := <-capturedItems:
+		t.Fatalf("clipboard item '%s' was captured", item.Content)
+	case <-time.After(watcherSettleDuration):
+	}
+}
+
+func drainCapturedItems(capturedItems chan dto.ClipboardItem) {
+	for {
+		select {
+		case <-capturedItems:
+		default:
+			return
+		}
+	}
+}
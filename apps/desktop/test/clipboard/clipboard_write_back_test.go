@@ -0,0 +1,80 @@
+package clipboard
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"cloudy-clip/desktop/internal/clipboard"
+	"cloudy-clip/desktop/internal/common/exception"
+	test "cloudy-clip/desktop/test/utils"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClipboardWriteBack(t1 *testing.T) {
+	test.Integration(t1, func(backend *clipboard.InMemoryClipboardBackend) {
+		t1.Run("1. writes the item back with its rich content and moves it to the top", func(t2 *testing.T) {
+			text := "Written back"
+			html := "<u>Written back</u>"
+
+			err := backend.WriteItem(clipboard.ClipboardContent{Text: &text, Html: &html})
+
+			require.NoError(t2, err)
+
+			item := clipboard.GetLatestClipboardItem()
+
+			// Items are ordered by the millisecond they were captured at.
+			time.Sleep(2 * time.Millisecond)
+			writeText(t2, backend, "Copied afterwards")
+
+			newerItem := clipboard.GetLatestClipboardItem()
+
+			time.Sleep(2 * time.Millisecond)
+
+			copiedItem, err := clipboard.CopyToClipboard(context.Background(), item.Id)
+
+			require.NoError(t2, err)
+			require.Equal(t2, item.Id, copiedItem.Id)
+			require.Greater(t2, copiedItem.CreatedAt, newerItem.CreatedAt)
+
+			content, err := backend.ReadLatestItem()
+
+			require.NoError(t2, err)
+			require.Equal(t2, &text, content.Text)
+			require.Equal(t2, &html, content.Html)
+		})
+
+		t1.Run("2. does not capture the item that was written back", func(t2 *testing.T) {
+			writeText(t2, backend, "Copied before watching")
+			item := clipboard.GetLatestClipboardItem()
+
+			writeText(t2, backend, "Copied while watching")
+
+			watcher, capturedItems := startClipboardWatcher(t2)
+			defer watcher.Stop()
+
+			require.Equal(t2, "Copied while watching", requireCapturedItem(t2, capturedItems).Content)
+
+			_, err := clipboard.CopyToClipboard(context.Background(), item.Id)
+
+			require.NoError(t2, err)
+
+			// Would the watcher capture the write back, it could no longer tell it apart from a new copy.
+			err = clipboard.DeleteClipboardItem(context.Background(), item.Id)
+
+			require.NoError(t2, err)
+			requireNoCapturedItem(t2, capturedItems)
+
+			writeText(t2, backend, "Copied after the write back")
+
+			require.Equal(t2, "Copied after the write back", requireCapturedItem(t2, capturedItems).Content)
+		})
+
+		t1.Run("3. returns not found for an item that does not exist", func(t2 *testing.T) {
+			_, err := clipboard.CopyToClipboard(context.Background(), "01J7EVKDD9V675PRGH48K6YANA")
+
+			require.True(t2, exception.IsOfExceptionType[exception.NotFoundException](err), err)
+		})
+	})
+}
@@ -0,0 +1,234 @@
+package ignorerule
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/png"
+	"strings"
+	"testing"
+	"time"
+
+	"cloudy-clip/desktop/internal/clipboard"
+	_clipboardDto "cloudy-clip/desktop/internal/clipboard/dto"
+	"cloudy-clip/desktop/internal/common/database"
+	"cloudy-clip/desktop/internal/common/exception"
+	"cloudy-clip/desktop/internal/ignorerule"
+	"cloudy-clip/desktop/internal/ignorerule/dto"
+	test "cloudy-clip/desktop/test/utils"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIgnoreRules(t1 *testing.T) {
+	test.Integration(t1, func(backend *clipboard.InMemoryClipboardBackend) {
+		t1.Run("1. rejects an invalid pattern when saving and when testing a draft rule", func(t2 *testing.T) {
+			pattern := "([a-z]+"
+			request := dto.SaveIgnoreRuleRequest{
+				Name:      "Unclosed group",
+				Pattern:   &pattern,
+				IsEnabled: true,
+			}
+
+			_, err := ignorerule.CreateIgnoreRule(context.Background(), request)
+
+			requireInvalidField(t2, err, "pattern")
+
+			_, err = ignorerule.TestIgnoreRules(
+				context.Background(),
+				dto.TestIgnoreRulesRequest{Content: "abc", DraftRule: &request},
+			)
+
+			requireInvalidField(t2, err, "pattern")
+
+			ignoreRules, err := ignorerule.GetIgnoreRules(context.Background())
+
+			require.NoError(t2, err)
+			require.Empty(t2, ignoreRules)
+		})
+
+		t1.Run("2. rejects lengths that are not positive or out of order", func(t2 *testing.T) {
+			for _, testCase := range []struct {
+				minLength    *int32
+				maxLength    *int32
+				invalidField string
+			}{
+				{minLength: pointer[int32](0), invalidField: "minLength"},
+				{maxLength: pointer[int32](0), invalidField: "maxLength"},
+				{minLength: pointer[int32](10), maxLength: pointer[int32](9), invalidField: "maxLength"},
+			} {
+				_, err := ignorerule.CreateIgnoreRule(context.Background(), dto.SaveIgnoreRuleRequest{
+					Name:      "Length",
+					MinLength: testCase.minLength,
+					MaxLength: testCase.maxLength,
+				})
+
+				requireInvalidField(t2, err, testCase.invalidField)
+			}
+		})
+
+		t1.Run("3. ignores content whose length is within the bounds, both included", func(t2 *testing.T) {
+			ignoreRule, err := ignorerule.CreateIgnoreRule(context.Background(), dto.SaveIgnoreRuleRequest{
+				Name:      "Between 5 and 10 characters",
+				MinLength: pointer[int32](5),
+				MaxLength: pointer[int32](10),
+				IsEnabled: true,
+			})
+
+			require.NoError(t2, err)
+
+			defer deleteIgnoreRule(t2, ignoreRule.Id)
+
+			for _, testCase := range []struct {
+				content   string
+				isIgnored bool
+			}{
+				{content: "abcd", isIgnored: false},
+				{content: "abcde", isIgnored: true},
+				// Characters are counted rather than bytes.
+				{content: "ééééé", isIgnored: true},
+				{content: strings.Repeat("a", 10), isIgnored: true},
+				{content: strings.Repeat("é", 10), isIgnored: true},
+				{content: strings.Repeat("a", 11), isIgnored: false},
+				// Surrounding whitespace is trimmed the same way as when the content is captured.
+				{content: "  abcd  ", isIgnored: false},
+			} {
+				testResult, err := ignorerule.TestIgnoreRules(
+					context.Background(),
+					dto.TestIgnoreRulesRequest{Content: testCase.content},
+				)
+
+				require.NoError(t2, err, testCase.content)
+				require.Equal(t2, testCase.isIgnored, testResult.IsIgnored, testCase.content)
+			}
+		})
+
+		t1.Run("4. only ignores the content types that the rule lists", func(t2 *testing.T) {
+			ignoreRule, err := ignorerule.CreateIgnoreRule(context.Background(), dto.SaveIgnoreRuleRequest{
+				Name:         "Images",
+				ContentTypes: []_clipboardDto.ClipboardItemType{_clipboardDto.ClipboardItemTypeImage},
+				IsEnabled:    true,
+			})
+
+			require.NoError(t2, err)
+
+			defer deleteIgnoreRule(t2, ignoreRule.Id)
+
+			for _, testCase := range []struct {
+				contentType _clipboardDto.ClipboardItemType
+				isIgnored   bool
+			}{
+				{contentType: _clipboardDto.ClipboardItemTypeImage, isIgnored: true},
+				{contentType: _clipboardDto.ClipboardItemTypeText, isIgnored: false},
+				{contentType: _clipboardDto.ClipboardItemTypeUrl, isIgnored: false},
+			} {
+				testResult, err := ignorerule.TestIgnoreRules(
+					context.Background(),
+					dto.TestIgnoreRulesRequest{Content: "https://example.com", ContentType: testCase.contentType},
+				)
+
+				require.NoError(t2, err)
+				require.Equal(t2, testCase.isIgnored, testResult.IsIgnored, testCase.contentType)
+			}
+
+			pattern := "^secret"
+			testResult, err := ignorerule.TestIgnoreRules(
+				context.Background(),
+				dto.TestIgnoreRulesRequest{
+					Content:     "secret",
+					ContentType: _clipboardDto.ClipboardItemTypeImage,
+					DraftRule: &dto.SaveIgnoreRuleRequest{
+						Name:    "Secrets",
+						Pattern: &pattern,
+					},
+				},
+			)
+
+			require.NoError(t2, err)
+			// Images have no text for the pattern to match.
+			require.False(t2, testResult.IsDraftRuleMatched)
+
+			var imageBuffer bytes.Buffer
+			err = png.Encode(&imageBuffer, image.NewRGBA(image.Rect(0, 0, 1, 1)))
+
+			require.NoError(t2, err)
+
+			err = backend.WriteItem(clipboard.NewImageClipboardContent(imageBuffer.Bytes()))
+
+			require.NoError(t2, err)
+			require.Empty(t2, clipboard.GetLatestClipboardItem().Id)
+		})
+
+		t1.Run("5. keeps capture paused in incognito mode across restarts until it ends", func(t2 *testing.T) {
+			incognitoMode, err := ignorerule.StartIncognitoMode(
+				context.Background(),
+				dto.StartIncognitoModeRequest{DurationMinutes: 1},
+			)
+
+			require.NoError(t2, err)
+			require.True(t2, incognitoMode.IsActive)
+
+			database.Close()
+			database.InitializeDatabaseClient()
+
+			isActive, err := ignorerule.IsIncognitoModeActive(time.Now())
+
+			require.NoError(t2, err)
+			require.True(t2, isActive)
+
+			writeText(t2, backend, "Copied in incognito mode")
+
+			require.Empty(t2, clipboard.GetLatestClipboardItem().Id)
+
+			endsAt := time.UnixMilli(int64(incognitoMode.EndsAt))
+
+			isActive, err = ignorerule.IsIncognitoModeActive(endsAt.Add(-time.Millisecond))
+
+			require.NoError(t2, err)
+			require.True(t2, isActive)
+
+			isActive, err = ignorerule.IsIncognitoModeActive(endsAt)
+
+			require.NoError(t2, err)
+			require.False(t2, isActive)
+
+			incognitoMode, err = ignorerule.StopIncognitoMode(context.Background())
+
+			require.NoError(t2, err)
+			require.Equal(t2, dto.IncognitoMode{}, incognitoMode)
+			require.NotEmpty(t2, clipboard.GetLatestClipboardItem().Id)
+		})
+
+		t1.Run("6. rejects incognito mode durations outside of 1 minute and 24 hours", func(t2 *testing.T) {
+			for _, durationMinutes := range []int32{0, 24*60 + 1} {
+				_, err := ignorerule.StartIncognitoMode(
+					context.Background(),
+					dto.StartIncognitoModeRequest{DurationMinutes: durationMinutes},
+				)
+
+				requireInvalidField(t2, err, "durationMinutes")
+			}
+		})
+	})
+}
+
+func requireInvalidField(t *testing.T, err error, fieldName string) {
+	require.True(t, exception.IsOfExceptionType[exception.ValidationException](err), err)
+	require.Contains(t, exception.GetAsApplicationException(err, "").GetExtra(), fieldName)
+}
+
+func deleteIgnoreRule(t *testing.T, ruleId string) {
+	err := ignorerule.DeleteIgnoreRule(context.Background(), ruleId)
+
+	require.NoError(t, err)
+}
+
+func writeText(t *testing.T, backend *clipboard.InMemoryClipboardBackend, text string) {
+	err := backend.WriteItem(clipboard.NewTextClipboardContent(text))
+
+	require.NoError(t, err)
+}
+
+func pointer[T any](value T) *T {
+	return &value
+}
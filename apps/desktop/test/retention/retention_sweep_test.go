@@ -0,0 +1,81 @@
+package retention
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"cloudy-clip/desktop/internal/clipboard"
+	"cloudy-clip/desktop/internal/retention"
+	"cloudy-clip/desktop/internal/retention/dto"
+	test "cloudy-clip/desktop/test/utils"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetentionSweep(t1 *testing.T) {
+	test.Integration(t1, func(backend *clipboard.InMemoryClipboardBackend) {
+		defer retention.SetPlanRetentionPeriod(nil)
+
+		t1.Run("1. keeps every item when neither the policy nor the plan limits their age", func(t2 *testing.T) {
+			captureText(t2, backend, "Kept forever")
+
+			evictedItemCount, err := retention.SweepClipboardHistory(context.Background(), time.Now().AddDate(1, 0, 0))
+
+			require.NoError(t2, err)
+			require.Zero(t2, evictedItemCount)
+		})
+
+		t1.Run("2. falls back to the retention period of the plan", func(t2 *testing.T) {
+			planRetentionPeriodDays := int32(30)
+			retention.SetPlanRetentionPeriod(&planRetentionPeriodDays)
+
+			retentionPolicy, err := retention.GetRetentionPolicy(context.Background())
+
+			require.NoError(t2, err)
+			require.Equal(t2, &planRetentionPeriodDays, retentionPolicy.EffectiveMaxAgeDays())
+
+			evictedItemCount, err := retention.SweepClipboardHistory(context.Background(), time.Now().AddDate(0, 0, 29))
+
+			require.NoError(t2, err)
+			require.Zero(t2, evictedItemCount)
+
+			evictedItemCount, err = retention.SweepClipboardHistory(context.Background(), time.Now().AddDate(0, 0, 31))
+
+			require.NoError(t2, err)
+			require.Equal(t2, 1, evictedItemCount)
+		})
+
+		t1.Run("3. prefers the max age of the policy over the retention period of the plan", func(t2 *testing.T) {
+			planRetentionPeriodDays := int32(30)
+			retention.SetPlanRetentionPeriod(&planRetentionPeriodDays)
+
+			maxAgeDays := int32(60)
+			_, err := retention.UpdateRetentionPolicy(
+				context.Background(),
+				dto.UpdateRetentionPolicyRequest{MaxAgeDays: &maxAgeDays},
+			)
+
+			require.NoError(t2, err)
+
+			captureText(t2, backend, "Kept for 60 days")
+
+			evictedItemCount, err := retention.SweepClipboardHistory(context.Background(), time.Now().AddDate(0, 0, 31))
+
+			require.NoError(t2, err)
+			require.Zero(t2, evictedItemCount)
+
+			evictedItemCount, err = retention.SweepClipboardHistory(context.Background(), time.Now().AddDate(0, 0, 61))
+
+			require.NoError(t2, err)
+			require.Equal(t2, 1, evictedItemCount)
+		})
+	})
+}
+
+func captureText(t *testing.T, backend *clipboard.InMemoryClipboardBackend, text string) {
+	err := backend.WriteItem(clipboard.NewTextClipboardContent(text))
+
+	require.NoError(t, err)
+	require.NotEmpty(t, clipboard.GetLatestClipboardItem().Id)
+}
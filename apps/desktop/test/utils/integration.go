@@ -0,0 +1,55 @@
+package test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"cloudy-clip/desktop/internal/clipboard"
+	"cloudy-clip/desktop/internal/common/database"
+	"cloudy-clip/desktop/internal/common/encryption"
+	"cloudy-clip/desktop/internal/common/environment"
+	"cloudy-clip/desktop/internal/common/utils"
+
+	"github.com/joho/godotenv"
+)
+
+// Integration runs the test group against a database of its own, which is deleted afterwards, and
+// against an in-memory clipboard and key store in place of the system ones.
+func Integration(t *testing.T, testGroup func(backend *clipboard.InMemoryClipboardBackend)) {
+	// Migrations are looked up relative to the working directory.
+	workingDirectory, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("failed to get working directory; error was %v\n", err)
+	}
+
+	err = os.Chdir(environment.ProjectRoot)
+	if err != nil {
+		t.Fatalf("failed to change working directory; error was %v\n", err)
+	}
+
+	defer os.Chdir(workingDirectory)
+
+	err = godotenv.Load(filepath.Join(environment.ProjectRoot, ".env."+string(environment.ExecutionProfileTest)))
+	if err != nil {
+		t.Fatalf("failed to load env file; error was %v\n", err)
+	}
+
+	environment.Initialize(environment.ExecutionProfileTest)
+	environment.Config.DatabaseName = "test-" + utils.Generate()
+
+	// The database, images and encryption key are all kept in a directory that is removed afterwards.
+	utils.SetAppHomeDirectory(t.TempDir())
+
+	database.InitializeDatabaseClient()
+	defer database.Close()
+
+	clipboard.SetEncryptionKeyStore(encryption.NewInMemoryKeyStore())
+	clipboard.InitializeEncryption(context.Background())
+
+	backend := clipboard.NewInMemoryClipboardBackend()
+	clipboard.SetClipboardBackend(backend)
+
+	testGroup(backend)
+}